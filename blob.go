@@ -7,10 +7,16 @@ import (
 	"io"
 
 	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/datamodel"
 )
 
 // DecodeBlob fills a NodeAssembler (from `Type.Blob__Repr.NewBuilder()`) from a stream of bytes
 func DecodeBlob(na ipld.NodeAssembler, rd *bufio.Reader) error {
+	return DecodeOptions{}.DecodeBlob(na, rd)
+}
+
+// DecodeBlob fills a NodeAssembler (from `Type.Blob__Repr.NewBuilder()`) from a stream of bytes
+func (o DecodeOptions) DecodeBlob(na ipld.NodeAssembler, rd *bufio.Reader) error {
 	sizen, err := readNullTerminatedNumber(rd)
 	if err != nil {
 		return err
@@ -19,31 +25,72 @@ func DecodeBlob(na ipld.NodeAssembler, rd *bufio.Reader) error {
 		return fmt.Errorf("invalid blob size: %d", sizen)
 	}
 
-	prefix := fmt.Sprintf("blob %d\x00", sizen)
+	// Only a BlobContent node encodes with the header restored, so decoding
+	// the content alone into any other node would lose it.
+	if _, ok := na.(*blobContentBuilder); o.BlobContent && !ok {
+		return fmt.Errorf("decoding blob content into a %T: only BlobContentPrototype can hold a blob without its header", na)
+	}
+
+	// A streaming blob reads its body from its source when it is read, so the
+	// body is only read through here, for a LinkSystem to hash.
+	if nb, ok := na.(*streamingBlobBuilder); ok {
+		if err := readBlobBody(io.Discard, rd, sizen); err != nil {
+			return err
+		}
+		nb.decoded(int64(sizen))
+		return nil
+	}
 
 	// The header's size is unverified until the body arrives, so grow the
 	// buffer as it is read rather than reserving the declared size up front.
 	var buf bytes.Buffer
-	buf.WriteString(prefix)
+	if _, ok := na.(*blobContentBuilder); !ok {
+		fmt.Fprintf(&buf, "blob %d\x00", sizen)
+	}
+	if err := readBlobBody(&buf, rd, sizen); err != nil {
+		return err
+	}
+	return na.AssignBytes(buf.Bytes())
+}
 
-	n, err := io.Copy(&buf, io.LimitReader(rd, int64(sizen)))
+// readBlobBody copies a blob body of size bytes to w.
+func readBlobBody(w io.Writer, rd io.Reader, size int) error {
+	n, err := io.Copy(w, io.LimitReader(rd, int64(size)))
 	if err != nil {
 		return err
 	}
 
 	// Match io.ReadFull: EOF if the body was entirely absent, ErrUnexpectedEOF
 	// if it was short.
-	if n != int64(sizen) {
+	if n != int64(size) {
 		if n == 0 {
 			return io.EOF
 		}
 		return io.ErrUnexpectedEOF
 	}
-
-	return na.AssignBytes(buf.Bytes())
+	return nil
 }
 
 func encodeBlob(n ipld.Node, w io.Writer) error {
+	if c, ok := n.(BlobContent); ok {
+		if _, err := fmt.Fprintf(w, "blob %d\x00", len(c)); err != nil {
+			return err
+		}
+		_, err := w.Write(c)
+		return err
+	}
+	if lb, ok := n.(datamodel.LargeBytesNode); ok {
+		rs, err := lb.AsLargeBytes()
+		if err != nil {
+			return err
+		}
+		if c, ok := rs.(io.Closer); ok {
+			defer c.Close()
+		}
+		_, err = io.Copy(w, rs)
+		return err
+	}
+
 	b, err := n.AsBytes()
 	if err != nil {
 		return err
@@ -52,3 +99,21 @@ func encodeBlob(n ipld.Node, w io.Writer) error {
 	_, err = w.Write(b)
 	return err
 }
+
+// Content returns the content of the blob, without the "blob <size>\x00" header
+// its bytes start with.
+func (n Blob) Content() ([]byte, error) {
+	return splitBlob(n.x)
+}
+
+// splitBlob returns the content of a blob in its loose form.
+func splitBlob(b []byte) ([]byte, error) {
+	hdr, content, ok := bytes.Cut(b, []byte{0})
+	if !ok {
+		return nil, fmt.Errorf("blob has no header")
+	}
+	if string(hdr) != fmt.Sprintf("blob %d", len(content)) {
+		return nil, fmt.Errorf("blob header %q does not match its %d byte content", hdr, len(content))
+	}
+	return content, nil
+}
@@ -0,0 +1,168 @@
+package ipldgit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+var (
+	_ datamodel.LargeBytesNode = BlobContent(nil)
+	_ ipld.NodeBuilder         = &blobContentBuilder{}
+)
+
+// BlobContent is a blob node holding only the content of the blob. Unlike a
+// Blob, whose bytes start with the "blob <size>\x00" header, its bytes are the
+// file contents alone. Encode restores the header, so a BlobContent has the
+// same link as the Blob with the same content.
+type BlobContent []byte
+
+// BlobContentPrototype builds BlobContent nodes. Blobs decoded into it, such as
+// by loading a link with it, have their header removed whatever the
+// DecodeOptions.
+var BlobContentPrototype ipld.NodePrototype = blobContentPrototype{}
+
+// NewBlob returns a Blob holding content, with its header.
+func NewBlob(content []byte) Blob {
+	b, _ := Type.Blob.FromBytes(append(fmt.Appendf(nil, "blob %d\x00", len(content)), content...))
+	return b
+}
+
+// Blob returns the Blob with the same content, header included.
+func (n BlobContent) Blob() Blob {
+	return NewBlob(n)
+}
+
+func (BlobContent) Kind() ipld.Kind {
+	return ipld.Kind_Bytes
+}
+func (BlobContent) LookupByString(string) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.LookupByString("")
+}
+func (BlobContent) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.LookupByNode(nil)
+}
+func (BlobContent) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.LookupByIndex(0)
+}
+func (BlobContent) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.LookupBySegment(seg)
+}
+func (BlobContent) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (BlobContent) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (BlobContent) Length() int64 {
+	return -1
+}
+func (BlobContent) IsAbsent() bool {
+	return false
+}
+func (BlobContent) IsNull() bool {
+	return false
+}
+func (BlobContent) AsBool() (bool, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.AsBool()
+}
+func (BlobContent) AsInt() (int64, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.AsInt()
+}
+func (BlobContent) AsFloat() (float64, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.AsFloat()
+}
+func (BlobContent) AsString() (string, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.AsString()
+}
+func (n BlobContent) AsBytes() ([]byte, error) {
+	return []byte(n), nil
+}
+func (BlobContent) AsLink() (ipld.Link, error) {
+	return mixins.Bytes{TypeName: "ipldgit.BlobContent"}.AsLink()
+}
+func (BlobContent) Prototype() ipld.NodePrototype {
+	return BlobContentPrototype
+}
+func (n BlobContent) AsLargeBytes() (io.ReadSeeker, error) {
+	return bytes.NewReader(n), nil
+}
+
+type blobContentPrototype struct{}
+
+func (blobContentPrototype) NewBuilder() ipld.NodeBuilder {
+	return &blobContentBuilder{}
+}
+
+type blobContentBuilder struct {
+	w BlobContent
+}
+
+func (nb *blobContentBuilder) Build() ipld.Node {
+	return nb.w
+}
+func (nb *blobContentBuilder) Reset() {
+	nb.w = nil
+}
+func (blobContentBuilder) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.BeginMap(0)
+}
+func (blobContentBuilder) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.BeginList(0)
+}
+func (blobContentBuilder) AssignNull() error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignNull()
+}
+func (blobContentBuilder) AssignBool(bool) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignBool(false)
+}
+func (blobContentBuilder) AssignInt(int64) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignInt(0)
+}
+func (blobContentBuilder) AssignFloat(float64) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignFloat(0)
+}
+func (blobContentBuilder) AssignString(string) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignString("")
+}
+func (nb *blobContentBuilder) AssignBytes(v []byte) error {
+	nb.w = BlobContent(v)
+	return nil
+}
+func (blobContentBuilder) AssignLink(ipld.Link) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.BlobContent"}.AssignLink(nil)
+}
+
+// AssignNode assigns the content of a bytes node. A Blob or StreamingBlob has
+// its header removed.
+func (nb *blobContentBuilder) AssignNode(v ipld.Node) error {
+	var (
+		b   []byte
+		err error
+	)
+	switch v := v.(type) {
+	case BlobContent:
+		b = v
+	case Blob:
+		b, err = v.Content()
+	case *StreamingBlob:
+		b, err = v.AsBytes()
+		if err == nil {
+			b, err = splitBlob(b)
+		}
+	default:
+		b, err = v.AsBytes()
+	}
+	if err != nil {
+		return err
+	}
+	nb.w = BlobContent(b)
+	return nil
+}
+func (blobContentBuilder) Prototype() ipld.NodePrototype {
+	return BlobContentPrototype
+}
@@ -0,0 +1,105 @@
+package ipldgit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+)
+
+func TestBlobContent(t *testing.T) {
+	raw := []byte("blob 12\x00Hello world\n")
+
+	n, err := ParseObjectFromBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := n.(Blob).Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "Hello world\n" {
+		t.Fatalf("unexpected content %q", content)
+	}
+
+	n, err = DecodeOptions{BlobContent: true}.ParseObjectFromBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := n.AsBytes(); string(b) != "Hello world\n" {
+		t.Fatalf("unexpected content %q", b)
+	}
+	buf := new(bytes.Buffer)
+	if err := Encode(n, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), raw) {
+		t.Fatalf("blob content encodes to %q", buf.Bytes())
+	}
+
+	// Decoding the content alone into another prototype would lose the header
+	// on encoding, so it fails.
+	for _, np := range []ipld.NodePrototype{Type.Blob, basicnode.Prototype.Bytes, StreamingBlobPrototype(nil)} {
+		nb := np.NewBuilder()
+		if err := (DecodeOptions{BlobContent: true}).Decode(nb, bytes.NewReader(raw)); err == nil {
+			t.Fatalf("decoded blob content into %T", nb)
+		}
+	}
+	nb := BlobContentPrototype.NewBuilder()
+	if err := (DecodeOptions{BlobContent: true}).Decode(nb, bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := nb.Build().AsBytes(); string(b) != "Hello world\n" {
+		t.Fatalf("unexpected content %q", b)
+	}
+
+	// Storing a BlobContent and loading it back as a Blob, and back again,
+	// keeps its link.
+	ls := cidlink.DefaultLinkSystem()
+	store := &memstore.Store{}
+	ls.SetReadStorage(store)
+	ls.SetWriteStorage(store)
+	lp := cidlink.LinkPrototype{Prefix: ObjectFormatSHA1.Prefix()}
+	lnk, err := ls.Store(ipld.LinkContext{}, lp, BlobContent("Hello world\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha, _ := hex.DecodeString("802992c4220de19a90767f3000a79a31b98d0df7")
+	if c, _ := ObjectFormatSHA1.Cid(sha); lnk.(cidlink.Link).Cid != c {
+		t.Fatalf("unexpected link %s", lnk)
+	}
+	blob, err := ls.Load(ipld.LinkContext{}, lnk, Type.Blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := blob.AsBytes(); !bytes.Equal(b, raw) || ls.MustComputeLink(lp, NewBlob([]byte("Hello world\n"))) != lnk {
+		t.Fatalf("unexpected blob %q", b)
+	}
+	n, err = ls.Load(ipld.LinkContext{}, lnk, BlobContentPrototype)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := n.AsBytes(); string(b) != "Hello world\n" {
+		t.Fatalf("unexpected content %q", b)
+	}
+
+	nb = BlobContentPrototype.NewBuilder()
+	if err := nb.AssignNode(blob); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := nb.Build().AsBytes(); string(b) != "Hello world\n" {
+		t.Fatalf("unexpected content %q", b)
+	}
+
+	if _, err := NewBlob(nil).Content(); err != nil {
+		t.Fatal(err)
+	}
+	bad, _ := Type.Blob.FromBytes([]byte("blob 3\x00ab"))
+	if _, err := bad.Content(); err == nil {
+		t.Fatal("expected an error for a blob shorter than its header says")
+	}
+}
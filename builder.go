@@ -0,0 +1,469 @@
+package ipldgit
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// The builders below make commits, trees and tags from typed values, checking
+// them as they go. Their methods return the builder, so that calls can be
+// chained, and remember the first error, which Build returns.
+
+// ObjectCid returns the CID of the object n in format f, as git names it.
+func ObjectCid(n ipld.Node, f ObjectFormat) (cid.Cid, error) {
+	if f == ObjectFormatDefault {
+		f = ObjectFormatSHA1
+	}
+	h := f.NewHash()
+	if err := (EncodeOptions{ObjectFormat: f}).Encode(n, h); err != nil {
+		return cid.Undef, err
+	}
+	return f.Cid(h.Sum(nil))
+}
+
+// BuildBlob returns the blob holding content, and its CID in format f.
+func BuildBlob(content []byte, f ObjectFormat) (Blob, cid.Cid, error) {
+	b := NewBlob(content)
+	c, err := ObjectCid(b, f)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return b, c, nil
+}
+
+// builderFormat returns the format objects are built in for f, which is SHA-1
+// when left unset.
+func builderFormat(f ObjectFormat) (ObjectFormat, error) {
+	if !f.valid() {
+		return f, fmt.Errorf("unsupported object format: %s", f)
+	}
+	if f == ObjectFormatDefault {
+		return ObjectFormatSHA1, nil
+	}
+	return f, nil
+}
+
+// checkLink checks that c is a git object hash in format f.
+func checkLink(what string, c cid.Cid, f ObjectFormat) error {
+	if !c.Defined() {
+		return fmt.Errorf("%s is not set", what)
+	}
+	if c.Type() != cid.GitRaw {
+		return fmt.Errorf("%s %s is not a git object", what, c)
+	}
+	if _, err := cidToSha(c, f); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	return nil
+}
+
+// checkPerson checks that a person info is written and read back the same,
+// with a valid time.
+func checkPerson(what string, p PersonInfo) error {
+	if p == nil {
+		return fmt.Errorf("%s is not set", what)
+	}
+	if err := checkNameEmail(p.FieldName().String(), p.FieldEmail().String()); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	if _, ok := decodePersonHeader(what, what+" "+p.GitString()); !ok {
+		return fmt.Errorf("invalid %s %q", what, p.GitString())
+	}
+	if _, err := p.Time(); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	return nil
+}
+
+// CommitBuilder makes a commit. The tree, author and committer must be set.
+type CommitBuilder struct {
+	format    ObjectFormat
+	tree      cid.Cid
+	parents   []cid.Cid
+	author    PersonInfo
+	committer PersonInfo
+	message   string
+	encoding  string
+	err       error
+}
+
+// NewCommitBuilder returns a builder of a commit in format f, whose links must
+// all be in that format. ObjectFormatDefault builds a SHA-1 commit.
+func NewCommitBuilder(f ObjectFormat) *CommitBuilder {
+	b := &CommitBuilder{}
+	b.format, b.err = builderFormat(f)
+	return b
+}
+
+// Tree sets the tree of the commit.
+func (b *CommitBuilder) Tree(tree cid.Cid) *CommitBuilder {
+	if b.err == nil {
+		b.err = checkLink("tree", tree, b.format)
+	}
+	b.tree = tree
+	return b
+}
+
+// Parents sets the parents of the commit, none for a root commit, and more than
+// one for a merge. The same parent may not be given twice.
+func (b *CommitBuilder) Parents(parents ...cid.Cid) *CommitBuilder {
+	for i, p := range parents {
+		if b.err != nil {
+			break
+		}
+		if b.err = checkLink("parent", p, b.format); b.err == nil && slices.Contains(parents[:i], p) {
+			b.err = fmt.Errorf("duplicate parent %s", p)
+		}
+	}
+	b.parents = parents
+	return b
+}
+
+// Author sets who wrote the change, and when.
+func (b *CommitBuilder) Author(author PersonInfo) *CommitBuilder {
+	if b.err == nil {
+		b.err = checkPerson("author", author)
+	}
+	b.author = author
+	return b
+}
+
+// Committer sets who made the commit, and when.
+func (b *CommitBuilder) Committer(committer PersonInfo) *CommitBuilder {
+	if b.err == nil {
+		b.err = checkPerson("committer", committer)
+	}
+	b.committer = committer
+	return b
+}
+
+// Message sets the message of the commit, which is written as it is: git ends
+// it with a newline.
+func (b *CommitBuilder) Message(message string) *CommitBuilder {
+	b.message = message
+	return b
+}
+
+// Encoding sets the encoding of the commit, such as "ISO-8859-1", when it is
+// not UTF-8. The message and the names and emails of the author and committer
+// are given in UTF-8, and Build converts them into the encoding, failing if it
+// cannot represent them or if a name or email becomes invalid in a header.
+func (b *CommitBuilder) Encoding(encoding string) *CommitBuilder {
+	if b.err == nil && (encoding == "" || strings.ContainsAny(encoding, " \n")) {
+		b.err = fmt.Errorf("invalid encoding %q", encoding)
+	}
+	if b.err == nil {
+		_, b.err = lookupEncoding(encoding)
+	}
+	b.encoding = encoding
+	return b
+}
+
+// Build returns the commit and its CID.
+func (b *CommitBuilder) Build() (Commit, cid.Cid, error) {
+	if b.err != nil {
+		return nil, cid.Undef, b.err
+	}
+	for _, check := range []error{
+		checkLink("tree", b.tree, b.format),
+		checkPerson("author", b.author),
+		checkPerson("committer", b.committer),
+	} {
+		if check != nil {
+			return nil, cid.Undef, check
+		}
+	}
+
+	message, err := EncodeText(b.encoding, b.message)
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("message: %w", err)
+	}
+	author, err := b.author.transcode(b.encoding, EncodeText)
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("author: %w", err)
+	}
+	committer, err := b.committer.transcode(b.encoding, EncodeText)
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("committer: %w", err)
+	}
+	// The encoding may turn characters into bytes that end a person header
+	// early, such as '>' or a newline, so check the values written too.
+	for _, check := range []error{
+		checkPerson("author", author),
+		checkPerson("committer", committer),
+	} {
+		if check != nil {
+			return nil, cid.Undef, fmt.Errorf("encoded as %s: %w", b.encoding, check)
+		}
+	}
+
+	c := &_Commit{
+		tree:      _Tree_Link{cidlink.Link{Cid: b.tree}},
+		parents:   _Commit_Link_List{x: make([]_Commit_Link, len(b.parents))},
+		message:   _String{message},
+		author:    _PersonInfo__Maybe{m: schema.Maybe_Value, v: author},
+		committer: _PersonInfo__Maybe{m: schema.Maybe_Value, v: committer},
+	}
+	for i, p := range b.parents {
+		c.parents.x[i] = _Commit_Link{cidlink.Link{Cid: p}}
+	}
+	if b.encoding != "" {
+		c.encoding = _String__Maybe{m: schema.Maybe_Value, v: _String{b.encoding}}
+	}
+	id, err := ObjectCid(c, b.format)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return c, id, nil
+}
+
+// TreeBuilder makes a tree. Entries may be added in any order, and are sorted
+// the way git sorts them.
+type TreeBuilder struct {
+	format  ObjectFormat
+	entries map[string]_TreeEntry
+	err     error
+}
+
+// NewTreeBuilder returns a builder of a tree in format f, whose entries must
+// all be in that format. ObjectFormatDefault builds a SHA-1 tree.
+func NewTreeBuilder(f ObjectFormat) *TreeBuilder {
+	b := &TreeBuilder{entries: make(map[string]_TreeEntry)}
+	b.format, b.err = builderFormat(f)
+	return b
+}
+
+// AddFile adds a file whose content is a blob, executable or not.
+func (b *TreeBuilder) AddFile(name string, blob cid.Cid, executable bool) *TreeBuilder {
+	if executable {
+		return b.add(name, EntryExecutable, blob)
+	}
+	return b.add(name, EntryFile, blob)
+}
+
+// AddDir adds a directory whose content is a tree.
+func (b *TreeBuilder) AddDir(name string, tree cid.Cid) *TreeBuilder {
+	return b.add(name, EntryDirectory, tree)
+}
+
+// AddSymlink adds a symbolic link whose target is the content of a blob.
+func (b *TreeBuilder) AddSymlink(name string, target cid.Cid) *TreeBuilder {
+	return b.add(name, EntrySymlink, target)
+}
+
+// AddSubmodule adds a submodule checked out at a commit of another repository.
+func (b *TreeBuilder) AddSubmodule(name string, commit cid.Cid) *TreeBuilder {
+	return b.add(name, EntryGitlink, commit)
+}
+
+func (b *TreeBuilder) add(name string, kind EntryKind, c cid.Cid) *TreeBuilder {
+	if b.err != nil {
+		return b
+	}
+	if err := CheckEntryName(name); err != nil {
+		b.err = err
+		return b
+	}
+	if _, ok := b.entries[name]; ok {
+		b.err = fmt.Errorf("duplicate tree entry %q", name)
+		return b
+	}
+	if err := checkLink(fmt.Sprintf("%s %q", kind, name), c, b.format); err != nil {
+		b.err = err
+		return b
+	}
+	b.entries[name] = _TreeEntry{mode: _String{kind.Mode()}, hash: _Link{cidlink.Link{Cid: c}}}
+	return b
+}
+
+// CheckEntryName checks that name is a single path component that git accepts
+// in a tree and checks out: not "", "." or "..", without a slash or NUL, and
+// not a name that NTFS or HFS+ would open as .git. As in git, those are .git
+// in any case, .git or its short name git~1 followed by spaces, periods or an
+// NTFS stream, and .git holding code points HFS+ ignores.
+func CheckEntryName(name string) error {
+	switch {
+	case name == "", name == ".", name == "..":
+		return fmt.Errorf("invalid tree entry name %q", name)
+	case strings.ContainsAny(name, "/\x00"):
+		return fmt.Errorf("tree entry name %q is not a single path component", name)
+	case strings.EqualFold(name, ".git"), isNTFSDotGit(name), isHFSDotGit(name):
+		return fmt.Errorf("tree entry name %q is reserved by git", name)
+	}
+	return nil
+}
+
+// isNTFSDotGit reports whether NTFS opens name as .git: it is .git or git~1 in
+// any case, followed by spaces and periods, which NTFS drops, up to its end, a
+// backslash or the colon of a stream name.
+func isNTFSDotGit(name string) bool {
+	var rest string
+	switch {
+	case len(name) >= 4 && strings.EqualFold(name[:4], ".git"):
+		rest = name[4:]
+	case len(name) >= 5 && strings.EqualFold(name[:5], "git~1"):
+		rest = name[5:]
+	default:
+		return false
+	}
+	if i := strings.IndexAny(rest, ":\\"); i >= 0 {
+		rest = rest[:i]
+	}
+	return strings.Trim(rest, " .") == ""
+}
+
+// isHFSDotGit reports whether HFS+ opens name as .git, which it does when the
+// name is .git in any case once the code points it ignores are removed.
+func isHFSDotGit(name string) bool {
+	return strings.EqualFold(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x200c && r <= 0x200f, r >= 0x202a && r <= 0x202e, r >= 0x206a && r <= 0x206f, r == 0xfeff:
+			return -1
+		}
+		return r
+	}, name), ".git")
+}
+
+// Build returns the tree and its CID.
+func (b *TreeBuilder) Build() (Tree, cid.Cid, error) {
+	if b.err != nil {
+		return nil, cid.Undef, b.err
+	}
+
+	// The node is built in the order encoding writes the entries in, so that
+	// iterating it lists them as git does.
+	type entry struct {
+		name, key string
+	}
+	entries := make([]entry, 0, len(b.entries))
+	for name, te := range b.entries {
+		key, err := treeSortKey(name, &te)
+		if err != nil {
+			return nil, cid.Undef, err
+		}
+		entries = append(entries, entry{name, key})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	nb := Type.Tree.NewBuilder()
+	ma, err := nb.BeginMap(int64(len(entries)))
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	for _, e := range entries {
+		te := b.entries[e.name]
+		if err := ma.AssembleKey().AssignString(e.name); err != nil {
+			return nil, cid.Undef, err
+		}
+		if err := ma.AssembleValue().AssignNode(&te); err != nil {
+			return nil, cid.Undef, err
+		}
+	}
+	if err := ma.Finish(); err != nil {
+		return nil, cid.Undef, err
+	}
+	t := nb.Build().(Tree)
+	id, err := ObjectCid(t, b.format)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return t, id, nil
+}
+
+// TagBuilder makes an annotated tag. The object, name and tagger must be set.
+type TagBuilder struct {
+	format  ObjectFormat
+	object  cid.Cid
+	typ     string
+	name    string
+	tagger  PersonInfo
+	message string
+	err     error
+}
+
+// NewTagBuilder returns a builder of a tag in format f, whose object must be in
+// that format. ObjectFormatDefault builds a SHA-1 tag.
+func NewTagBuilder(f ObjectFormat) *TagBuilder {
+	b := &TagBuilder{}
+	b.format, b.err = builderFormat(f)
+	return b
+}
+
+// Object sets the object tagged, and its type: "commit", "tree", "blob" or
+// "tag".
+func (b *TagBuilder) Object(object cid.Cid, typ string) *TagBuilder {
+	if b.err == nil {
+		switch typ {
+		case "commit", "tree", "blob", "tag":
+			b.err = checkLink("object", object, b.format)
+		default:
+			b.err = fmt.Errorf("invalid object type %q", typ)
+		}
+	}
+	b.object, b.typ = object, typ
+	return b
+}
+
+// Name sets the name of the tag, such as "v1.0".
+func (b *TagBuilder) Name(name string) *TagBuilder {
+	if b.err == nil && (name == "" || strings.ContainsAny(name, " \n")) {
+		b.err = fmt.Errorf("invalid tag name %q", name)
+	}
+	b.name = name
+	return b
+}
+
+// Tagger sets who made the tag, and when.
+func (b *TagBuilder) Tagger(tagger PersonInfo) *TagBuilder {
+	if b.err == nil {
+		b.err = checkPerson("tagger", tagger)
+	}
+	b.tagger = tagger
+	return b
+}
+
+// Message sets the message of the tag. It may not hold a signature, which
+// signing the tag adds.
+func (b *TagBuilder) Message(message string) *TagBuilder {
+	if _, sig := splitTagMessage(message); b.err == nil && sig != "" {
+		b.err = fmt.Errorf("tag message holds a signature")
+	}
+	b.message = message
+	return b
+}
+
+// Build returns the tag and its CID.
+func (b *TagBuilder) Build() (Tag, cid.Cid, error) {
+	if b.err != nil {
+		return nil, cid.Undef, b.err
+	}
+	if err := checkLink("object", b.object, b.format); err != nil {
+		return nil, cid.Undef, err
+	}
+	if b.name == "" {
+		return nil, cid.Undef, fmt.Errorf("tag name is not set")
+	}
+	if err := checkPerson("tagger", b.tagger); err != nil {
+		return nil, cid.Undef, err
+	}
+
+	t := &_Tag{
+		object:  _Link{cidlink.Link{Cid: b.object}},
+		typ:     _String{b.typ},
+		tag:     _String{b.name},
+		tagger:  _PersonInfo__Maybe{m: schema.Maybe_Value, v: b.tagger},
+		message: _String{b.message},
+	}
+	id, err := ObjectCid(t, b.format)
+	if err != nil {
+		return nil, cid.Undef, err
+	}
+	return t, id, nil
+}
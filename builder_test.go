@@ -0,0 +1,259 @@
+package ipldgit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+)
+
+// hashCid returns the CID of the object with the given hex hash.
+func hashCid(t *testing.T, f ObjectFormat, h string) cid.Cid {
+	t.Helper()
+	sha, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := f.Cid(sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func testPerson(t *testing.T, name, email string, secs int64, offset int) PersonInfo {
+	t.Helper()
+	p, err := NewPersonInfo(name, email, time.Unix(secs, 0).In(time.FixedZone("", offset)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestBuilders(t *testing.T) {
+	f := ObjectFormatSHA1
+	author := testPerson(t, "A U Thor", "author@example.com", 1700000000, 3600)
+	committer := testPerson(t, "C O Mitter", "committer@example.com", 1700000001, -(3*60+30)*60)
+
+	_, hello, err := BuildBlob([]byte("hello\n"), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, target, err := BuildBlob([]byte("hello"), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, empty, err := NewTreeBuilder(f).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		got  cid.Cid
+		want string
+	}{
+		{hello, "ce013625030ba8dba906f756967f9e9ca394464a"},
+		{target, "b6fc4c620b67d95f953a5c1c1230aaab5db5a1b0"},
+		{empty, "4b825dc642cb6eb9a060e54bf8d69288fbee4904"},
+	} {
+		if want := hashCid(t, f, test.want); !test.got.Equals(want) {
+			t.Errorf("got %s, want %s", test.got, want)
+		}
+	}
+
+	tree, treeCid, err := NewTreeBuilder(f).
+		AddDir("dir.d", empty).
+		AddSubmodule("sub", hashCid(t, f, "2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51")).
+		AddFile("run", hello, true).
+		AddSymlink("link", target).
+		AddDir("dir", empty).
+		AddFile("hello", hello, false).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for it := tree.MapIterator(); !it.Done(); {
+		k, _, err := it.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, _ := k.AsString()
+		names = append(names, name)
+	}
+	if strings.Join(names, " ") != "dir.d dir hello link run sub" {
+		t.Fatalf("entries not in git order: %q", names)
+	}
+
+	_, initial, err := NewCommitBuilder(f).Tree(treeCid).Author(author).Committer(committer).Message("initial\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, other, err := NewCommitBuilder(f).Tree(empty).Author(author).Committer(committer).Message("other\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	merge, mergeCid, err := NewCommitBuilder(f).
+		Tree(treeCid).
+		Parents(initial, other).
+		Author(author).
+		Committer(committer).
+		Encoding("ISO-8859-1").
+		Message("merge\n").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, tagCid, err := NewTagBuilder(f).Object(initial, "commit").Name("v1.0").Tagger(committer).Message("Release 1.0\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		got  cid.Cid
+		want string
+	}{
+		{treeCid, "cf0ebfd59a9edf0778165b2971b1b59ac0ce4342"},
+		{initial, "2b6df1ad54f9e8cf5ad5ff7de1062aab4126cb41"},
+		{other, "889002dc3ac3921d925618f7600e89aa846a5869"},
+		{mergeCid, "cab4c6ad7c4fc4db9408b93840bc02e5ad516203"},
+		{tagCid, "827c800ee4e1f56f3f4599e341390c5a4541ea59"},
+	} {
+		if want := hashCid(t, f, test.want); !test.got.Equals(want) {
+			t.Errorf("got %s, want %s", test.got, want)
+		}
+	}
+
+	// The objects built are the ones decoding their bytes gives.
+	for i, n := range []ipld.Node{tree, merge, tag} {
+		var buf bytes.Buffer
+		if err := Encode(n, &buf); err != nil {
+			t.Fatal(err)
+		}
+		back, err := ParseObjectFromBuffer(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := ObjectCid(back, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []cid.Cid{treeCid, mergeCid, tagCid}[i]; !id.Equals(want) {
+			t.Errorf("decoded object has CID %s, want %s", id, want)
+		}
+	}
+}
+
+func TestBuildersSHA256(t *testing.T) {
+	f := ObjectFormatSHA256
+	author := testPerson(t, "A U Thor", "author@example.com", 1700000000, 3600)
+	committer := testPerson(t, "C O Mitter", "committer@example.com", 1700000001, -(3*60+30)*60)
+
+	_, hello, err := BuildBlob([]byte("hello\n"), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, tree, err := NewTreeBuilder(f).AddFile("hello", hello, false).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, commit, err := NewCommitBuilder(f).Tree(tree).Author(author).Committer(committer).Message("initial\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		got  cid.Cid
+		want string
+	}{
+		{hello, "2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4"},
+		{tree, "ab39bc840914e6c219053910a617f89e6e0b561cfab5953c79f014c4302f8a01"},
+		{commit, "fafff6b204ccf562f3ee0a8fdf5ca5eeb0edfb7358ab3299ebafd71db001cea2"},
+	} {
+		if want := hashCid(t, f, test.want); !test.got.Equals(want) {
+			t.Errorf("got %s, want %s", test.got, want)
+		}
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	f := ObjectFormatSHA1
+	person := testPerson(t, "A U Thor", "author@example.com", 1700000000, 0)
+	blob := hashCid(t, f, "ce013625030ba8dba906f756967f9e9ca394464a")
+	tree := hashCid(t, f, "4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+	sha256Tree := hashCid(t, ObjectFormatSHA256, "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321")
+	badZone := &_PersonInfo{name: _String{"A"}, email: _String{"a@example.com"}, date: _String{"1"}, timezone: _String{"+05"}}
+
+	commit := func() *CommitBuilder {
+		return NewCommitBuilder(f).Tree(tree).Author(person).Committer(person)
+	}
+	newTree := func() *TreeBuilder { return NewTreeBuilder(f) }
+	tag := func() *TagBuilder { return NewTagBuilder(f).Object(tree, "tree").Name("v1").Tagger(person) }
+
+	for _, test := range []struct {
+		name  string
+		build func() error
+	}{
+		{"NoTree", func() error { _, _, err := NewCommitBuilder(f).Author(person).Committer(person).Build(); return err }},
+		{"NoAuthor", func() error { _, _, err := NewCommitBuilder(f).Tree(tree).Committer(person).Build(); return err }},
+		{"OtherFormat", func() error { _, _, err := commit().Tree(sha256Tree).Build(); return err }},
+		{"NotGit", func() error { _, _, err := commit().Tree(cid.NewCidV1(cid.Raw, tree.Hash())).Build(); return err }},
+		{"DuplicateParent", func() error { _, _, err := commit().Parents(tree, tree).Build(); return err }},
+		{"BadTimezone", func() error { _, _, err := commit().Author(badZone).Build(); return err }},
+		{"BadEncoding", func() error { _, _, err := commit().Encoding("ISO 8859-1").Build(); return err }},
+		{"EmptyName", func() error { _, _, err := newTree().AddFile("", blob, false).Build(); return err }},
+		{"DotDot", func() error { _, _, err := newTree().AddDir("..", tree).Build(); return err }},
+		{"Slash", func() error { _, _, err := newTree().AddFile("a/b", blob, false).Build(); return err }},
+		{"DotGit", func() error { _, _, err := newTree().AddDir(".GIT", tree).Build(); return err }},
+		{"DuplicateEntry", func() error { _, _, err := newTree().AddFile("a", blob, false).AddDir("a", tree).Build(); return err }},
+		{"UnsetEntry", func() error { _, _, err := newTree().AddFile("a", cid.Undef, false).Build(); return err }},
+		{"TagType", func() error { _, _, err := tag().Object(tree, "folder").Build(); return err }},
+		{"TagName", func() error { _, _, err := tag().Name("v 1").Build(); return err }},
+		{"TagNoTagger", func() error { _, _, err := NewTagBuilder(f).Object(tree, "tree").Name("v1").Build(); return err }},
+		{"TagSignature", func() error {
+			_, _, err := tag().Message("Release\n-----BEGIN PGP SIGNATURE-----\n").Build()
+			return err
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.build(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestCheckEntryName(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ok   bool
+	}{
+		{"a.txt", true},
+		{".gitignore", true},
+		{".git-blame-ignore-revs", true},
+		{"git~2", true},
+		{"git", true},
+		{".g\u200cit", false},
+		{".git", false},
+		{".Git", false},
+		{".git.", false},
+		{".git ", false},
+		{".git . .", false},
+		{".git::$INDEX_ALLOCATION", false},
+		{".git\\hooks", false},
+		{"git~1", false},
+		{"GIT~1.", false},
+		{"\u200c.git", false},
+		{".\ufeffGi\u206at\u202e", false},
+		{"..", false},
+		{"", false},
+		{"a/b", false},
+		{"a\x00", false},
+	} {
+		if err := CheckEntryName(test.name); (err == nil) != test.ok {
+			t.Errorf("%q: got %v", test.name, err)
+		}
+	}
+}
@@ -13,193 +13,224 @@ import (
 	"github.com/ipld/go-ipld-prime/schema"
 )
 
-const prefixMergetag = 16 // the length of "mergetag object "
-
 // DecodeCommit fills a NodeAssembler (from `Type.Commit__Repr.NewBuilder()`) from a stream of bytes
 func DecodeCommit(na ipld.NodeAssembler, rd *bufio.Reader) error {
+	return DecodeOptions{}.DecodeCommit(na, rd)
+}
+
+// DecodeCommit fills a NodeAssembler (from `Type.Commit__Repr.NewBuilder()`) from a stream of bytes
+//
+// Headers the schema has no field for, and headers that would not be written
+// back the same way from their fields, are kept as they are in other. When the
+// headers are not in the order encoding writes them in, their order is kept in
+// headerOrder, so that the commit encodes back to the bytes it was read from.
+// Commits without a tree header, which git fsck rejects, fail to decode, as do
+// commits with no blank line after their headers, which could not be written
+// back.
+func (o DecodeOptions) DecodeCommit(na ipld.NodeAssembler, rd *bufio.Reader) error {
 	if _, err := readNullTerminatedNumber(rd); err != nil {
 		return err
 	}
 
+	headers, message, err := readHeaders(rd)
+	if err != nil {
+		return err
+	}
+
 	c := _Commit{
 		parents: _Commit_Link_List{[]_Commit_Link{}},
+		message: _String{message},
 	}
-	for {
-		line, _, err := rd.ReadLine()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return err
-		}
-
-		err = decodeCommitLine(&c, line, rd)
+	order := make([]string, 0, len(headers))
+	for _, h := range headers {
+		key, err := decodeCommitHeader(&c, h, o.ObjectFormat)
 		if err != nil {
 			return err
 		}
+		order = append(order, key)
+	}
+	// Every commit is written with a tree, so one without would not encode
+	// back to its bytes.
+	if c.tree.x == nil {
+		return fmt.Errorf("commit has no tree header")
 	}
+	c.headerOrder = headerOrderOf(order, commitHeaders(&c))
 
 	return na.AssignNode(&c)
 }
 
-func decodeCommitLine(c Commit, line []byte, rd *bufio.Reader) error {
-	switch {
-	case bytes.HasPrefix(line, []byte("tree ")):
-		sha, err := hex.DecodeString(string(line[5:]))
-		if err != nil {
-			return err
-		}
-
-		treeCid, err := shaToCid(sha)
-		if err != nil {
-			return err
+// decodeCommitHeader decodes a header into c, and returns the headerOrder key
+// it is recorded under.
+func decodeCommitHeader(c *_Commit, h string, f ObjectFormat) (string, error) {
+	key, value, hasValue := strings.Cut(h, " ")
+	switch key {
+	case "tree":
+		if c.tree.x != nil {
+			break
 		}
-
-		c.tree = _Tree_Link{cidlink.Link{Cid: treeCid}}
-	case bytes.HasPrefix(line, []byte("parent ")):
-		psha, err := hex.DecodeString(string(line[7:]))
+		lnk, err := decodeLinkHeader(value, f)
 		if err != nil {
-			return err
+			return "", err
 		}
-
-		parentCid, err := shaToCid(psha)
+		c.tree = _Tree_Link{lnk}
+		return key, nil
+	case "parent":
+		lnk, err := decodeLinkHeader(value, f)
 		if err != nil {
-			return err
+			return "", err
 		}
-
-		c.parents.x = append(c.parents.x, _Commit_Link{cidlink.Link{Cid: parentCid}})
-	case bytes.HasPrefix(line, []byte("author ")):
-		a, err := parsePersonInfo(line)
-		if err != nil {
-			return err
+		c.parents.x = append(c.parents.x, _Commit_Link{lnk})
+		return key, nil
+	case "author":
+		if c.author.m == schema.Maybe_Value {
+			break
 		}
-
-		c.author = _PersonInfo__Maybe{m: schema.Maybe_Value, v: a}
-	case bytes.HasPrefix(line, []byte("committer ")):
-		com, err := parsePersonInfo(line)
-		if err != nil {
-			return err
+		if pi, ok := decodePersonHeader(key, h); ok {
+			c.author = _PersonInfo__Maybe{m: schema.Maybe_Value, v: pi}
+			return key, nil
 		}
-
-		c.committer = _PersonInfo__Maybe{m: schema.Maybe_Value, v: com}
-	case bytes.HasPrefix(line, []byte("encoding ")):
-		c.encoding = _String__Maybe{m: schema.Maybe_Value, v: _String{string(line[9:])}}
-	case bytes.HasPrefix(line, []byte("mergetag object ")):
-		sha, err := hex.DecodeString(string(line)[prefixMergetag:])
-		if err != nil {
-			return err
+	case "committer":
+		if c.committer.m == schema.Maybe_Value {
+			break
 		}
-
-		mt, rest, err := readMergeTag(sha, rd)
-		if err != nil {
-			return err
+		if pi, ok := decodePersonHeader(key, h); ok {
+			c.committer = _PersonInfo__Maybe{m: schema.Maybe_Value, v: pi}
+			return key, nil
 		}
-
-		c.mergetag.x = append(c.mergetag.x, *mt)
-
-		if rest != nil {
-			err = decodeCommitLine(c, rest, rd)
-			if err != nil {
-				return err
-			}
+	case "encoding":
+		// A header without a space would be written back with one.
+		if c.encoding.m == schema.Maybe_Value || !hasValue {
+			break
 		}
-	case bytes.HasPrefix(line, []byte("gpgsig ")):
-		sig, err := decodeGpgSig(rd)
-		if err != nil {
-			return err
+		c.encoding = _String__Maybe{m: schema.Maybe_Value, v: _String{value}}
+		return key, nil
+	case "mergetag":
+		if mt, ok := decodeMergetag(value, f); ok {
+			c.mergetag.x = append(c.mergetag.x, *mt)
+			return key, nil
 		}
-		c.signature = _GpgSig__Maybe{m: schema.Maybe_Value, v: sig}
-	case len(line) == 0:
-		rest, err := io.ReadAll(rd)
-		if err != nil {
-			return err
+	case SignatureHeader, SignatureHeaderSHA256:
+		if sig, ok := decodeSignatureHeader(h); ok {
+			c.signatures.x = append(c.signatures.x, sig)
+			return "signature", nil
 		}
-
-		c.message = _String{string(rest)}
-	default:
-		c.other.x = append(c.other.x, _String{string(line)})
 	}
-	return nil
+	c.other.x = append(c.other.x, parseHeader(h))
+	return "other", nil
 }
 
-func decodeGpgSig(rd *bufio.Reader) (_GpgSig, error) {
-	out := _GpgSig{}
-
-	line, _, err := rd.ReadLine()
+func decodeLinkHeader(value string, f ObjectFormat) (cidlink.Link, error) {
+	sha, err := hex.DecodeString(value)
 	if err != nil {
-		return out, err
+		return cidlink.Link{}, err
 	}
-
-	// Accumulate in a builder: the signature has no fixed size, and repeated
-	// string concatenation would copy the whole thing on every line.
-	var sig strings.Builder
-
-	if string(line) != " " {
-		if strings.HasPrefix(string(line), " Version: ") || strings.HasPrefix(string(line), " Comment: ") {
-			sig.WriteString(string(line) + "\n")
-		} else {
-			return out, fmt.Errorf("expected first line of sig to be a single space or version")
-		}
-	} else {
-		sig.WriteString(" \n")
+	c, err := shaToCid(sha, f)
+	if err != nil {
+		return cidlink.Link{}, err
 	}
+	return cidlink.Link{Cid: c}, nil
+}
 
-	for {
-		line, _, err := rd.ReadLine()
-		if err != nil {
-			out.x = sig.String()
-			return out, err
-		}
-
-		if bytes.Equal(line, []byte(" -----END PGP SIGNATURE-----")) {
-			break
-		}
-
-		sig.WriteString(string(line) + "\n")
+// decodeMergetag parses the tag embedded in a mergetag header, if it encodes
+// back to the same text.
+func decodeMergetag(value string, f ObjectFormat) (*_Tag, bool) {
+	text := unfoldHeader(value) + "\n"
+	t, err := decodeTagBody(text, f)
+	if err != nil {
+		return nil, false
 	}
+	body, err := encodeTagBody(t, f)
+	if err != nil || string(body) != text {
+		return nil, false
+	}
+	return t, true
+}
 
-	out.x = sig.String()
-	return out, nil
+// commitHeaders returns the headerOrder keys of the headers of c, in the order
+// they are written in when it has no headerOrder.
+func commitHeaders(c *_Commit) []string {
+	keys := []string{"tree"}
+	for range c.parents.x {
+		keys = append(keys, "parent")
+	}
+	if c.author.m == schema.Maybe_Value {
+		keys = append(keys, "author")
+	}
+	if c.committer.m == schema.Maybe_Value {
+		keys = append(keys, "committer")
+	}
+	if c.encoding.m == schema.Maybe_Value {
+		keys = append(keys, "encoding")
+	}
+	for range c.mergetag.x {
+		keys = append(keys, "mergetag")
+	}
+	for range c.signatures.x {
+		keys = append(keys, "signature")
+	}
+	for range c.other.x {
+		keys = append(keys, "other")
+	}
+	return keys
 }
 
-func encodeCommit(n ipld.Node, w io.Writer) error {
+func encodeCommit(n ipld.Node, w io.Writer, f ObjectFormat) error {
 	ci := Type.Commit__Repr.NewBuilder()
 	if err := ci.AssignNode(n); err != nil {
 		return fmt.Errorf("not a Commit: %T %w", n, err)
 	}
 	c := ci.Build().(Commit)
 
-	buf := new(bytes.Buffer)
-
-	fmt.Fprintf(buf, "tree %s\n", hex.EncodeToString(c.tree.sha()))
-	for _, p := range c.parents.x {
-		fmt.Fprintf(buf, "parent %s\n", hex.EncodeToString(p.sha()))
-	}
-	fmt.Fprintf(buf, "author %s\n", c.author.v.GitString())
-	fmt.Fprintf(buf, "committer %s\n", c.committer.v.GitString())
-	if c.encoding.m == schema.Maybe_Value {
-		fmt.Fprintf(buf, "encoding %s\n", c.encoding.v.x)
-	}
-	for _, mtag := range c.mergetag.x {
-		fmt.Fprintf(buf, "mergetag object %s\n", hex.EncodeToString(mtag.object.sha()))
-		fmt.Fprintf(buf, " type %s\n", mtag.typ.x)
-		fmt.Fprintf(buf, " tag %s\n", mtag.tag.x)
-		fmt.Fprintf(buf, " tagger %s\n \n", mtag.tagger.GitString())
-		fmt.Fprintf(buf, "%s", mtag.message.x)
-	}
-	if c.signature.m == schema.Maybe_Value {
-		fmt.Fprintln(buf, "gpgsig -----BEGIN PGP SIGNATURE-----")
-		fmt.Fprint(buf, c.signature.v.x)
-		fmt.Fprintln(buf, " -----END PGP SIGNATURE-----")
+	order, err := writeOrder(c.headerOrder, commitHeaders(c))
+	if err != nil {
+		return err
 	}
-	for _, line := range c.other.x {
-		fmt.Fprintln(buf, line.x)
+
+	buf := new(bytes.Buffer)
+	var parents, mergetags, signatures, others int
+	for _, key := range order {
+		switch key {
+		case "tree":
+			tree, err := c.tree.sha(f)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "tree %s\n", hex.EncodeToString(tree))
+		case "parent":
+			parent, err := c.parents.x[parents].sha(f)
+			if err != nil {
+				return err
+			}
+			parents++
+			fmt.Fprintf(buf, "parent %s\n", hex.EncodeToString(parent))
+		case "author":
+			fmt.Fprintf(buf, "author %s\n", c.author.v.GitString())
+		case "committer":
+			fmt.Fprintf(buf, "committer %s\n", c.committer.v.GitString())
+		case "encoding":
+			fmt.Fprintf(buf, "encoding %s\n", c.encoding.v.x)
+		case "mergetag":
+			body, err := encodeTagBody(&c.mergetag.x[mergetags], f)
+			if err != nil {
+				return err
+			}
+			mergetags++
+			fmt.Fprintf(buf, "mergetag %s\n", foldHeader(strings.TrimSuffix(string(body), "\n")))
+		case "signature":
+			sig, err := c.signatures.x[signatures].gitString()
+			if err != nil {
+				return err
+			}
+			signatures++
+			fmt.Fprintln(buf, sig)
+		case "other":
+			fmt.Fprintln(buf, c.other.x[others].gitString())
+			others++
+		}
 	}
 	fmt.Fprintf(buf, "\n%s", c.message.x)
 
-	// fmt.Printf("encode commit len: %d \n", buf.Len())
-	//	fmt.Printf("out: %s\n", string(buf.Bytes()))
-	_, err := fmt.Fprintf(w, "commit %d\x00", buf.Len())
+	_, err = fmt.Fprintf(w, "commit %d\x00", buf.Len())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,112 @@
+package ipldgit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime/schema"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ErrUnknownEncoding is the error of text in an encoding that is not known,
+// wrapped with its name.
+var ErrUnknownEncoding = errors.New("unknown encoding")
+
+// lookupEncoding returns the encoding named by an IANA name or alias, such as
+// "ISO-8859-1" or "latin1", or by a label of the WHATWG Encoding Standard, such
+// as "cp1252". It returns nil for UTF-8, which git assumes when a commit has no
+// encoding header.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	if name == "" || strings.EqualFold(name, "UTF-8") || strings.EqualFold(name, "utf8") {
+		return nil, nil
+	}
+	e, err := ianaindex.IANA.Encoding(name)
+	if err != nil || e == nil {
+		e, err = htmlindex.Get(name)
+	}
+	if err != nil || e == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEncoding, name)
+	}
+	if e == unicode.UTF8 {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// DecodeText converts text in the named encoding, as the value of the encoding
+// header of a commit names it, into UTF-8. Bytes that are invalid in the
+// encoding become U+FFFD. Text in UTF-8, or in no named encoding, is returned as
+// it is.
+func DecodeText(name, text string) (string, error) {
+	e, err := lookupEncoding(name)
+	if err != nil || e == nil {
+		return text, err
+	}
+	return e.NewDecoder().String(text)
+}
+
+// EncodeText converts UTF-8 text into the named encoding. It fails if the text
+// has characters that the encoding cannot represent.
+func EncodeText(name, text string) (string, error) {
+	e, err := lookupEncoding(name)
+	if err != nil || e == nil {
+		return text, err
+	}
+	encoded, err := e.NewEncoder().String(text)
+	if err != nil {
+		return "", fmt.Errorf("encoding text as %s: %w", name, err)
+	}
+	return encoded, nil
+}
+
+// MessageUTF8 returns the message of the commit decoded to UTF-8 from the
+// encoding its encoding header names. The message field keeps the raw text,
+// which is what the commit is encoded with.
+func (c _Commit) MessageUTF8() (string, error) {
+	return DecodeText(c.encodingName(), c.message.x)
+}
+
+// AuthorUTF8 returns the author of the commit with their name and email decoded
+// to UTF-8 from the encoding its encoding header names, or nil if the commit
+// has no author.
+func (c _Commit) AuthorUTF8() (PersonInfo, error) {
+	return c.personUTF8(c.author)
+}
+
+// CommitterUTF8 returns the committer of the commit with their name and email
+// decoded to UTF-8 from the encoding its encoding header names, or nil if the
+// commit has no committer.
+func (c _Commit) CommitterUTF8() (PersonInfo, error) {
+	return c.personUTF8(c.committer)
+}
+
+func (c _Commit) encodingName() string {
+	if c.encoding.m != schema.Maybe_Value {
+		return ""
+	}
+	return c.encoding.v.x
+}
+
+func (c _Commit) personUTF8(p _PersonInfo__Maybe) (PersonInfo, error) {
+	if p.m != schema.Maybe_Value {
+		return nil, nil
+	}
+	return p.v.transcode(c.encodingName(), DecodeText)
+}
+
+// transcode returns a copy of the person info whose name and email are
+// converted by convert.
+func (p _PersonInfo) transcode(name string, convert func(name, text string) (string, error)) (PersonInfo, error) {
+	var err error
+	if p.name.x, err = convert(name, p.name.x); err != nil {
+		return nil, err
+	}
+	if p.email.x, err = convert(name, p.email.x); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
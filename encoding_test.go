@@ -0,0 +1,89 @@
+package ipldgit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCommitUTF8(t *testing.T) {
+	for _, test := range []struct {
+		name, header, author, message string
+		wantAuthor, wantMessage       string
+		err                           error
+	}{
+		{"NoEncoding", "", "A U Thor", "message\n", "A U Thor", "message\n", nil},
+		{"UTF8", "encoding UTF-8\n", "Zoë", "naïve\n", "Zoë", "naïve\n", nil},
+		{"Latin1", "encoding ISO-8859-1\n", "Jos\xe9", "Caf\xe9\n", "José", "Café\n", nil},
+		{"Latin1Alias", "encoding latin1\n", "Jos\xe9", "Caf\xe9\n", "José", "Café\n", nil},
+		{"Windows1252", "encoding cp1252\n", "A U Thor", "\x80 5\n", "A U Thor", "€ 5\n", nil},
+		{"ShiftJIS", "encoding Shift_JIS\n", "\x93\xfa\x96\x7b", "\x82\xa0\n", "日本", "あ\n", nil},
+		{"Unknown", "encoding x-unknown\n", "A U Thor", "message\n", "", "", ErrUnknownEncoding},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			body := fmt.Sprintf("tree %s\nauthor %s <author@example.com> 1700000000 +0000\ncommitter C O Mitter <committer@example.com> 1700000000 +0000\n%s\n%s",
+				"4b825dc642cb6eb9a060e54bf8d69288fbee4904", test.author, test.header, test.message)
+			n, err := ParseObjectFromBuffer(fmt.Appendf(nil, "commit %d\x00%s", len(body), body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := n.(Commit)
+			if got := c.FieldMessage().String(); got != test.message {
+				t.Fatalf("raw message changed to %q", got)
+			}
+
+			message, err := c.MessageUTF8()
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("expected %v, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil || message != test.wantMessage {
+				t.Fatalf("got message %q, %v", message, err)
+			}
+			author, err := c.AuthorUTF8()
+			if err != nil || author.FieldName().String() != test.wantAuthor || author.FieldEmail().String() != "author@example.com" {
+				t.Fatalf("got author %v, %v", author, err)
+			}
+			if committer, err := c.CommitterUTF8(); err != nil || committer.FieldName().String() != "C O Mitter" {
+				t.Fatalf("got committer %v, %v", committer, err)
+			}
+		})
+	}
+}
+
+func TestBuildEncodedCommit(t *testing.T) {
+	f := ObjectFormatSHA1
+	tree := hashCid(t, f, "4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+	author := testPerson(t, "José", "jose@example.com", 1700000000, 0)
+
+	c, _, err := NewCommitBuilder(f).Tree(tree).Author(author).Committer(author).Encoding("ISO-8859-1").Message("Café\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.FieldMessage().String(); got != "Caf\xe9\n" {
+		t.Fatalf("got raw message %q", got)
+	}
+	if got := c.FieldAuthor().Must().FieldName().String(); got != "Jos\xe9" {
+		t.Fatalf("got raw author name %q", got)
+	}
+	if got := author.FieldName().String(); got != "José" {
+		t.Fatalf("building changed the author given to %q", got)
+	}
+	if got, err := c.MessageUTF8(); err != nil || got != "Café\n" {
+		t.Fatalf("got message %q, %v", got, err)
+	}
+
+	if _, _, err := NewCommitBuilder(f).Tree(tree).Author(author).Committer(author).Encoding("ISO-8859-1").Message("日本\n").Build(); err == nil {
+		t.Fatal("expected an error encoding a message the encoding cannot represent")
+	}
+	// U+0A3E is the bytes "\n>" in UTF-16BE, which would end the header.
+	gurmukhi := testPerson(t, "Jos\u0a3e", "jose@example.com", 1700000000, 0)
+	if _, _, err := NewCommitBuilder(f).Tree(tree).Author(gurmukhi).Committer(author).Encoding("UTF-16BE").Build(); err == nil {
+		t.Fatal("expected an error building an author whose encoded name has a newline")
+	}
+	if _, _, err := NewCommitBuilder(f).Tree(tree).Author(author).Committer(author).Encoding("x-unknown").Build(); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("expected %v, got %v", ErrUnknownEncoding, err)
+	}
+}
@@ -0,0 +1,107 @@
+package ipldgit
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// ObjectFormat is the hash function a repository names its objects with, as
+// chosen by `git init --object-format`.
+type ObjectFormat uint8
+
+const (
+	// ObjectFormatDefault leaves the format unset. Decoders read SHA-1 objects,
+	// and encoders take the format of each link from its multihash.
+	ObjectFormatDefault ObjectFormat = iota
+	// ObjectFormatSHA1 is the original git object format, with 20 byte hashes.
+	ObjectFormatSHA1
+	// ObjectFormatSHA256 is the SHA-256 object format, with 32 byte hashes.
+	ObjectFormatSHA256
+)
+
+// String returns the name git uses for the format.
+func (f ObjectFormat) String() string {
+	switch f {
+	case ObjectFormatDefault, ObjectFormatSHA1:
+		return "sha1"
+	case ObjectFormatSHA256:
+		return "sha256"
+	default:
+		return fmt.Sprintf("ObjectFormat(%d)", uint8(f))
+	}
+}
+
+// Size returns the length in bytes of an object hash in this format.
+func (f ObjectFormat) Size() int {
+	if f == ObjectFormatSHA256 {
+		return 32
+	}
+	return 20
+}
+
+// Prefix returns the prefix of git-raw CIDs in this format, suitable for a
+// cidlink.LinkPrototype.
+func (f ObjectFormat) Prefix() cid.Prefix {
+	return cid.Prefix{
+		Version:  1,
+		Codec:    cid.GitRaw,
+		MhType:   f.multihash(),
+		MhLength: f.Size(),
+	}
+}
+
+// NewHash returns a hash.Hash computing object names in this format.
+func (f ObjectFormat) NewHash() hash.Hash {
+	if f == ObjectFormatSHA256 {
+		return sha256.New()
+	}
+	return sha1.New()
+}
+
+// Cid returns the git-raw CID naming the object with the given hash.
+func (f ObjectFormat) Cid(sha []byte) (cid.Cid, error) {
+	return shaToCid(sha, f)
+}
+
+// Sha returns the git object hash carried by a CID. With ObjectFormatDefault a
+// hash of either format is accepted.
+func (f ObjectFormat) Sha(c cid.Cid) ([]byte, error) {
+	return cidToSha(c, f)
+}
+
+// ObjectFormatOf returns the object format of the hash carried by a CID.
+func ObjectFormatOf(c cid.Cid) (ObjectFormat, error) {
+	dh, err := mh.Decode(c.Hash())
+	if err != nil {
+		return ObjectFormatDefault, err
+	}
+	return formatOf(dh.Code, dh.Length)
+}
+
+func (f ObjectFormat) multihash() uint64 {
+	if f == ObjectFormatSHA256 {
+		return mh.SHA2_256
+	}
+	return mh.SHA1
+}
+
+func (f ObjectFormat) valid() bool {
+	return f <= ObjectFormatSHA256
+}
+
+// formatOf returns the object format a multihash belongs to.
+func formatOf(code uint64, length int) (ObjectFormat, error) {
+	switch {
+	case code == mh.SHA1 && length == ObjectFormatSHA1.Size():
+		return ObjectFormatSHA1, nil
+	case code == mh.SHA2_256 && length == ObjectFormatSHA256.Size():
+		return ObjectFormatSHA256, nil
+	default:
+		return ObjectFormatDefault, fmt.Errorf("multihash %#x of %d bytes is not a git object hash", code, length)
+	}
+}
@@ -0,0 +1,76 @@
+package ipldgit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/multiformats/go-multihash"
+)
+
+func sha256Object(typ, body string) ([]byte, [32]byte) {
+	obj := []byte(fmt.Sprintf("%s %d\x00%s", typ, len(body), body))
+	return obj, sha256.Sum256(obj)
+}
+
+func TestSHA256ObjectFormat(t *testing.T) {
+	blob, blobSum := sha256Object("blob", "hello world\n")
+	tree, treeSum := sha256Object("tree", "100644 hello.txt\x00"+string(blobSum[:]))
+	commit, commitSum := sha256Object("commit", "tree "+hex.EncodeToString(treeSum[:])+"\n"+
+		"author A <a@b> 1792206015 +0000\n"+
+		"committer A <a@b> 1792206015 +0000\n"+
+		"\nmsg\n")
+	tag, tagSum := sha256Object("tag", "object "+hex.EncodeToString(commitSum[:])+"\n"+
+		"type commit\n"+
+		"tag v1\n"+
+		"tagger A <a@b> 1792206015 +0000\n"+
+		"\ntagmsg\n")
+
+	lp := cidlink.LinkPrototype{Prefix: ObjectFormatSHA256.Prefix()}
+	ls := cidlink.DefaultLinkSystem()
+	opts := DecodeOptions{ObjectFormat: ObjectFormatSHA256}
+
+	for _, obj := range []struct {
+		raw []byte
+		sum [32]byte
+	}{{blob, blobSum}, {tree, treeSum}, {commit, commitSum}, {tag, tagSum}} {
+		n, err := opts.ParseObjectFromBuffer(obj.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lnk := ls.MustComputeLink(lp, n)
+		dh, err := multihash.Decode(lnk.(cidlink.Link).Cid.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dh.Code != multihash.SHA2_256 || !bytes.Equal(dh.Digest, obj.sum[:]) {
+			t.Fatalf("link %s does not match sha256 %x", lnk, obj.sum)
+		}
+
+		buf := new(bytes.Buffer)
+		if err := (EncodeOptions{ObjectFormat: ObjectFormatSHA256}).Encode(n, buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), obj.raw) {
+			t.Fatalf("round trip mismatch:\n%q\n%q", buf.Bytes(), obj.raw)
+		}
+	}
+
+	treeNode, err := opts.ParseObjectFromBuffer(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = EncodeOptions{ObjectFormat: ObjectFormatSHA1}.Encode(treeNode, new(bytes.Buffer))
+	if err == nil || !strings.Contains(err.Error(), "expected sha1") {
+		t.Fatalf("encoding sha256 links as sha1 gave %v", err)
+	}
+
+	if _, err := ParseObjectFromBuffer(commit); err == nil {
+		t.Fatal("decoded a sha256 commit as sha1")
+	}
+}
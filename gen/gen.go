@@ -23,6 +23,12 @@ func main() {
 
 	ts.Accumulate(schema.SpawnList("String_List", "String", false))
 
+	ts.Accumulate(schema.SpawnStruct("Header", []schema.StructField{
+		schema.SpawnStructField("key", "String", false, false),
+		schema.SpawnStructField("value", "String", false, false),
+	}, schema.SpawnStructRepresentationMap(map[string]string{})))
+	ts.Accumulate(schema.SpawnList("Header_List", "Header", false))
+
 	ts.Accumulate(schema.SpawnStruct("PersonInfo", []schema.StructField{
 		schema.SpawnStructField("date", "String", false, false),
 		schema.SpawnStructField("timezone", "String", false, false),
@@ -30,17 +36,31 @@ func main() {
 		schema.SpawnStructField("name", "String", false, false),
 	}, schema.SpawnStructRepresentationMap(map[string]string{})))
 
-	ts.Accumulate(schema.SpawnString("GpgSig"))
+	ts.Accumulate(schema.SpawnStruct("Signature", []schema.StructField{
+		schema.SpawnStructField("header", "String", false, false),
+		schema.SpawnStructField("text", "String", false, false),
+	}, schema.SpawnStructRepresentationMap(map[string]string{})))
+	ts.Accumulate(schema.SpawnList("Signature_List", "Signature", false))
 
+	// The object of a tag and the hash of a tree entry name an object whose
+	// type is given by another field. Only an inline union keyed by that field
+	// would type them without changing the data, and gengo does not generate
+	// inline unions, so they stay untyped links. typedlink.go types them from
+	// the sibling field instead.
 	ts.Accumulate(schema.SpawnStruct("Tag", []schema.StructField{
 		schema.SpawnStructField("object", "Link", false, false),
 		schema.SpawnStructField("type", "String", false, false),
 		schema.SpawnStructField("tag", "String", false, false),
-		schema.SpawnStructField("tagger", "PersonInfo", false, false),
+		schema.SpawnStructField("tagger", "PersonInfo", true, false),
 		schema.SpawnStructField("message", "String", false, false),
+		schema.SpawnStructField("signature", "String", true, false),
+		schema.SpawnStructField("signatures", "Signature_List", true, false),
+		schema.SpawnStructField("other", "Header_List", true, false),
+		schema.SpawnStructField("headerOrder", "String_List", true, false),
 	}, schema.SpawnStructRepresentationMap(map[string]string{})))
 
 	ts.Accumulate(schema.SpawnList("Tag_List", "Tag", false))
+	ts.Accumulate(schema.SpawnLinkReference("Tag_Link", "Tag"))
 
 	ts.Accumulate(schema.SpawnStruct("Commit", []schema.StructField{
 		schema.SpawnStructField("tree", "Tree_Link", false, false),
@@ -49,14 +69,16 @@ func main() {
 		schema.SpawnStructField("author", "PersonInfo", true, false),
 		schema.SpawnStructField("committer", "PersonInfo", true, false),
 		schema.SpawnStructField("encoding", "String", true, false),
-		schema.SpawnStructField("signature", "GpgSig", true, false),
+		schema.SpawnStructField("signatures", "Signature_List", false, false),
 		schema.SpawnStructField("mergetag", "Tag_List", false, false),
-		schema.SpawnStructField("other", "String_List", false, false),
+		schema.SpawnStructField("other", "Header_List", false, false),
+		schema.SpawnStructField("headerOrder", "String_List", true, false),
 	}, schema.SpawnStructRepresentationMap(map[string]string{})))
 	ts.Accumulate(schema.SpawnLinkReference("Commit_Link", "Commit"))
 	ts.Accumulate(schema.SpawnList("Commit_Link_List", "Commit_Link", false))
 
 	ts.Accumulate(schema.SpawnBytes("Blob"))
+	ts.Accumulate(schema.SpawnLinkReference("Blob_Link", "Blob"))
 
 	ts.Accumulate(schema.SpawnMap("Tree", "String", "TreeEntry", false))
 	ts.Accumulate(schema.SpawnStruct("TreeEntry", []schema.StructField{
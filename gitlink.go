@@ -0,0 +1,118 @@
+package ipldgit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/traversal"
+)
+
+// ErrGitlink is returned when loading the commit a gitlink names is refused.
+var ErrGitlink = errors.New("gitlink names a commit in another repository")
+
+// GitlinkPolicy is what a LinkSystem made by GitlinkLinkSystem does when asked
+// to load the commit a submodule entry of a tree names.
+type GitlinkPolicy uint8
+
+const (
+	// GitlinkSkip returns traversal.SkipMe, so that traversals pass over
+	// submodules.
+	GitlinkSkip GitlinkPolicy = iota
+	// GitlinkError fails with ErrGitlink.
+	GitlinkError
+	// GitlinkResolve loads the commit from the storage of another LinkSystem,
+	// such as one over the repositories of the submodules, along with the
+	// objects a traversal reaches through it.
+	GitlinkResolve
+)
+
+// IsGitlink reports whether the entry is a submodule, whose hash names a
+// commit in another repository rather than an object of this one.
+func (n TreeEntry) IsGitlink() bool {
+	k, err := ParseEntryMode(n.mode.x)
+	return err == nil && k == EntryGitlink
+}
+
+// CommitLink returns the commit a submodule entry names.
+func (n TreeEntry) CommitLink() (Commit_Link, error) {
+	if !n.IsGitlink() {
+		return nil, fmt.Errorf("tree entry with mode %s is not a gitlink", n.mode.x)
+	}
+	return &_Commit_Link{n.hash.x}, nil
+}
+
+// GitlinkLinkSystem returns a copy of ls that loads the links of submodule
+// entries according to policy. The commits are loaded from the storage of
+// submodules with GitlinkResolve, and it is unused otherwise.
+//
+// Submodule entries are recognised from the ParentNode of the LinkContext,
+// which traversals set to the tree entry holding the link, whether it was
+// loaded as a TreeEntry or with a basicnode prototype. With GitlinkResolve,
+// other links are loaded from the storage of ls, and from that of submodules
+// when ls does not have them, as happens below a resolved gitlink. Only errors
+// that say the object is not found lead to submodules: those wrapping
+// fs.ErrNotExist, as gitstore's do, or with a NotFound method reporting true,
+// as those of go-ipld-format and go-datastore. Other errors are returned as
+// they are. Objects are
+// checked against their hash wherever they come from, so no state is kept
+// between loads, and the LinkSystem can be shared by any traversals.
+func GitlinkLinkSystem(ls ipld.LinkSystem, policy GitlinkPolicy, submodules *ipld.LinkSystem) ipld.LinkSystem {
+	open := ls.StorageReadOpener
+	ls.StorageReadOpener = func(lctx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		if !isGitlinkEntry(lctx.ParentNode) {
+			r, err := open(lctx, lnk)
+			if isNotFound(err) && policy == GitlinkResolve && submodules != nil && submodules.StorageReadOpener != nil {
+				if sr, serr := submodules.StorageReadOpener(lctx, lnk); serr == nil {
+					return sr, nil
+				}
+			}
+			return r, err
+		}
+		switch policy {
+		case GitlinkSkip:
+			return nil, traversal.SkipMe{}
+		case GitlinkResolve:
+			if submodules == nil || submodules.StorageReadOpener == nil {
+				return nil, fmt.Errorf("no link system to resolve gitlink %s with", lnk)
+			}
+			return submodules.StorageReadOpener(lctx, lnk)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrGitlink, lnk)
+		}
+	}
+	return ls
+}
+
+// isNotFound reports whether err says that storage does not have an object.
+func isNotFound(err error) bool {
+	if errors.Is(err, fs.ErrNotExist) {
+		return true
+	}
+	var nf interface{ NotFound() bool }
+	return errors.As(err, &nf) && nf.NotFound()
+}
+
+func isGitlinkEntry(n ipld.Node) bool {
+	switch n := n.(type) {
+	case nil:
+		return false
+	case TreeEntry:
+		return n.IsGitlink()
+	}
+	if n.Kind() != ipld.Kind_Map {
+		return false
+	}
+	m, err := n.LookupByString("mode")
+	if err != nil {
+		return false
+	}
+	ms, err := m.AsString()
+	if err != nil {
+		return false
+	}
+	k, err := ParseEntryMode(ms)
+	return err == nil && k == EntryGitlink
+}
@@ -0,0 +1,218 @@
+package ipldgit
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+// memLinkSystem returns a LinkSystem over a memstore, reporting objects it
+// does not have with fs.ErrNotExist, as gitstore does, since memstore has no
+// error that says so.
+func memLinkSystem() ipld.LinkSystem {
+	ls := cidlink.DefaultLinkSystem()
+	store := &memstore.Store{}
+	ls.SetReadStorage(store)
+	ls.SetWriteStorage(store)
+	open := ls.StorageReadOpener
+	ls.StorageReadOpener = func(lctx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		if ok, _ := store.Has(lctx.Ctx, lnk.Binary()); !ok {
+			return nil, fmt.Errorf("%s: %w", lnk, fs.ErrNotExist)
+		}
+		return open(lctx, lnk)
+	}
+	return ls
+}
+
+// storeRaw stores the object raw, in its loose form, and returns its hash.
+func storeRaw(t *testing.T, ls ipld.LinkSystem, raw string) []byte {
+	n, err := ParseObjectFromBuffer([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ObjectFormatSHA1.Prefix()}, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha, err := ObjectFormatSHA1.Sha(lnk.(cidlink.Link).Cid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha
+}
+
+func storeCommit(t *testing.T, ls ipld.LinkSystem, tree []byte, message string) []byte {
+	body := fmt.Sprintf("tree %x\nauthor A U Thor <author@example.com> 1 +0000\ncommitter A U Thor <author@example.com> 1 +0000\n\n%s", tree, message)
+	return storeRaw(t, ls, fmt.Sprintf("commit %d\x00%s", len(body), body))
+}
+
+func storeTree(t *testing.T, ls ipld.LinkSystem, entries ...string) []byte {
+	var body string
+	for i := 0; i < len(entries); i += 3 {
+		sha, _ := hex.DecodeString(entries[i+2])
+		body += fmt.Sprintf("%s %s\x00%s", entries[i], entries[i+1], sha)
+	}
+	return storeRaw(t, ls, fmt.Sprintf("tree %d\x00%s", len(body), body))
+}
+
+func TestGitlinks(t *testing.T) {
+	sub := memLinkSystem()
+	subCommit := storeCommit(t, sub, storeTree(t, sub), "submodule commit\n")
+
+	ls := memLinkSystem()
+	blob := storeRaw(t, ls, "blob 6\x00hello\n")
+	tree := storeTree(t, ls,
+		"100644", "README", hex.EncodeToString(blob),
+		"160000", "vendor", hex.EncodeToString(subCommit),
+	)
+	head := storeCommit(t, ls, tree, "superproject commit\n")
+
+	headCid, _ := ObjectFormatSHA1.Cid(head)
+	root, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: headCid}, Type.Commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	te := root.(Commit).FieldTree()
+	treeNode, err := ls.Load(ipld.LinkContext{}, te.Link(), Type.Tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := treeNode.(Tree).Lookup(&_String{"vendor"})
+	if !entry.IsGitlink() {
+		t.Fatal("submodule entry is not a gitlink")
+	}
+	cl, err := entry.CommitLink()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha, _ := cl.sha(ObjectFormatSHA1); hex.EncodeToString(sha) != hex.EncodeToString(subCommit) {
+		t.Fatalf("unexpected commit link %s", cl.Link())
+	}
+	if _, err := treeNode.(Tree).Lookup(&_String{"README"}).CommitLink(); err == nil {
+		t.Fatal("expected an error for the commit link of a file")
+	}
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := selector.CompileSelector(ssb.ExploreRecursive(selector.RecursionLimitNone(),
+		ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.ExploreRecursiveEdge()))).Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	walkFrom := func(ls ipld.LinkSystem, np ipld.NodePrototype, root cid.Cid) ([]string, error) {
+		var messages []string
+		start, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: root}, np)
+		if err != nil {
+			return nil, err
+		}
+		err = traversal.Progress{
+			Cfg: &traversal.Config{
+				LinkSystem: ls,
+				LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+					return basicnode.Prototype.Any, nil
+				},
+			},
+		}.WalkMatching(start, sel, func(p traversal.Progress, n ipld.Node) error {
+			if p.Path.Last().String() == "message" {
+				s, _ := n.AsString()
+				messages = append(messages, s)
+			}
+			return nil
+		})
+		sort.Strings(messages)
+		return messages, err
+	}
+	walk := func(ls ipld.LinkSystem, np ipld.NodePrototype) ([]string, error) {
+		return walkFrom(ls, np, headCid)
+	}
+
+	for _, np := range []ipld.NodePrototype{Type.Commit, basicnode.Prototype.Any} {
+		if _, err := walk(ls, np); err == nil {
+			t.Fatal("expected an error walking into a submodule without a policy")
+		}
+
+		messages, err := walk(GitlinkLinkSystem(ls, GitlinkSkip, nil), np)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(messages) != 1 {
+			t.Fatalf("expected only the superproject commit, got %q", messages)
+		}
+
+		if _, err := walk(GitlinkLinkSystem(ls, GitlinkError, nil), np); !errors.Is(err, ErrGitlink) {
+			t.Fatalf("expected ErrGitlink, got %v", err)
+		}
+
+		messages, err = walk(GitlinkLinkSystem(ls, GitlinkResolve, &sub), np)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(messages) != 2 || messages[0] != "submodule commit\n" {
+			t.Fatalf("expected the submodule commit, got %q", messages)
+		}
+	}
+
+	// The same LinkSystem serves traversals from any root, one after another
+	// or at the same time.
+	resolve := GitlinkLinkSystem(ls, GitlinkResolve, &sub)
+	subCid, _ := ObjectFormatSHA1.Cid(subCommit)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			root, want := headCid, []string{"submodule commit\n", "superproject commit\n"}
+			if i%2 == 1 {
+				root, want = subCid, []string{"submodule commit\n"}
+			}
+			messages, err := walkFrom(resolve, Type.Commit, root)
+			if err != nil {
+				t.Error(err)
+			} else if !slices.Equal(messages, want) {
+				t.Errorf("walk from %s found %q, want %q", root, messages, want)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Only objects the storage of ls does not have are looked for in that of
+	// submodules: other errors are returned as they are.
+	for _, test := range []struct {
+		name     string
+		err      error
+		fallback bool
+	}{
+		{"NotFound", fmt.Errorf("missing: %w", fs.ErrNotExist), true},
+		{"NotFoundMethod", notFoundError{}, true},
+		{"Broken", errors.New("storage broken"), false},
+	} {
+		broken := ls
+		broken.StorageReadOpener = func(ipld.LinkContext, ipld.Link) (io.Reader, error) {
+			return nil, test.err
+		}
+		_, err := GitlinkLinkSystem(broken, GitlinkResolve, &sub).StorageReadOpener(ipld.LinkContext{}, cidlink.Link{Cid: subCid})
+		if test.fallback && err != nil {
+			t.Errorf("%s: not looked up in the submodules: %v", test.name, err)
+		}
+		if !test.fallback && !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string  { return "not found" }
+func (notFoundError) NotFound() bool { return true }
@@ -0,0 +1,429 @@
+// Package gitstore implements go-ipld-prime storage over the object database
+// of a git repository, so that a LinkSystem can load git-raw CIDs straight
+// from a .git directory, and store new objects in it as loose objects.
+// Store.LinkSystem returns such a LinkSystem, decoding objects in the object
+// format of the repository.
+//
+// Keys are the binary form of CIDs, as used by cidlink, and values are git
+// objects in their loose form: a "<type> <size>\x00" header followed by the
+// object body, which is what the ipldgit codec decodes.
+package gitstore
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipfs/go-ipld-git/packfile"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/storage"
+)
+
+var (
+	_ storage.ReadableStorage          = (*Store)(nil)
+	_ storage.StreamingReadableStorage = (*Store)(nil)
+	_ storage.WritableStorage          = (*Store)(nil)
+)
+
+// ErrNotFound is returned when an object is neither loose nor in a pack. It
+// wraps fs.ErrNotExist.
+var ErrNotFound = fmt.Errorf("object not found: %w", fs.ErrNotExist)
+
+// Options configures how a repository is opened.
+type Options struct {
+	// ObjectFormat is the hash function of the repository. When left as
+	// ObjectFormatDefault it is read from the repository configuration.
+	ObjectFormat ipldgit.ObjectFormat
+	// CacheSize bounds the delta bases each pack keeps in memory, as in
+	// packfile.Options.
+	CacheSize int64
+}
+
+// Store serves the objects of a git repository. It is safe for concurrent use.
+type Store struct {
+	dir    string
+	format ipldgit.ObjectFormat
+	opts   Options
+
+	mu    sync.RWMutex
+	packs map[string]*packfile.Pack
+}
+
+// Open opens the repository whose git directory is gitDir. A working tree
+// holding a .git directory is accepted too.
+func Open(gitDir string, opts Options) (*Store, error) {
+	if fi, err := os.Stat(filepath.Join(gitDir, ".git")); err == nil && fi.IsDir() {
+		gitDir = filepath.Join(gitDir, ".git")
+	}
+	if fi, err := os.Stat(filepath.Join(gitDir, "objects")); err != nil {
+		return nil, err
+	} else if !fi.IsDir() {
+		return nil, fmt.Errorf("%s is not a git directory", gitDir)
+	}
+
+	if opts.ObjectFormat == ipldgit.ObjectFormatDefault {
+		f, err := readObjectFormat(filepath.Join(gitDir, "config"))
+		if err != nil {
+			return nil, err
+		}
+		opts.ObjectFormat = f
+	}
+
+	s := &Store{
+		dir:    filepath.Join(gitDir, "objects"),
+		format: opts.ObjectFormat,
+		opts:   opts,
+		packs:  make(map[string]*packfile.Pack),
+	}
+	if err := s.scanPacks(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Init creates an empty repository in gitDir, which must not already hold one,
+// and opens it. The repository is bare, and its HEAD names the unborn master
+// branch. Its object format is that of opts, or SHA-1 by default.
+func Init(gitDir string, opts Options) (*Store, error) {
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err == nil {
+		return nil, fmt.Errorf("%s already holds a repository", gitDir)
+	}
+	f := opts.ObjectFormat
+	if f == ipldgit.ObjectFormatDefault {
+		f = ipldgit.ObjectFormatSHA1
+	}
+
+	for _, dir := range []string{"objects/info", "objects/pack", "refs/heads", "refs/tags"} {
+		if err := os.MkdirAll(filepath.Join(gitDir, dir), 0777); err != nil {
+			return nil, err
+		}
+	}
+	config := "[core]\n\trepositoryformatversion = 0\n\tbare = true\n"
+	if f != ipldgit.ObjectFormatSHA1 {
+		config = fmt.Sprintf("[core]\n\trepositoryformatversion = 1\n\tbare = true\n[extensions]\n\tobjectformat = %s\n", f)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(config), 0666); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/master\n"), 0666); err != nil {
+		return nil, err
+	}
+
+	opts.ObjectFormat = f
+	return Open(gitDir, opts)
+}
+
+// readObjectFormat reads extensions.objectFormat from a git config file.
+func readObjectFormat(path string) (ipldgit.ObjectFormat, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ipldgit.ObjectFormatSHA1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var section string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.Trim(line, "[] \t"))
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != "extensions" || !strings.EqualFold(strings.TrimSpace(key), "objectformat") {
+			continue
+		}
+		switch value = strings.TrimSpace(value); value {
+		case "sha1":
+			return ipldgit.ObjectFormatSHA1, nil
+		case "sha256":
+			return ipldgit.ObjectFormatSHA256, nil
+		default:
+			return 0, fmt.Errorf("unsupported object format %q", value)
+		}
+	}
+	return ipldgit.ObjectFormatSHA1, nil
+}
+
+// ObjectFormat returns the object format of the repository.
+func (s *Store) ObjectFormat() ipldgit.ObjectFormat {
+	return s.format
+}
+
+// LinkSystem returns a LinkSystem loading objects from the store and storing
+// them in it, decoding and encoding them in the object format of the
+// repository. Links in another format are refused rather than misread.
+func (s *Store) LinkSystem() ipld.LinkSystem {
+	ls := cidlink.DefaultLinkSystem()
+	ls.DecoderChooser = ipldgit.DecodeOptions{ObjectFormat: s.format}.DecoderChooser
+	encode := ipldgit.EncodeOptions{ObjectFormat: s.format}.Encode
+	ls.EncoderChooser = func(lp ipld.LinkPrototype) (ipld.Encoder, error) {
+		if clp, ok := lp.(cidlink.LinkPrototype); !ok || clp.Codec != cid.GitRaw {
+			return nil, fmt.Errorf("link prototype %v is not git-raw", lp)
+		}
+		return encode, nil
+	}
+	ls.SetReadStorage(s)
+	ls.SetWriteStorage(s)
+	return ls
+}
+
+// Close closes the packs of the repository.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	for name, p := range s.packs {
+		if cerr := p.Close(); err == nil {
+			err = cerr
+		}
+		delete(s.packs, name)
+	}
+	return err
+}
+
+// scanPacks opens the packs that were added to the repository since it was
+// last scanned.
+func (s *Store) scanPacks() error {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "pack", "*.pack"))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if _, ok := s.packs[name]; ok {
+			continue
+		}
+		p, err := packfile.Open(path, packfile.Options{ObjectFormat: s.format, CacheSize: s.opts.CacheSize})
+		if errors.Is(err, fs.ErrNotExist) {
+			// git writes the index after the pack, so this one is incomplete.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		s.packs[name] = p
+	}
+	return nil
+}
+
+func (s *Store) sha(key string) ([]byte, error) {
+	c, err := cid.Cast([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return s.format.Sha(c)
+}
+
+func (s *Store) loosePath(sha []byte) string {
+	h := hex.EncodeToString(sha)
+	return filepath.Join(s.dir, h[:2], h[2:])
+}
+
+// findPack returns the pack holding the object with the given hash, scanning
+// for new packs once if none does.
+func (s *Store) findPack(sha []byte) (*packfile.Pack, error) {
+	for rescanned := false; ; rescanned = true {
+		s.mu.RLock()
+		for _, p := range s.packs {
+			if _, ok := p.Index().Find(sha); ok {
+				s.mu.RUnlock()
+				return p, nil
+			}
+		}
+		s.mu.RUnlock()
+
+		if rescanned {
+			return nil, ErrNotFound
+		}
+		if err := s.scanPacks(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Has reports whether the repository holds the object named by key.
+func (s *Store) Has(ctx context.Context, key string) (bool, error) {
+	sha, err := s.sha(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(s.loosePath(sha)); err == nil {
+		return true, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if _, err := s.findPack(sha); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns the object named by key in its loose form.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	rc, err := s.GetStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// GetStream returns a reader of the object named by key in its loose form.
+// Loose objects are inflated as they are read, while packed objects are read
+// whole first, as they may need deltas applied.
+func (s *Store) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	sha, err := s.sha(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(s.loosePath(sha))
+	if err == nil {
+		zr, err := zlib.NewReader(bufio.NewReader(f))
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("reading loose object %x: %w", sha, err)
+		}
+		return &looseReader{ReadCloser: zr, f: f}, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	p, err := s.findPack(sha)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.ReadRaw(sha)
+	if errors.Is(err, packfile.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+type looseReader struct {
+	io.ReadCloser
+	f *os.File
+}
+
+func (r *looseReader) Close() error {
+	err := r.ReadCloser.Close()
+	if ferr := r.f.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Put stores the object named by key as a loose object, unless the repository
+// already holds it. The content must be the object in its loose form, as
+// written by ipldgit.Encode, and hash to key.
+//
+// Like git, the object is compressed into a temporary file that is then
+// renamed into place, so that readers never see part of it, and it is made
+// read-only.
+func (s *Store) Put(ctx context.Context, key string, content []byte) error {
+	sha, err := s.sha(key)
+	if err != nil {
+		return err
+	}
+	h := s.format.NewHash()
+	h.Write(content)
+	if !bytes.Equal(h.Sum(nil), sha) {
+		return fmt.Errorf("content does not hash to object %x", sha)
+	}
+	if err := checkObject(content); err != nil {
+		return fmt.Errorf("object %x: %w", sha, err)
+	}
+
+	if ok, err := s.Has(ctx, key); err != nil || ok {
+		return err
+	}
+
+	path := s.loosePath(sha)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "tmp_obj_")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// git compresses loose objects for speed, as core.looseCompression does by
+	// default.
+	zw, err := zlib.NewWriterLevel(tmp, zlib.BestSpeed)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := zw.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0444); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// checkObject checks that content is a loose object whose header names a known
+// type and the size of its body.
+func checkObject(content []byte) error {
+	hdr, body, ok := bytes.Cut(content, []byte{0})
+	if !ok {
+		return errors.New("object has no header")
+	}
+	typ, size, _ := bytes.Cut(hdr, []byte{' '})
+	switch string(typ) {
+	case "blob", "tree", "commit", "tag":
+	default:
+		return fmt.Errorf("unknown object type %q", typ)
+	}
+	if n, err := strconv.Atoi(string(size)); err != nil || n != len(body) {
+		return fmt.Errorf("object header %q does not match its %d byte body", hdr, len(body))
+	}
+	return nil
+}
+
+// OpenBlob returns a node for the blob named by c that reads the blob from the
+// repository whenever its content is asked for. Loose blobs are then streamed,
+// while packed ones are read whole each time.
+func (s *Store) OpenBlob(ctx context.Context, c cid.Cid) (*ipldgit.StreamingBlob, error) {
+	return ipldgit.OpenBlob(func() (io.ReadCloser, error) {
+		return s.GetStream(ctx, c.KeyString())
+	})
+}
@@ -0,0 +1,402 @@
+package gitstore
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipfs/go-ipld-git/packfile"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+// extractRepo extracts the repository of the root package tests into a
+// temporary directory and returns its git directory.
+func extractRepo(t *testing.T) string {
+	archive, err := os.Open("../testdata.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, hdr.Name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+		case tar.TypeReg:
+			var data []byte
+			if data, err = io.ReadAll(tr); err == nil {
+				err = os.WriteFile(path, data, 0644)
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, ".git")
+}
+
+// copyPack copies a pack of the packfile tests into the repository.
+func copyPack(t *testing.T, gitDir, name string) {
+	for _, ext := range []string{".pack", ".idx"} {
+		data, err := os.ReadFile("../packfile/testdata/" + name + ext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(gitDir, "objects", "pack", name+ext), data, 0444); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func refCid(t *testing.T, gitDir, ref string) cid.Cid {
+	data, err := os.ReadFile(filepath.Join(gitDir, ref))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := ipldgit.ObjectFormatSHA1.Cid(sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestStoreTraversal(t *testing.T) {
+	gitDir := extractRepo(t)
+	s, err := Open(filepath.Dir(gitDir), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.ObjectFormat() != ipldgit.ObjectFormatSHA1 {
+		t.Fatalf("unexpected object format %s", s.ObjectFormat())
+	}
+
+	ls := cidlink.DefaultLinkSystem()
+	ls.SetReadStorage(s)
+	root, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: refCid(t, gitDir, "refs/heads/master")}, basicnode.Prototype.Any)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := selector.CompileSelector(ssb.ExploreRecursive(selector.RecursionLimitNone(),
+		ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.ExploreRecursiveEdge()))).Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobs := 0
+	err = traversal.Progress{
+		Cfg: &traversal.Config{
+			LinkSystem: ls,
+			LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+				return basicnode.Prototype.Any, nil
+			},
+		},
+	}.WalkMatching(root, sel, func(p traversal.Progress, n ipld.Node) error {
+		if n.Kind() == ipld.Kind_Bytes {
+			blobs++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blobs == 0 {
+		t.Fatal("traversal reached no blobs")
+	}
+
+	// The "Hello world" blob of the first commit, loose in the repository.
+	sha, _ := hex.DecodeString("802992c4220de19a90767f3000a79a31b98d0df7")
+	c, _ := ipldgit.ObjectFormatSHA1.Cid(sha)
+	b, err := s.OpenBlob(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := b.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if body, err := io.ReadAll(rc); err != nil || string(body) != "Hello world\n" {
+		t.Fatalf("unexpected blob %q: %v", body, err)
+	}
+}
+
+func TestStorePacks(t *testing.T) {
+	gitDir := extractRepo(t)
+	s, err := Open(gitDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// The pack is added after the store is opened, and found when looked up.
+	copyPack(t, gitDir, "ofsdelta")
+	p, err := packfile.Open(filepath.Join(gitDir, "objects", "pack", "ofsdelta.pack"), packfile.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	ls := cidlink.DefaultLinkSystem()
+	ls.SetReadStorage(s)
+	ctx := context.Background()
+	err = p.ForEach(func(c cid.Cid, n ipld.Node) error {
+		if ok, err := s.Has(ctx, c.KeyString()); err != nil || !ok {
+			t.Fatalf("store does not have %s: %v", c, err)
+		}
+		got, err := ls.Load(ipld.LinkContext{Ctx: ctx}, cidlink.Link{Cid: c}, basicnode.Prototype.Any)
+		if err != nil {
+			return err
+		}
+		if !ipld.DeepEqual(got, n) {
+			t.Fatalf("object %s differs from the one in the pack", c)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing, _ := ipldgit.ObjectFormatSHA1.Cid(make([]byte, 20))
+	if ok, err := s.Has(ctx, missing.KeyString()); err != nil || ok {
+		t.Fatalf("expected a missing object, got %v, %v", ok, err)
+	}
+	if _, err := s.Get(ctx, missing.KeyString()); !errors.Is(err, ErrNotFound) || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreObjectFormat(t *testing.T) {
+	gitDir := extractRepo(t)
+	config := "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tobjectFormat = sha256\n"
+	if err := os.WriteFile(filepath.Join(gitDir, "config"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(gitDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.ObjectFormat() != ipldgit.ObjectFormatSHA256 {
+		t.Fatalf("unexpected object format %s", s.ObjectFormat())
+	}
+
+	c := refCid(t, gitDir, "refs/heads/master")
+	if _, err := s.Get(context.Background(), c.KeyString()); err == nil {
+		t.Fatal("expected an error looking up a sha1 object in a sha256 repository")
+	}
+}
+
+func TestStoreSHA256Repository(t *testing.T) {
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(git, append([]string{"-c", "user.name=A U Thor", "-c", "user.email=author@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q", "--object-format=sha256")
+	if err := os.MkdirAll(filepath.Join(dir, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"hello": "hello\n", "dir/a.txt": "a\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "initial")
+	sha, err := hex.DecodeString(run("rev-parse", "HEAD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := ipldgit.ObjectFormatSHA256.Cid(sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Open(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ls := s.LinkSystem()
+	root, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: head}, ipldgit.Type.Commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := selector.CompileSelector(ssb.ExploreRecursive(selector.RecursionLimitNone(),
+		ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.ExploreRecursiveEdge()))).Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobs := make(map[string]string)
+	err = traversal.Progress{
+		Cfg: &traversal.Config{
+			LinkSystem: ls,
+			LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+				return basicnode.Prototype.Any, nil
+			},
+		},
+	}.WalkMatching(root, sel, func(p traversal.Progress, n ipld.Node) error {
+		if n.Kind() == ipld.Kind_Bytes {
+			b, _ := n.AsBytes()
+			blobs[p.Path.String()] = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blobs["tree/hello/hash"] != "blob 6\x00hello\n" || blobs["tree/dir/hash/a.txt/hash"] != "blob 2\x00a\n" || len(blobs) != 2 {
+		t.Fatalf("unexpected blobs %q", blobs)
+	}
+
+	// Storing a commit again through the LinkSystem gives the same CID, and
+	// SHA-1 links are refused.
+	lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA256.Prefix()}, root)
+	if err != nil || !lnk.(cidlink.Link).Cid.Equals(head) {
+		t.Fatalf("stored HEAD as %v: %v", lnk, err)
+	}
+	if _, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA1.Prefix()}, root); err == nil {
+		t.Fatal("stored a sha256 commit under a sha1 link")
+	}
+	if _, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: refCid(t, extractRepo(t), "refs/heads/master")}, basicnode.Prototype.Any); err == nil {
+		t.Fatal("loaded a sha1 link from a sha256 repository")
+	}
+}
+
+func TestStorePut(t *testing.T) {
+	gitDir := filepath.Join(t.TempDir(), "repo.git")
+	s, err := Init(gitDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	p, err := packfile.Open("../packfile/testdata/ofsdelta.pack", packfile.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	ls := cidlink.DefaultLinkSystem()
+	ls.SetReadStorage(s)
+	ls.SetWriteStorage(s)
+	ctx := context.Background()
+	var head cid.Cid
+	err = p.ForEach(func(c cid.Cid, n ipld.Node) error {
+		lnk, err := ls.Store(ipld.LinkContext{Ctx: ctx}, cidlink.LinkPrototype{Prefix: c.Prefix()}, n)
+		if err != nil {
+			return err
+		}
+		if lnk.(cidlink.Link).Cid != c {
+			t.Fatalf("object %s stored as %s", c, lnk)
+		}
+		if n.Prototype() == ipldgit.Type.Commit {
+			head = c
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sha, _ := ipldgit.ObjectFormatSHA1.Sha(head)
+	fi, err := os.Stat(s.loosePath(sha))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0444 {
+		t.Fatalf("loose object has mode %v", fi.Mode())
+	}
+	// Storing an object again leaves it alone.
+	raw, err := s.Get(ctx, head.KeyString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, head.KeyString(), raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, head.KeyString(), append(raw, '\n')); err == nil {
+		t.Fatal("expected an error storing content under the wrong key")
+	}
+
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not found, not checking the repository with git")
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "refs", "heads", "master"), []byte(hex.EncodeToString(sha)+"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(git, "-c", "safe.directory=*", "--git-dir", gitDir, "fsck", "--full", "--strict", "--no-dangling")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git fsck: %v\n%s", err, out)
+	}
+}
+
+func TestInitObjectFormat(t *testing.T) {
+	gitDir := filepath.Join(t.TempDir(), "repo.git")
+	s, err := Init(gitDir, Options{ObjectFormat: ipldgit.ObjectFormatSHA256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = Open(gitDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.ObjectFormat() != ipldgit.ObjectFormatSHA256 {
+		t.Fatalf("unexpected object format %s", s.ObjectFormat())
+	}
+	if _, err := Init(gitDir, Options{}); err == nil {
+		t.Fatal("expected an error initializing a repository twice")
+	}
+}
@@ -0,0 +1,125 @@
+package ipldgit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// errNoBlankLine is the error of a commit or tag whose headers run to its end.
+// Encoding always writes the blank line, so such an object would not encode
+// back to its bytes.
+var errNoBlankLine = errors.New("missing blank line after the headers")
+
+// readHeaders reads the headers of a commit or tag, up to the blank line that
+// separates them from the message, and returns the message that follows it.
+// Each header is returned as written, with its continuation lines (the lines
+// that start with a space) and without its final newline.
+func readHeaders(rd *bufio.Reader) ([]string, string, error) {
+	var headers []string
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, "", err
+		}
+		if line == "" {
+			return nil, "", errNoBlankLine
+		}
+		if line == "\n" {
+			rest, err := io.ReadAll(rd)
+			if err != nil {
+				return nil, "", err
+			}
+			return headers, string(rest), nil
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line[0] == ' ' && len(headers) > 0 {
+			headers[len(headers)-1] += "\n" + line
+		} else {
+			headers = append(headers, line)
+		}
+		if err == io.EOF {
+			return nil, "", errNoBlankLine
+		}
+	}
+}
+
+// parseHeader splits a header into its key, the text before the first space,
+// and its value, with the continuation lines unfolded. A header with no value
+// keeps any trailing space in its key, so that it is written back the same.
+func parseHeader(h string) _Header {
+	key, value, _ := strings.Cut(h, " ")
+	if value == "" {
+		key = h
+	}
+	return _Header{key: _String{key}, value: _String{unfoldHeader(value)}}
+}
+
+// gitString returns the header as it is written in an object, without its
+// final newline.
+func (h _Header) gitString() string {
+	if h.value.x == "" {
+		return h.key.x
+	}
+	return h.key.x + " " + foldHeader(h.value.x)
+}
+
+// unfoldHeader removes the space git puts at the start of every continuation
+// line of a header value.
+func unfoldHeader(value string) string {
+	return strings.ReplaceAll(value, "\n ", "\n")
+}
+
+// foldHeader is the inverse of unfoldHeader.
+func foldHeader(value string) string {
+	return strings.ReplaceAll(value, "\n", "\n ")
+}
+
+// decodePersonHeader parses a person header, such as "author", if its person
+// info writes back to exactly the same line.
+func decodePersonHeader(key, header string) (*_PersonInfo, bool) {
+	pi, err := parsePersonInfo([]byte(header))
+	if err != nil {
+		return nil, false
+	}
+	if key+" "+pi.GitString() != header {
+		return nil, false
+	}
+	return pi, true
+}
+
+// headerOrderOf returns the headerOrder to record for headers read in order,
+// which is absent when it is the order the headers are written in anyway.
+func headerOrderOf(order, canonical []string) _String_List__Maybe {
+	if slices.Equal(order, canonical) {
+		return _String_List__Maybe{m: schema.Maybe_Absent}
+	}
+	l := _String_List{x: make([]_String, len(order))}
+	for i, key := range order {
+		l.x[i] = _String{key}
+	}
+	return _String_List__Maybe{m: schema.Maybe_Value, v: l}
+}
+
+// writeOrder returns the order to write headers in: canonical, unless
+// headerOrder is present, in which case it must hold the same keys.
+func writeOrder(headerOrder _String_List__Maybe, canonical []string) ([]string, error) {
+	if headerOrder.m != schema.Maybe_Value {
+		return canonical, nil
+	}
+	order := make([]string, len(headerOrder.v.x))
+	for i, key := range headerOrder.v.x {
+		order[i] = key.x
+	}
+
+	if !slices.Equal(slices.Sorted(slices.Values(order)), slices.Sorted(slices.Values(canonical))) {
+		return nil, fmt.Errorf("headerOrder %q does not match the headers present %q", order, canonical)
+	}
+	return order, nil
+}
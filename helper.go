@@ -2,8 +2,6 @@ package ipldgit
 
 import (
 	"bufio"
-	"bytes"
-	"compress/zlib"
 	"errors"
 	"io"
 	"strconv"
@@ -11,7 +9,6 @@ import (
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"github.com/ipld/go-ipld-prime"
-	mh "github.com/multiformats/go-multihash"
 )
 
 // DecodeBlock attempts to parse a serialized ipfs block into an ipld node dag
@@ -19,27 +16,25 @@ import (
 func DecodeBlock(block blocks.Block) (ipld.Node, error) {
 	prefix := block.Cid().Prefix()
 
-	if prefix.Codec != cid.GitRaw || prefix.MhType != mh.SHA1 || prefix.MhLength != mh.DefaultLengths[mh.SHA1] {
+	if prefix.Codec != cid.GitRaw {
+		return nil, errors.New("invalid CID prefix")
+	}
+	f, err := formatOf(prefix.MhType, prefix.MhLength)
+	if err != nil {
 		return nil, errors.New("invalid CID prefix")
 	}
 
-	return ParseObjectFromBuffer(block.RawData())
+	return DecodeOptions{ObjectFormat: f}.ParseObjectFromBuffer(block.RawData())
 }
 
 // ParseCompressedObject works like ParseObject, but with a surrounding zlib compression.
 func ParseCompressedObject(r io.Reader) (ipld.Node, error) {
-	rc, err := zlib.NewReader(r)
-	if err != nil {
-		return nil, err
-	}
-	defer rc.Close()
-
-	return ParseObject(rc)
+	return DecodeOptions{}.ParseCompressedObject(r)
 }
 
 // ParseObjectFromBuffer is like ParseObject, but with a fully in-memory stream
 func ParseObjectFromBuffer(b []byte) (ipld.Node, error) {
-	return ParseObject(bytes.NewReader(b))
+	return DecodeOptions{}.ParseObjectFromBuffer(b)
 }
 
 func readNullTerminatedNumber(rd *bufio.Reader) (int, error) {
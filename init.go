@@ -12,6 +12,8 @@ import (
 var (
 	_ ipld.Decoder = Decode
 	_ ipld.Encoder = Encode
+	_ ipld.Decoder = DecodeOptions{}.Decode
+	_ ipld.Encoder = EncodeOptions{}.Encode
 )
 
 func init() {
@@ -3,7 +3,7 @@ package ipldgit
 // Code generated by go-ipld-prime gengo.  DO NOT EDIT.
 
 import (
-	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/datamodel"
 	"github.com/ipld/go-ipld-prime/node/mixins"
 	"github.com/ipld/go-ipld-prime/schema"
 )
@@ -31,12 +31,12 @@ func (m MaybeBlob) IsAbsent() bool {
 func (m MaybeBlob) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeBlob) AsNode() ipld.Node {
+func (m MaybeBlob) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -50,28 +50,28 @@ func (m MaybeBlob) Must() Blob {
 	return &m.v
 }
 
-var _ ipld.Node = (Blob)(&_Blob{})
+var _ datamodel.Node = (Blob)(&_Blob{})
 var _ schema.TypedNode = (Blob)(&_Blob{})
 
-func (Blob) Kind() ipld.Kind {
-	return ipld.Kind_Bytes
+func (Blob) Kind() datamodel.Kind {
+	return datamodel.Kind_Bytes
 }
-func (Blob) LookupByString(string) (ipld.Node, error) {
+func (Blob) LookupByString(string) (datamodel.Node, error) {
 	return mixins.Bytes{TypeName: "ipldgit.Blob"}.LookupByString("")
 }
-func (Blob) LookupByNode(ipld.Node) (ipld.Node, error) {
+func (Blob) LookupByNode(datamodel.Node) (datamodel.Node, error) {
 	return mixins.Bytes{TypeName: "ipldgit.Blob"}.LookupByNode(nil)
 }
-func (Blob) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Blob) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Bytes{TypeName: "ipldgit.Blob"}.LookupByIndex(0)
 }
-func (Blob) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (Blob) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return mixins.Bytes{TypeName: "ipldgit.Blob"}.LookupBySegment(seg)
 }
-func (Blob) MapIterator() ipld.MapIterator {
+func (Blob) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (Blob) ListIterator() ipld.ListIterator {
+func (Blob) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (Blob) Length() int64 {
@@ -98,16 +98,16 @@ func (Blob) AsString() (string, error) {
 func (n Blob) AsBytes() ([]byte, error) {
 	return n.x, nil
 }
-func (Blob) AsLink() (ipld.Link, error) {
+func (Blob) AsLink() (datamodel.Link, error) {
 	return mixins.Bytes{TypeName: "ipldgit.Blob"}.AsLink()
 }
-func (Blob) Prototype() ipld.NodePrototype {
+func (Blob) Prototype() datamodel.NodePrototype {
 	return _Blob__Prototype{}
 }
 
 type _Blob__Prototype struct{}
 
-func (_Blob__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Blob__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Blob__Builder
 	nb.Reset()
 	return &nb
@@ -117,7 +117,7 @@ type _Blob__Builder struct {
 	_Blob__Assembler
 }
 
-func (nb *_Blob__Builder) Build() ipld.Node {
+func (nb *_Blob__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -135,10 +135,10 @@ type _Blob__Assembler struct {
 }
 
 func (na *_Blob__Assembler) reset() {}
-func (_Blob__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Blob__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.BytesAssembler{TypeName: "ipldgit.Blob"}.BeginMap(0)
 }
-func (_Blob__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Blob__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.BytesAssembler{TypeName: "ipldgit.Blob"}.BeginList(0)
 }
 func (na *_Blob__Assembler) AssignNull() error {
@@ -174,10 +174,10 @@ func (na *_Blob__Assembler) AssignBytes(v []byte) error {
 	*na.m = schema.Maybe_Value
 	return nil
 }
-func (_Blob__Assembler) AssignLink(ipld.Link) error {
+func (_Blob__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.BytesAssembler{TypeName: "ipldgit.Blob"}.AssignLink(nil)
 }
-func (na *_Blob__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Blob__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -196,23 +196,231 @@ func (na *_Blob__Assembler) AssignNode(v ipld.Node) error {
 		return na.AssignBytes(v2)
 	}
 }
-func (_Blob__Assembler) Prototype() ipld.NodePrototype {
+func (_Blob__Assembler) Prototype() datamodel.NodePrototype {
 	return _Blob__Prototype{}
 }
 func (Blob) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Blob) Representation() ipld.Node {
+func (n Blob) Representation() datamodel.Node {
 	return (*_Blob__Repr)(n)
 }
 
 type _Blob__Repr = _Blob
 
-var _ ipld.Node = &_Blob__Repr{}
+var _ datamodel.Node = &_Blob__Repr{}
 
 type _Blob__ReprPrototype = _Blob__Prototype
 type _Blob__ReprAssembler = _Blob__Assembler
 
+func (n Blob_Link) Link() datamodel.Link {
+	return n.x
+}
+func (_Blob_Link__Prototype) FromLink(v datamodel.Link) (Blob_Link, error) {
+	n := _Blob_Link{v}
+	return &n, nil
+}
+
+type _Blob_Link__Maybe struct {
+	m schema.Maybe
+	v _Blob_Link
+}
+type MaybeBlob_Link = *_Blob_Link__Maybe
+
+func (m MaybeBlob_Link) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeBlob_Link) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeBlob_Link) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeBlob_Link) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeBlob_Link) Must() Blob_Link {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ datamodel.Node = (Blob_Link)(&_Blob_Link{})
+var _ schema.TypedNode = (Blob_Link)(&_Blob_Link{})
+
+func (Blob_Link) Kind() datamodel.Kind {
+	return datamodel.Kind_Link
+}
+func (Blob_Link) LookupByString(string) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.LookupByString("")
+}
+func (Blob_Link) LookupByNode(datamodel.Node) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.LookupByNode(nil)
+}
+func (Blob_Link) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.LookupByIndex(0)
+}
+func (Blob_Link) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.LookupBySegment(seg)
+}
+func (Blob_Link) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (Blob_Link) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (Blob_Link) Length() int64 {
+	return -1
+}
+func (Blob_Link) IsAbsent() bool {
+	return false
+}
+func (Blob_Link) IsNull() bool {
+	return false
+}
+func (Blob_Link) AsBool() (bool, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.AsBool()
+}
+func (Blob_Link) AsInt() (int64, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.AsInt()
+}
+func (Blob_Link) AsFloat() (float64, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.AsFloat()
+}
+func (Blob_Link) AsString() (string, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.AsString()
+}
+func (Blob_Link) AsBytes() ([]byte, error) {
+	return mixins.Link{TypeName: "ipldgit.Blob_Link"}.AsBytes()
+}
+func (n Blob_Link) AsLink() (datamodel.Link, error) {
+	return n.x, nil
+}
+func (Blob_Link) Prototype() datamodel.NodePrototype {
+	return _Blob_Link__Prototype{}
+}
+
+type _Blob_Link__Prototype struct{}
+
+func (_Blob_Link__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Blob_Link__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Blob_Link__Builder struct {
+	_Blob_Link__Assembler
+}
+
+func (nb *_Blob_Link__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Blob_Link__Builder) Reset() {
+	var w _Blob_Link
+	var m schema.Maybe
+	*nb = _Blob_Link__Builder{_Blob_Link__Assembler{w: &w, m: &m}}
+}
+
+type _Blob_Link__Assembler struct {
+	w *_Blob_Link
+	m *schema.Maybe
+}
+
+func (na *_Blob_Link__Assembler) reset() {}
+func (_Blob_Link__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.BeginMap(0)
+}
+func (_Blob_Link__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.BeginList(0)
+}
+func (na *_Blob_Link__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_Blob_Link__Assembler) AssignBool(bool) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignBool(false)
+}
+func (_Blob_Link__Assembler) AssignInt(int64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignInt(0)
+}
+func (_Blob_Link__Assembler) AssignFloat(float64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignFloat(0)
+}
+func (_Blob_Link__Assembler) AssignString(string) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignString("")
+}
+func (_Blob_Link__Assembler) AssignBytes([]byte) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Blob_Link"}.AssignBytes(nil)
+}
+func (na *_Blob_Link__Assembler) AssignLink(v datamodel.Link) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (na *_Blob_Link__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Blob_Link); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsLink(); err != nil {
+		return err
+	} else {
+		return na.AssignLink(v2)
+	}
+}
+func (_Blob_Link__Assembler) Prototype() datamodel.NodePrototype {
+	return _Blob_Link__Prototype{}
+}
+func (Blob_Link) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (Blob_Link) LinkTargetNodePrototype() datamodel.NodePrototype {
+	return Type.Blob__Repr
+}
+func (n Blob_Link) Representation() datamodel.Node {
+	return (*_Blob_Link__Repr)(n)
+}
+
+type _Blob_Link__Repr = _Blob_Link
+
+var _ datamodel.Node = &_Blob_Link__Repr{}
+
+type _Blob_Link__ReprPrototype = _Blob_Link__Prototype
+type _Blob_Link__ReprAssembler = _Blob_Link__Assembler
+
 func (n _Commit) FieldTree() Tree_Link {
 	return &n.tree
 }
@@ -231,15 +439,18 @@ func (n _Commit) FieldCommitter() MaybePersonInfo {
 func (n _Commit) FieldEncoding() MaybeString {
 	return &n.encoding
 }
-func (n _Commit) FieldSignature() MaybeGpgSig {
-	return &n.signature
+func (n _Commit) FieldSignatures() Signature_List {
+	return &n.signatures
 }
 func (n _Commit) FieldMergetag() Tag_List {
 	return &n.mergetag
 }
-func (n _Commit) FieldOther() String_List {
+func (n _Commit) FieldOther() Header_List {
 	return &n.other
 }
+func (n _Commit) FieldHeaderOrder() MaybeString_List {
+	return &n.headerOrder
+}
 
 type _Commit__Maybe struct {
 	m schema.Maybe
@@ -256,12 +467,12 @@ func (m MaybeCommit) IsAbsent() bool {
 func (m MaybeCommit) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeCommit) AsNode() ipld.Node {
+func (m MaybeCommit) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return m.v
 	default:
@@ -276,23 +487,24 @@ func (m MaybeCommit) Must() Commit {
 }
 
 var (
-	fieldName__Commit_Tree      = _String{"tree"}
-	fieldName__Commit_Parents   = _String{"parents"}
-	fieldName__Commit_Message   = _String{"message"}
-	fieldName__Commit_Author    = _String{"author"}
-	fieldName__Commit_Committer = _String{"committer"}
-	fieldName__Commit_Encoding  = _String{"encoding"}
-	fieldName__Commit_Signature = _String{"signature"}
-	fieldName__Commit_Mergetag  = _String{"mergetag"}
-	fieldName__Commit_Other     = _String{"other"}
+	fieldName__Commit_Tree        = _String{"tree"}
+	fieldName__Commit_Parents     = _String{"parents"}
+	fieldName__Commit_Message     = _String{"message"}
+	fieldName__Commit_Author      = _String{"author"}
+	fieldName__Commit_Committer   = _String{"committer"}
+	fieldName__Commit_Encoding    = _String{"encoding"}
+	fieldName__Commit_Signatures  = _String{"signatures"}
+	fieldName__Commit_Mergetag    = _String{"mergetag"}
+	fieldName__Commit_Other       = _String{"other"}
+	fieldName__Commit_HeaderOrder = _String{"headerOrder"}
 )
-var _ ipld.Node = (Commit)(&_Commit{})
+var _ datamodel.Node = (Commit)(&_Commit{})
 var _ schema.TypedNode = (Commit)(&_Commit{})
 
-func (Commit) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (Commit) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n Commit) LookupByString(key string) (ipld.Node, error) {
+func (n Commit) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "tree":
 		return &n.tree, nil
@@ -302,46 +514,48 @@ func (n Commit) LookupByString(key string) (ipld.Node, error) {
 		return &n.message, nil
 	case "author":
 		if n.author.m == schema.Maybe_Absent {
-			return ipld.Absent, nil
+			return datamodel.Absent, nil
 		}
 		return n.author.v, nil
 	case "committer":
 		if n.committer.m == schema.Maybe_Absent {
-			return ipld.Absent, nil
+			return datamodel.Absent, nil
 		}
 		return n.committer.v, nil
 	case "encoding":
 		if n.encoding.m == schema.Maybe_Absent {
-			return ipld.Absent, nil
+			return datamodel.Absent, nil
 		}
 		return &n.encoding.v, nil
-	case "signature":
-		if n.signature.m == schema.Maybe_Absent {
-			return ipld.Absent, nil
-		}
-		return &n.signature.v, nil
+	case "signatures":
+		return &n.signatures, nil
 	case "mergetag":
 		return &n.mergetag, nil
 	case "other":
 		return &n.other, nil
+	case "headerOrder":
+		if n.headerOrder.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return &n.headerOrder.v, nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n Commit) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n Commit) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (Commit) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Commit) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit"}.LookupByIndex(0)
 }
-func (n Commit) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n Commit) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n Commit) MapIterator() ipld.MapIterator {
+func (n Commit) MapIterator() datamodel.MapIterator {
 	return &_Commit__MapItr{n, 0}
 }
 
@@ -350,9 +564,9 @@ type _Commit__MapItr struct {
 	idx int
 }
 
-func (itr *_Commit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
-	if itr.idx >= 9 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+func (itr *_Commit__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 10 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -367,37 +581,40 @@ func (itr *_Commit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 	case 3:
 		k = &fieldName__Commit_Author
 		if itr.n.author.m == schema.Maybe_Absent {
-			v = ipld.Absent
+			v = datamodel.Absent
 			break
 		}
 		v = itr.n.author.v
 	case 4:
 		k = &fieldName__Commit_Committer
 		if itr.n.committer.m == schema.Maybe_Absent {
-			v = ipld.Absent
+			v = datamodel.Absent
 			break
 		}
 		v = itr.n.committer.v
 	case 5:
 		k = &fieldName__Commit_Encoding
 		if itr.n.encoding.m == schema.Maybe_Absent {
-			v = ipld.Absent
+			v = datamodel.Absent
 			break
 		}
 		v = &itr.n.encoding.v
 	case 6:
-		k = &fieldName__Commit_Signature
-		if itr.n.signature.m == schema.Maybe_Absent {
-			v = ipld.Absent
-			break
-		}
-		v = &itr.n.signature.v
+		k = &fieldName__Commit_Signatures
+		v = &itr.n.signatures
 	case 7:
 		k = &fieldName__Commit_Mergetag
 		v = &itr.n.mergetag
 	case 8:
 		k = &fieldName__Commit_Other
 		v = &itr.n.other
+	case 9:
+		k = &fieldName__Commit_HeaderOrder
+		if itr.n.headerOrder.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = &itr.n.headerOrder.v
 	default:
 		panic("unreachable")
 	}
@@ -405,14 +622,14 @@ func (itr *_Commit__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 	return
 }
 func (itr *_Commit__MapItr) Done() bool {
-	return itr.idx >= 9
+	return itr.idx >= 10
 }
 
-func (Commit) ListIterator() ipld.ListIterator {
+func (Commit) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (Commit) Length() int64 {
-	return 9
+	return 10
 }
 func (Commit) IsAbsent() bool {
 	return false
@@ -435,16 +652,16 @@ func (Commit) AsString() (string, error) {
 func (Commit) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit"}.AsBytes()
 }
-func (Commit) AsLink() (ipld.Link, error) {
+func (Commit) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit"}.AsLink()
 }
-func (Commit) Prototype() ipld.NodePrototype {
+func (Commit) Prototype() datamodel.NodePrototype {
 	return _Commit__Prototype{}
 }
 
 type _Commit__Prototype struct{}
 
-func (_Commit__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Commit__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Commit__Builder
 	nb.Reset()
 	return &nb
@@ -454,7 +671,7 @@ type _Commit__Builder struct {
 	_Commit__Assembler
 }
 
-func (nb *_Commit__Builder) Build() ipld.Node {
+func (nb *_Commit__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -473,16 +690,17 @@ type _Commit__Assembler struct {
 	s     int
 	f     int
 
-	cm           schema.Maybe
-	ca_tree      _Tree_Link__Assembler
-	ca_parents   _Commit_Link_List__Assembler
-	ca_message   _String__Assembler
-	ca_author    _PersonInfo__Assembler
-	ca_committer _PersonInfo__Assembler
-	ca_encoding  _String__Assembler
-	ca_signature _GpgSig__Assembler
-	ca_mergetag  _Tag_List__Assembler
-	ca_other     _String_List__Assembler
+	cm             schema.Maybe
+	ca_tree        _Tree_Link__Assembler
+	ca_parents     _Commit_Link_List__Assembler
+	ca_message     _String__Assembler
+	ca_author      _PersonInfo__Assembler
+	ca_committer   _PersonInfo__Assembler
+	ca_encoding    _String__Assembler
+	ca_signatures  _Signature_List__Assembler
+	ca_mergetag    _Tag_List__Assembler
+	ca_other       _Header_List__Assembler
+	ca_headerOrder _String_List__Assembler
 }
 
 func (na *_Commit__Assembler) reset() {
@@ -494,9 +712,10 @@ func (na *_Commit__Assembler) reset() {
 	na.ca_author.reset()
 	na.ca_committer.reset()
 	na.ca_encoding.reset()
-	na.ca_signature.reset()
+	na.ca_signatures.reset()
 	na.ca_mergetag.reset()
 	na.ca_other.reset()
+	na.ca_headerOrder.reset()
 }
 
 var (
@@ -506,13 +725,14 @@ var (
 	fieldBit__Commit_Author      = 1 << 3
 	fieldBit__Commit_Committer   = 1 << 4
 	fieldBit__Commit_Encoding    = 1 << 5
-	fieldBit__Commit_Signature   = 1 << 6
+	fieldBit__Commit_Signatures  = 1 << 6
 	fieldBit__Commit_Mergetag    = 1 << 7
 	fieldBit__Commit_Other       = 1 << 8
-	fieldBits__Commit_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<7 + 1<<8
+	fieldBit__Commit_HeaderOrder = 1 << 9
+	fieldBits__Commit_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<6 + 1<<7 + 1<<8
 )
 
-func (na *_Commit__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_Commit__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -525,7 +745,7 @@ func (na *_Commit__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	return na, nil
 }
-func (_Commit__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Commit__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit"}.BeginList(0)
 }
 func (na *_Commit__Assembler) AssignNull() error {
@@ -557,10 +777,10 @@ func (_Commit__Assembler) AssignString(string) error {
 func (_Commit__Assembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit"}.AssignBytes(nil)
 }
-func (_Commit__Assembler) AssignLink(ipld.Link) error {
+func (_Commit__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit"}.AssignLink(nil)
 }
-func (na *_Commit__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Commit__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -580,8 +800,8 @@ func (na *_Commit__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Commit", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Commit", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -598,7 +818,7 @@ func (na *_Commit__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Commit__Assembler) Prototype() ipld.NodePrototype {
+func (_Commit__Assembler) Prototype() datamodel.NodePrototype {
 	return _Commit__Prototype{}
 }
 func (ma *_Commit__Assembler) valueFinishTidy() bool {
@@ -660,8 +880,10 @@ func (ma *_Commit__Assembler) valueFinishTidy() bool {
 			return false
 		}
 	case 6:
-		switch ma.w.signature.m {
+		switch ma.cm {
 		case schema.Maybe_Value:
+			ma.ca_signatures.w = nil
+			ma.cm = schema.Maybe_Absent
 			ma.state = maState_initial
 			return true
 		default:
@@ -687,11 +909,19 @@ func (ma *_Commit__Assembler) valueFinishTidy() bool {
 		default:
 			return false
 		}
+	case 9:
+		switch ma.w.headerOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
 	default:
 		panic("unreachable")
 	}
 }
-func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Commit__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -709,7 +939,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 	switch k {
 	case "tree":
 		if ma.s&fieldBit__Commit_Tree != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree}
 		}
 		ma.s += fieldBit__Commit_Tree
 		ma.state = maState_midValue
@@ -719,7 +949,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_tree, nil
 	case "parents":
 		if ma.s&fieldBit__Commit_Parents != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents}
 		}
 		ma.s += fieldBit__Commit_Parents
 		ma.state = maState_midValue
@@ -729,7 +959,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_parents, nil
 	case "message":
 		if ma.s&fieldBit__Commit_Message != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Message}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Message}
 		}
 		ma.s += fieldBit__Commit_Message
 		ma.state = maState_midValue
@@ -739,7 +969,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_message, nil
 	case "author":
 		if ma.s&fieldBit__Commit_Author != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Author}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Author}
 		}
 		ma.s += fieldBit__Commit_Author
 		ma.state = maState_midValue
@@ -749,7 +979,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_author, nil
 	case "committer":
 		if ma.s&fieldBit__Commit_Committer != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer}
 		}
 		ma.s += fieldBit__Commit_Committer
 		ma.state = maState_midValue
@@ -759,7 +989,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_committer, nil
 	case "encoding":
 		if ma.s&fieldBit__Commit_Encoding != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding}
 		}
 		ma.s += fieldBit__Commit_Encoding
 		ma.state = maState_midValue
@@ -767,19 +997,19 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		ma.ca_encoding.w = &ma.w.encoding.v
 		ma.ca_encoding.m = &ma.w.encoding.m
 		return &ma.ca_encoding, nil
-	case "signature":
-		if ma.s&fieldBit__Commit_Signature != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Signature}
+	case "signatures":
+		if ma.s&fieldBit__Commit_Signatures != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Signatures}
 		}
-		ma.s += fieldBit__Commit_Signature
+		ma.s += fieldBit__Commit_Signatures
 		ma.state = maState_midValue
 		ma.f = 6
-		ma.ca_signature.w = &ma.w.signature.v
-		ma.ca_signature.m = &ma.w.signature.m
-		return &ma.ca_signature, nil
+		ma.ca_signatures.w = &ma.w.signatures
+		ma.ca_signatures.m = &ma.cm
+		return &ma.ca_signatures, nil
 	case "mergetag":
 		if ma.s&fieldBit__Commit_Mergetag != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag}
 		}
 		ma.s += fieldBit__Commit_Mergetag
 		ma.state = maState_midValue
@@ -789,7 +1019,7 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		return &ma.ca_mergetag, nil
 	case "other":
 		if ma.s&fieldBit__Commit_Other != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Other}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Other}
 		}
 		ma.s += fieldBit__Commit_Other
 		ma.state = maState_midValue
@@ -797,10 +1027,20 @@ func (ma *_Commit__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error
 		ma.ca_other.w = &ma.w.other
 		ma.ca_other.m = &ma.cm
 		return &ma.ca_other, nil
+	case "headerOrder":
+		if ma.s&fieldBit__Commit_HeaderOrder != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_HeaderOrder}
+		}
+		ma.s += fieldBit__Commit_HeaderOrder
+		ma.state = maState_midValue
+		ma.f = 9
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+		return &ma.ca_headerOrder, nil
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.Commit", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Commit", Key: &_String{k}}
 }
-func (ma *_Commit__Assembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Commit__Assembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -818,7 +1058,7 @@ func (ma *_Commit__Assembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_Commit__KeyAssembler)(ma)
 }
-func (ma *_Commit__Assembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Commit__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -858,9 +1098,9 @@ func (ma *_Commit__Assembler) AssembleValue() ipld.NodeAssembler {
 		ma.ca_encoding.m = &ma.w.encoding.m
 		return &ma.ca_encoding
 	case 6:
-		ma.ca_signature.w = &ma.w.signature.v
-		ma.ca_signature.m = &ma.w.signature.m
-		return &ma.ca_signature
+		ma.ca_signatures.w = &ma.w.signatures
+		ma.ca_signatures.m = &ma.cm
+		return &ma.ca_signatures
 	case 7:
 		ma.ca_mergetag.w = &ma.w.mergetag
 		ma.ca_mergetag.m = &ma.cm
@@ -869,6 +1109,10 @@ func (ma *_Commit__Assembler) AssembleValue() ipld.NodeAssembler {
 		ma.ca_other.w = &ma.w.other
 		ma.ca_other.m = &ma.cm
 		return &ma.ca_other
+	case 9:
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+		return &ma.ca_headerOrder
 	default:
 		panic("unreachable")
 	}
@@ -889,7 +1133,7 @@ func (ma *_Commit__Assembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__Commit_sufficient != fieldBits__Commit_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__Commit_Tree == 0 {
 			err.Missing = append(err.Missing, "tree")
 		}
@@ -899,6 +1143,9 @@ func (ma *_Commit__Assembler) Finish() error {
 		if ma.s&fieldBit__Commit_Message == 0 {
 			err.Missing = append(err.Missing, "message")
 		}
+		if ma.s&fieldBit__Commit_Signatures == 0 {
+			err.Missing = append(err.Missing, "signatures")
+		}
 		if ma.s&fieldBit__Commit_Mergetag == 0 {
 			err.Missing = append(err.Missing, "mergetag")
 		}
@@ -911,19 +1158,19 @@ func (ma *_Commit__Assembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Commit__Assembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Commit__Assembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_Commit__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_Commit__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler valueprototype")
 }
 
 type _Commit__KeyAssembler _Commit__Assembler
 
-func (_Commit__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Commit__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.KeyAssembler"}.BeginMap(0)
 }
-func (_Commit__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Commit__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.KeyAssembler"}.BeginList(0)
 }
 func (na *_Commit__KeyAssembler) AssignNull() error {
@@ -945,7 +1192,7 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 	switch k {
 	case "tree":
 		if ka.s&fieldBit__Commit_Tree != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree}
 		}
 		ka.s += fieldBit__Commit_Tree
 		ka.state = maState_expectValue
@@ -953,7 +1200,7 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "parents":
 		if ka.s&fieldBit__Commit_Parents != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents}
 		}
 		ka.s += fieldBit__Commit_Parents
 		ka.state = maState_expectValue
@@ -961,7 +1208,7 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "message":
 		if ka.s&fieldBit__Commit_Message != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Message}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Message}
 		}
 		ka.s += fieldBit__Commit_Message
 		ka.state = maState_expectValue
@@ -969,7 +1216,7 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "author":
 		if ka.s&fieldBit__Commit_Author != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Author}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Author}
 		}
 		ka.s += fieldBit__Commit_Author
 		ka.state = maState_expectValue
@@ -977,7 +1224,7 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "committer":
 		if ka.s&fieldBit__Commit_Committer != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer}
 		}
 		ka.s += fieldBit__Commit_Committer
 		ka.state = maState_expectValue
@@ -985,23 +1232,23 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "encoding":
 		if ka.s&fieldBit__Commit_Encoding != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding}
 		}
 		ka.s += fieldBit__Commit_Encoding
 		ka.state = maState_expectValue
 		ka.f = 5
 		return nil
-	case "signature":
-		if ka.s&fieldBit__Commit_Signature != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Signature}
+	case "signatures":
+		if ka.s&fieldBit__Commit_Signatures != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Signatures}
 		}
-		ka.s += fieldBit__Commit_Signature
+		ka.s += fieldBit__Commit_Signatures
 		ka.state = maState_expectValue
 		ka.f = 6
 		return nil
 	case "mergetag":
 		if ka.s&fieldBit__Commit_Mergetag != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag}
 		}
 		ka.s += fieldBit__Commit_Mergetag
 		ka.state = maState_expectValue
@@ -1009,58 +1256,67 @@ func (ka *_Commit__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "other":
 		if ka.s&fieldBit__Commit_Other != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Other}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Other}
 		}
 		ka.s += fieldBit__Commit_Other
 		ka.state = maState_expectValue
 		ka.f = 8
 		return nil
+	case "headerOrder":
+		if ka.s&fieldBit__Commit_HeaderOrder != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_HeaderOrder}
+		}
+		ka.s += fieldBit__Commit_HeaderOrder
+		ka.state = maState_expectValue
+		ka.f = 9
+		return nil
 	default:
-		return ipld.ErrInvalidKey{TypeName: "ipldgit.Commit", Key: &_String{k}}
+		return schema.ErrInvalidKey{TypeName: "ipldgit.Commit", Key: &_String{k}}
 	}
 }
 func (_Commit__KeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.KeyAssembler"}.AssignBytes(nil)
 }
-func (_Commit__KeyAssembler) AssignLink(ipld.Link) error {
+func (_Commit__KeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_Commit__KeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_Commit__KeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_Commit__KeyAssembler) Prototype() ipld.NodePrototype {
+func (_Commit__KeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 func (Commit) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Commit) Representation() ipld.Node {
+func (n Commit) Representation() datamodel.Node {
 	return (*_Commit__Repr)(n)
 }
 
 type _Commit__Repr _Commit
 
 var (
-	fieldName__Commit_Tree_serial      = _String{"tree"}
-	fieldName__Commit_Parents_serial   = _String{"parents"}
-	fieldName__Commit_Message_serial   = _String{"message"}
-	fieldName__Commit_Author_serial    = _String{"author"}
-	fieldName__Commit_Committer_serial = _String{"committer"}
-	fieldName__Commit_Encoding_serial  = _String{"encoding"}
-	fieldName__Commit_Signature_serial = _String{"signature"}
-	fieldName__Commit_Mergetag_serial  = _String{"mergetag"}
-	fieldName__Commit_Other_serial     = _String{"other"}
+	fieldName__Commit_Tree_serial        = _String{"tree"}
+	fieldName__Commit_Parents_serial     = _String{"parents"}
+	fieldName__Commit_Message_serial     = _String{"message"}
+	fieldName__Commit_Author_serial      = _String{"author"}
+	fieldName__Commit_Committer_serial   = _String{"committer"}
+	fieldName__Commit_Encoding_serial    = _String{"encoding"}
+	fieldName__Commit_Signatures_serial  = _String{"signatures"}
+	fieldName__Commit_Mergetag_serial    = _String{"mergetag"}
+	fieldName__Commit_Other_serial       = _String{"other"}
+	fieldName__Commit_HeaderOrder_serial = _String{"headerOrder"}
 )
-var _ ipld.Node = &_Commit__Repr{}
+var _ datamodel.Node = &_Commit__Repr{}
 
-func (_Commit__Repr) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (_Commit__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n *_Commit__Repr) LookupByString(key string) (ipld.Node, error) {
+func (n *_Commit__Repr) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "tree":
 		return n.tree.Representation(), nil
@@ -1070,58 +1326,68 @@ func (n *_Commit__Repr) LookupByString(key string) (ipld.Node, error) {
 		return n.message.Representation(), nil
 	case "author":
 		if n.author.m == schema.Maybe_Absent {
-			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
 		}
 		return n.author.v.Representation(), nil
 	case "committer":
 		if n.committer.m == schema.Maybe_Absent {
-			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
 		}
 		return n.committer.v.Representation(), nil
 	case "encoding":
 		if n.encoding.m == schema.Maybe_Absent {
-			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
 		}
 		return n.encoding.v.Representation(), nil
-	case "signature":
-		if n.signature.m == schema.Maybe_Absent {
-			return ipld.Absent, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(key)}
-		}
-		return n.signature.v.Representation(), nil
+	case "signatures":
+		return n.signatures.Representation(), nil
 	case "mergetag":
 		return n.mergetag.Representation(), nil
 	case "other":
 		return n.other.Representation(), nil
+	case "headerOrder":
+		if n.headerOrder.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.headerOrder.v.Representation(), nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n *_Commit__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n *_Commit__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (_Commit__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (_Commit__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit.Repr"}.LookupByIndex(0)
 }
-func (n _Commit__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Commit__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n *_Commit__Repr) MapIterator() ipld.MapIterator {
-	return &_Commit__ReprMapItr{n, 0}
+func (n *_Commit__Repr) MapIterator() datamodel.MapIterator {
+	end := 10
+	if n.headerOrder.m == schema.Maybe_Absent {
+		end = 9
+	} else {
+		goto done
+	}
+done:
+	return &_Commit__ReprMapItr{n, 0, end}
 }
 
 type _Commit__ReprMapItr struct {
 	n   *_Commit__Repr
 	idx int
+	end int
 }
 
-func (itr *_Commit__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+func (itr *_Commit__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
 advance:
-	if itr.idx >= 9 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+	if itr.idx >= 10 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -1155,18 +1421,21 @@ advance:
 		}
 		v = itr.n.encoding.v.Representation()
 	case 6:
-		k = &fieldName__Commit_Signature_serial
-		if itr.n.signature.m == schema.Maybe_Absent {
-			itr.idx++
-			goto advance
-		}
-		v = itr.n.signature.v.Representation()
+		k = &fieldName__Commit_Signatures_serial
+		v = itr.n.signatures.Representation()
 	case 7:
 		k = &fieldName__Commit_Mergetag_serial
 		v = itr.n.mergetag.Representation()
 	case 8:
 		k = &fieldName__Commit_Other_serial
 		v = itr.n.other.Representation()
+	case 9:
+		k = &fieldName__Commit_HeaderOrder_serial
+		if itr.n.headerOrder.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.headerOrder.v.Representation()
 	default:
 		panic("unreachable")
 	}
@@ -1174,13 +1443,13 @@ advance:
 	return
 }
 func (itr *_Commit__ReprMapItr) Done() bool {
-	return itr.idx >= 9
+	return itr.idx >= itr.end
 }
-func (_Commit__Repr) ListIterator() ipld.ListIterator {
+func (_Commit__Repr) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (rn *_Commit__Repr) Length() int64 {
-	l := 9
+	l := 10
 	if rn.author.m == schema.Maybe_Absent {
 		l--
 	}
@@ -1190,7 +1459,7 @@ func (rn *_Commit__Repr) Length() int64 {
 	if rn.encoding.m == schema.Maybe_Absent {
 		l--
 	}
-	if rn.signature.m == schema.Maybe_Absent {
+	if rn.headerOrder.m == schema.Maybe_Absent {
 		l--
 	}
 	return int64(l)
@@ -1216,16 +1485,16 @@ func (_Commit__Repr) AsString() (string, error) {
 func (_Commit__Repr) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit.Repr"}.AsBytes()
 }
-func (_Commit__Repr) AsLink() (ipld.Link, error) {
+func (_Commit__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Commit.Repr"}.AsLink()
 }
-func (_Commit__Repr) Prototype() ipld.NodePrototype {
+func (_Commit__Repr) Prototype() datamodel.NodePrototype {
 	return _Commit__ReprPrototype{}
 }
 
 type _Commit__ReprPrototype struct{}
 
-func (_Commit__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_Commit__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Commit__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -1235,7 +1504,7 @@ type _Commit__ReprBuilder struct {
 	_Commit__ReprAssembler
 }
 
-func (nb *_Commit__ReprBuilder) Build() ipld.Node {
+func (nb *_Commit__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -1254,16 +1523,17 @@ type _Commit__ReprAssembler struct {
 	s     int
 	f     int
 
-	cm           schema.Maybe
-	ca_tree      _Tree_Link__ReprAssembler
-	ca_parents   _Commit_Link_List__ReprAssembler
-	ca_message   _String__ReprAssembler
-	ca_author    _PersonInfo__ReprAssembler
-	ca_committer _PersonInfo__ReprAssembler
-	ca_encoding  _String__ReprAssembler
-	ca_signature _GpgSig__ReprAssembler
-	ca_mergetag  _Tag_List__ReprAssembler
-	ca_other     _String_List__ReprAssembler
+	cm             schema.Maybe
+	ca_tree        _Tree_Link__ReprAssembler
+	ca_parents     _Commit_Link_List__ReprAssembler
+	ca_message     _String__ReprAssembler
+	ca_author      _PersonInfo__ReprAssembler
+	ca_committer   _PersonInfo__ReprAssembler
+	ca_encoding    _String__ReprAssembler
+	ca_signatures  _Signature_List__ReprAssembler
+	ca_mergetag    _Tag_List__ReprAssembler
+	ca_other       _Header_List__ReprAssembler
+	ca_headerOrder _String_List__ReprAssembler
 }
 
 func (na *_Commit__ReprAssembler) reset() {
@@ -1275,11 +1545,12 @@ func (na *_Commit__ReprAssembler) reset() {
 	na.ca_author.reset()
 	na.ca_committer.reset()
 	na.ca_encoding.reset()
-	na.ca_signature.reset()
+	na.ca_signatures.reset()
 	na.ca_mergetag.reset()
 	na.ca_other.reset()
+	na.ca_headerOrder.reset()
 }
-func (na *_Commit__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_Commit__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -1292,7 +1563,7 @@ func (na *_Commit__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	return na, nil
 }
-func (_Commit__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Commit__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit.Repr"}.BeginList(0)
 }
 func (na *_Commit__ReprAssembler) AssignNull() error {
@@ -1324,10 +1595,10 @@ func (_Commit__ReprAssembler) AssignString(string) error {
 func (_Commit__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit.Repr"}.AssignBytes(nil)
 }
-func (_Commit__ReprAssembler) AssignLink(ipld.Link) error {
+func (_Commit__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Commit.Repr"}.AssignLink(nil)
 }
-func (na *_Commit__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Commit__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -1347,8 +1618,8 @@ func (na *_Commit__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Commit.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Commit.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -1365,7 +1636,7 @@ func (na *_Commit__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Commit__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_Commit__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _Commit__ReprPrototype{}
 }
 func (ma *_Commit__ReprAssembler) valueFinishTidy() bool {
@@ -1424,8 +1695,9 @@ func (ma *_Commit__ReprAssembler) valueFinishTidy() bool {
 			return false
 		}
 	case 6:
-		switch ma.w.signature.m {
+		switch ma.cm {
 		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
 			ma.state = maState_initial
 			return true
 		default:
@@ -1449,11 +1721,19 @@ func (ma *_Commit__ReprAssembler) valueFinishTidy() bool {
 		default:
 			return false
 		}
+	case 9:
+		switch ma.w.headerOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
 	default:
 		panic("unreachable")
 	}
 }
-func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -1471,7 +1751,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 	switch k {
 	case "tree":
 		if ma.s&fieldBit__Commit_Tree != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree_serial}
 		}
 		ma.s += fieldBit__Commit_Tree
 		ma.state = maState_midValue
@@ -1481,7 +1761,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_tree, nil
 	case "parents":
 		if ma.s&fieldBit__Commit_Parents != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents_serial}
 		}
 		ma.s += fieldBit__Commit_Parents
 		ma.state = maState_midValue
@@ -1491,7 +1771,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_parents, nil
 	case "message":
 		if ma.s&fieldBit__Commit_Message != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Message_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Message_serial}
 		}
 		ma.s += fieldBit__Commit_Message
 		ma.state = maState_midValue
@@ -1501,7 +1781,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_message, nil
 	case "author":
 		if ma.s&fieldBit__Commit_Author != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Author_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Author_serial}
 		}
 		ma.s += fieldBit__Commit_Author
 		ma.state = maState_midValue
@@ -1512,7 +1792,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_author, nil
 	case "committer":
 		if ma.s&fieldBit__Commit_Committer != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer_serial}
 		}
 		ma.s += fieldBit__Commit_Committer
 		ma.state = maState_midValue
@@ -1523,7 +1803,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_committer, nil
 	case "encoding":
 		if ma.s&fieldBit__Commit_Encoding != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding_serial}
 		}
 		ma.s += fieldBit__Commit_Encoding
 		ma.state = maState_midValue
@@ -1532,20 +1812,19 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		ma.ca_encoding.m = &ma.w.encoding.m
 
 		return &ma.ca_encoding, nil
-	case "signature":
-		if ma.s&fieldBit__Commit_Signature != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Signature_serial}
+	case "signatures":
+		if ma.s&fieldBit__Commit_Signatures != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Signatures_serial}
 		}
-		ma.s += fieldBit__Commit_Signature
+		ma.s += fieldBit__Commit_Signatures
 		ma.state = maState_midValue
 		ma.f = 6
-		ma.ca_signature.w = &ma.w.signature.v
-		ma.ca_signature.m = &ma.w.signature.m
-
-		return &ma.ca_signature, nil
+		ma.ca_signatures.w = &ma.w.signatures
+		ma.ca_signatures.m = &ma.cm
+		return &ma.ca_signatures, nil
 	case "mergetag":
 		if ma.s&fieldBit__Commit_Mergetag != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag_serial}
 		}
 		ma.s += fieldBit__Commit_Mergetag
 		ma.state = maState_midValue
@@ -1555,7 +1834,7 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		return &ma.ca_mergetag, nil
 	case "other":
 		if ma.s&fieldBit__Commit_Other != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Other_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Other_serial}
 		}
 		ma.s += fieldBit__Commit_Other
 		ma.state = maState_midValue
@@ -1563,11 +1842,22 @@ func (ma *_Commit__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		ma.ca_other.w = &ma.w.other
 		ma.ca_other.m = &ma.cm
 		return &ma.ca_other, nil
+	case "headerOrder":
+		if ma.s&fieldBit__Commit_HeaderOrder != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_HeaderOrder_serial}
+		}
+		ma.s += fieldBit__Commit_HeaderOrder
+		ma.state = maState_midValue
+		ma.f = 9
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+
+		return &ma.ca_headerOrder, nil
 	default:
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.Commit.Repr", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Commit.Repr", Key: &_String{k}}
 }
-func (ma *_Commit__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Commit__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -1585,7 +1875,7 @@ func (ma *_Commit__ReprAssembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_Commit__ReprKeyAssembler)(ma)
 }
-func (ma *_Commit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Commit__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -1628,10 +1918,9 @@ func (ma *_Commit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
 
 		return &ma.ca_encoding
 	case 6:
-		ma.ca_signature.w = &ma.w.signature.v
-		ma.ca_signature.m = &ma.w.signature.m
-
-		return &ma.ca_signature
+		ma.ca_signatures.w = &ma.w.signatures
+		ma.ca_signatures.m = &ma.cm
+		return &ma.ca_signatures
 	case 7:
 		ma.ca_mergetag.w = &ma.w.mergetag
 		ma.ca_mergetag.m = &ma.cm
@@ -1640,6 +1929,11 @@ func (ma *_Commit__ReprAssembler) AssembleValue() ipld.NodeAssembler {
 		ma.ca_other.w = &ma.w.other
 		ma.ca_other.m = &ma.cm
 		return &ma.ca_other
+	case 9:
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+
+		return &ma.ca_headerOrder
 	default:
 		panic("unreachable")
 	}
@@ -1660,7 +1954,7 @@ func (ma *_Commit__ReprAssembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__Commit_sufficient != fieldBits__Commit_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__Commit_Tree == 0 {
 			err.Missing = append(err.Missing, "tree")
 		}
@@ -1670,6 +1964,9 @@ func (ma *_Commit__ReprAssembler) Finish() error {
 		if ma.s&fieldBit__Commit_Message == 0 {
 			err.Missing = append(err.Missing, "message")
 		}
+		if ma.s&fieldBit__Commit_Signatures == 0 {
+			err.Missing = append(err.Missing, "signatures")
+		}
 		if ma.s&fieldBit__Commit_Mergetag == 0 {
 			err.Missing = append(err.Missing, "mergetag")
 		}
@@ -1682,19 +1979,19 @@ func (ma *_Commit__ReprAssembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Commit__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Commit__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_Commit__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_Commit__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler repr valueprototype")
 }
 
 type _Commit__ReprKeyAssembler _Commit__ReprAssembler
 
-func (_Commit__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Commit__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.Repr.KeyAssembler"}.BeginMap(0)
 }
-func (_Commit__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Commit__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.Repr.KeyAssembler"}.BeginList(0)
 }
 func (na *_Commit__ReprKeyAssembler) AssignNull() error {
@@ -1716,7 +2013,7 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 	switch k {
 	case "tree":
 		if ka.s&fieldBit__Commit_Tree != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Tree_serial}
 		}
 		ka.s += fieldBit__Commit_Tree
 		ka.state = maState_expectValue
@@ -1724,7 +2021,7 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "parents":
 		if ka.s&fieldBit__Commit_Parents != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Parents_serial}
 		}
 		ka.s += fieldBit__Commit_Parents
 		ka.state = maState_expectValue
@@ -1732,7 +2029,7 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "message":
 		if ka.s&fieldBit__Commit_Message != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Message_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Message_serial}
 		}
 		ka.s += fieldBit__Commit_Message
 		ka.state = maState_expectValue
@@ -1740,7 +2037,7 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "author":
 		if ka.s&fieldBit__Commit_Author != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Author_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Author_serial}
 		}
 		ka.s += fieldBit__Commit_Author
 		ka.state = maState_expectValue
@@ -1748,7 +2045,7 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "committer":
 		if ka.s&fieldBit__Commit_Committer != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Committer_serial}
 		}
 		ka.s += fieldBit__Commit_Committer
 		ka.state = maState_expectValue
@@ -1756,23 +2053,23 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "encoding":
 		if ka.s&fieldBit__Commit_Encoding != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Encoding_serial}
 		}
 		ka.s += fieldBit__Commit_Encoding
 		ka.state = maState_expectValue
 		ka.f = 5
 		return nil
-	case "signature":
-		if ka.s&fieldBit__Commit_Signature != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Signature_serial}
+	case "signatures":
+		if ka.s&fieldBit__Commit_Signatures != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Signatures_serial}
 		}
-		ka.s += fieldBit__Commit_Signature
+		ka.s += fieldBit__Commit_Signatures
 		ka.state = maState_expectValue
 		ka.f = 6
 		return nil
 	case "mergetag":
 		if ka.s&fieldBit__Commit_Mergetag != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Mergetag_serial}
 		}
 		ka.s += fieldBit__Commit_Mergetag
 		ka.state = maState_expectValue
@@ -1780,36 +2077,44 @@ func (ka *_Commit__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "other":
 		if ka.s&fieldBit__Commit_Other != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Commit_Other_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_Other_serial}
 		}
 		ka.s += fieldBit__Commit_Other
 		ka.state = maState_expectValue
 		ka.f = 8
 		return nil
+	case "headerOrder":
+		if ka.s&fieldBit__Commit_HeaderOrder != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Commit_HeaderOrder_serial}
+		}
+		ka.s += fieldBit__Commit_HeaderOrder
+		ka.state = maState_expectValue
+		ka.f = 9
+		return nil
 	}
-	return ipld.ErrInvalidKey{TypeName: "ipldgit.Commit.Repr", Key: &_String{k}}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.Commit.Repr", Key: &_String{k}}
 }
 func (_Commit__ReprKeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.Repr.KeyAssembler"}.AssignBytes(nil)
 }
-func (_Commit__ReprKeyAssembler) AssignLink(ipld.Link) error {
+func (_Commit__ReprKeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Commit.Repr.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_Commit__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_Commit__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_Commit__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+func (_Commit__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 
-func (n Commit_Link) Link() ipld.Link {
+func (n Commit_Link) Link() datamodel.Link {
 	return n.x
 }
-func (_Commit_Link__Prototype) FromLink(v ipld.Link) (Commit_Link, error) {
+func (_Commit_Link__Prototype) FromLink(v datamodel.Link) (Commit_Link, error) {
 	n := _Commit_Link{v}
 	return &n, nil
 }
@@ -1829,12 +2134,12 @@ func (m MaybeCommit_Link) IsAbsent() bool {
 func (m MaybeCommit_Link) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeCommit_Link) AsNode() ipld.Node {
+func (m MaybeCommit_Link) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -1848,28 +2153,28 @@ func (m MaybeCommit_Link) Must() Commit_Link {
 	return &m.v
 }
 
-var _ ipld.Node = (Commit_Link)(&_Commit_Link{})
+var _ datamodel.Node = (Commit_Link)(&_Commit_Link{})
 var _ schema.TypedNode = (Commit_Link)(&_Commit_Link{})
 
-func (Commit_Link) Kind() ipld.Kind {
-	return ipld.Kind_Link
+func (Commit_Link) Kind() datamodel.Kind {
+	return datamodel.Kind_Link
 }
-func (Commit_Link) LookupByString(string) (ipld.Node, error) {
+func (Commit_Link) LookupByString(string) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Commit_Link"}.LookupByString("")
 }
-func (Commit_Link) LookupByNode(ipld.Node) (ipld.Node, error) {
+func (Commit_Link) LookupByNode(datamodel.Node) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Commit_Link"}.LookupByNode(nil)
 }
-func (Commit_Link) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Commit_Link) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Commit_Link"}.LookupByIndex(0)
 }
-func (Commit_Link) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (Commit_Link) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Commit_Link"}.LookupBySegment(seg)
 }
-func (Commit_Link) MapIterator() ipld.MapIterator {
+func (Commit_Link) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (Commit_Link) ListIterator() ipld.ListIterator {
+func (Commit_Link) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (Commit_Link) Length() int64 {
@@ -1896,16 +2201,16 @@ func (Commit_Link) AsString() (string, error) {
 func (Commit_Link) AsBytes() ([]byte, error) {
 	return mixins.Link{TypeName: "ipldgit.Commit_Link"}.AsBytes()
 }
-func (n Commit_Link) AsLink() (ipld.Link, error) {
+func (n Commit_Link) AsLink() (datamodel.Link, error) {
 	return n.x, nil
 }
-func (Commit_Link) Prototype() ipld.NodePrototype {
+func (Commit_Link) Prototype() datamodel.NodePrototype {
 	return _Commit_Link__Prototype{}
 }
 
 type _Commit_Link__Prototype struct{}
 
-func (_Commit_Link__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Commit_Link__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Commit_Link__Builder
 	nb.Reset()
 	return &nb
@@ -1915,7 +2220,7 @@ type _Commit_Link__Builder struct {
 	_Commit_Link__Assembler
 }
 
-func (nb *_Commit_Link__Builder) Build() ipld.Node {
+func (nb *_Commit_Link__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -1933,10 +2238,10 @@ type _Commit_Link__Assembler struct {
 }
 
 func (na *_Commit_Link__Assembler) reset() {}
-func (_Commit_Link__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Commit_Link__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Commit_Link"}.BeginMap(0)
 }
-func (_Commit_Link__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Commit_Link__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Commit_Link"}.BeginList(0)
 }
 func (na *_Commit_Link__Assembler) AssignNull() error {
@@ -1966,7 +2271,7 @@ func (_Commit_Link__Assembler) AssignString(string) error {
 func (_Commit_Link__Assembler) AssignBytes([]byte) error {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Commit_Link"}.AssignBytes(nil)
 }
-func (na *_Commit_Link__Assembler) AssignLink(v ipld.Link) error {
+func (na *_Commit_Link__Assembler) AssignLink(v datamodel.Link) error {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -1975,7 +2280,7 @@ func (na *_Commit_Link__Assembler) AssignLink(v ipld.Link) error {
 	*na.m = schema.Maybe_Value
 	return nil
 }
-func (na *_Commit_Link__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Commit_Link__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -1994,22 +2299,22 @@ func (na *_Commit_Link__Assembler) AssignNode(v ipld.Node) error {
 		return na.AssignLink(v2)
 	}
 }
-func (_Commit_Link__Assembler) Prototype() ipld.NodePrototype {
+func (_Commit_Link__Assembler) Prototype() datamodel.NodePrototype {
 	return _Commit_Link__Prototype{}
 }
 func (Commit_Link) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (Commit_Link) LinkTargetNodePrototype() ipld.NodePrototype {
+func (Commit_Link) LinkTargetNodePrototype() datamodel.NodePrototype {
 	return Type.Commit__Repr
 }
-func (n Commit_Link) Representation() ipld.Node {
+func (n Commit_Link) Representation() datamodel.Node {
 	return (*_Commit_Link__Repr)(n)
 }
 
 type _Commit_Link__Repr = _Commit_Link
 
-var _ ipld.Node = &_Commit_Link__Repr{}
+var _ datamodel.Node = &_Commit_Link__Repr{}
 
 type _Commit_Link__ReprPrototype = _Commit_Link__Prototype
 type _Commit_Link__ReprAssembler = _Commit_Link__Assembler
@@ -2071,12 +2376,12 @@ func (m MaybeCommit_Link_List) IsAbsent() bool {
 func (m MaybeCommit_Link_List) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeCommit_Link_List) AsNode() ipld.Node {
+func (m MaybeCommit_Link_List) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -2090,40 +2395,40 @@ func (m MaybeCommit_Link_List) Must() Commit_Link_List {
 	return &m.v
 }
 
-var _ ipld.Node = (Commit_Link_List)(&_Commit_Link_List{})
+var _ datamodel.Node = (Commit_Link_List)(&_Commit_Link_List{})
 var _ schema.TypedNode = (Commit_Link_List)(&_Commit_Link_List{})
 
-func (Commit_Link_List) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (Commit_Link_List) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (Commit_Link_List) LookupByString(string) (ipld.Node, error) {
+func (Commit_Link_List) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List"}.LookupByString("")
 }
-func (n Commit_Link_List) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (n Commit_Link_List) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	idx, err := k.AsInt()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByIndex(idx)
 }
-func (n Commit_Link_List) LookupByIndex(idx int64) (ipld.Node, error) {
+func (n Commit_Link_List) LookupByIndex(idx int64) (datamodel.Node, error) {
 	if n.Length() <= idx {
-		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfInt(idx)}
 	}
 	v := &n.x[idx]
 	return v, nil
 }
-func (n Commit_Link_List) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n Commit_Link_List) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.Commit_Link_List", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Commit_Link_List", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (Commit_Link_List) MapIterator() ipld.MapIterator {
+func (Commit_Link_List) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (n Commit_Link_List) ListIterator() ipld.ListIterator {
+func (n Commit_Link_List) ListIterator() datamodel.ListIterator {
 	return &_Commit_Link_List__ListItr{n, 0}
 }
 
@@ -2132,9 +2437,9 @@ type _Commit_Link_List__ListItr struct {
 	idx int
 }
 
-func (itr *_Commit_Link_List__ListItr) Next() (idx int64, v ipld.Node, _ error) {
+func (itr *_Commit_Link_List__ListItr) Next() (idx int64, v datamodel.Node, _ error) {
 	if itr.idx >= len(itr.n.x) {
-		return -1, nil, ipld.ErrIteratorOverread{}
+		return -1, nil, datamodel.ErrIteratorOverread{}
 	}
 	idx = int64(itr.idx)
 	x := &itr.n.x[itr.idx]
@@ -2170,16 +2475,16 @@ func (Commit_Link_List) AsString() (string, error) {
 func (Commit_Link_List) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List"}.AsBytes()
 }
-func (Commit_Link_List) AsLink() (ipld.Link, error) {
+func (Commit_Link_List) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List"}.AsLink()
 }
-func (Commit_Link_List) Prototype() ipld.NodePrototype {
+func (Commit_Link_List) Prototype() datamodel.NodePrototype {
 	return _Commit_Link_List__Prototype{}
 }
 
 type _Commit_Link_List__Prototype struct{}
 
-func (_Commit_Link_List__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Commit_Link_List__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Commit_Link_List__Builder
 	nb.Reset()
 	return &nb
@@ -2189,7 +2494,7 @@ type _Commit_Link_List__Builder struct {
 	_Commit_Link_List__Assembler
 }
 
-func (nb *_Commit_Link_List__Builder) Build() ipld.Node {
+func (nb *_Commit_Link_List__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -2214,10 +2519,10 @@ func (na *_Commit_Link_List__Assembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_Commit_Link_List__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Commit_Link_List__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List"}.BeginMap(0)
 }
-func (na *_Commit_Link_List__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_Commit_Link_List__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -2262,10 +2567,10 @@ func (_Commit_Link_List__Assembler) AssignString(string) error {
 func (_Commit_Link_List__Assembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List"}.AssignBytes(nil)
 }
-func (_Commit_Link_List__Assembler) AssignLink(ipld.Link) error {
+func (_Commit_Link_List__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List"}.AssignLink(nil)
 }
-func (na *_Commit_Link_List__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Commit_Link_List__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -2280,8 +2585,8 @@ func (na *_Commit_Link_List__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Commit_Link_List", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Commit_Link_List", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -2295,7 +2600,7 @@ func (na *_Commit_Link_List__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Commit_Link_List__Assembler) Prototype() ipld.NodePrototype {
+func (_Commit_Link_List__Assembler) Prototype() datamodel.NodePrototype {
 	return _Commit_Link_List__Prototype{}
 }
 func (la *_Commit_Link_List__Assembler) valueFinishTidy() bool {
@@ -2310,7 +2615,7 @@ func (la *_Commit_Link_List__Assembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_Commit_Link_List__Assembler) AssembleValue() ipld.NodeAssembler {
+func (la *_Commit_Link_List__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -2343,59 +2648,59 @@ func (la *_Commit_Link_List__Assembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_Commit_Link_List__Assembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_Commit_Link_List__Assembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _Commit_Link__Prototype{}
 }
 func (Commit_Link_List) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Commit_Link_List) Representation() ipld.Node {
+func (n Commit_Link_List) Representation() datamodel.Node {
 	return (*_Commit_Link_List__Repr)(n)
 }
 
 type _Commit_Link_List__Repr _Commit_Link_List
 
-var _ ipld.Node = &_Commit_Link_List__Repr{}
+var _ datamodel.Node = &_Commit_Link_List__Repr{}
 
-func (_Commit_Link_List__Repr) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (_Commit_Link_List__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (_Commit_Link_List__Repr) LookupByString(string) (ipld.Node, error) {
+func (_Commit_Link_List__Repr) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List.Repr"}.LookupByString("")
 }
-func (nr *_Commit_Link_List__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (nr *_Commit_Link_List__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	v, err := (Commit_Link_List)(nr).LookupByNode(k)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(Commit_Link).Representation(), nil
 }
-func (nr *_Commit_Link_List__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (nr *_Commit_Link_List__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	v, err := (Commit_Link_List)(nr).LookupByIndex(idx)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(Commit_Link).Representation(), nil
 }
-func (n _Commit_Link_List__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Commit_Link_List__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.Commit_Link_List.Repr", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Commit_Link_List.Repr", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (_Commit_Link_List__Repr) MapIterator() ipld.MapIterator {
+func (_Commit_Link_List__Repr) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (nr *_Commit_Link_List__Repr) ListIterator() ipld.ListIterator {
+func (nr *_Commit_Link_List__Repr) ListIterator() datamodel.ListIterator {
 	return &_Commit_Link_List__ReprListItr{(Commit_Link_List)(nr), 0}
 }
 
 type _Commit_Link_List__ReprListItr _Commit_Link_List__ListItr
 
-func (itr *_Commit_Link_List__ReprListItr) Next() (idx int64, v ipld.Node, err error) {
+func (itr *_Commit_Link_List__ReprListItr) Next() (idx int64, v datamodel.Node, err error) {
 	idx, v, err = (*_Commit_Link_List__ListItr)(itr).Next()
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return
 	}
 	return idx, v.(Commit_Link).Representation(), nil
@@ -2428,16 +2733,16 @@ func (_Commit_Link_List__Repr) AsString() (string, error) {
 func (_Commit_Link_List__Repr) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List.Repr"}.AsBytes()
 }
-func (_Commit_Link_List__Repr) AsLink() (ipld.Link, error) {
+func (_Commit_Link_List__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.Commit_Link_List.Repr"}.AsLink()
 }
-func (_Commit_Link_List__Repr) Prototype() ipld.NodePrototype {
+func (_Commit_Link_List__Repr) Prototype() datamodel.NodePrototype {
 	return _Commit_Link_List__ReprPrototype{}
 }
 
 type _Commit_Link_List__ReprPrototype struct{}
 
-func (_Commit_Link_List__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_Commit_Link_List__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Commit_Link_List__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -2447,7 +2752,7 @@ type _Commit_Link_List__ReprBuilder struct {
 	_Commit_Link_List__ReprAssembler
 }
 
-func (nb *_Commit_Link_List__ReprBuilder) Build() ipld.Node {
+func (nb *_Commit_Link_List__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -2472,10 +2777,10 @@ func (na *_Commit_Link_List__ReprAssembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_Commit_Link_List__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Commit_Link_List__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List.Repr"}.BeginMap(0)
 }
-func (na *_Commit_Link_List__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_Commit_Link_List__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -2520,10 +2825,10 @@ func (_Commit_Link_List__ReprAssembler) AssignString(string) error {
 func (_Commit_Link_List__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List.Repr"}.AssignBytes(nil)
 }
-func (_Commit_Link_List__ReprAssembler) AssignLink(ipld.Link) error {
+func (_Commit_Link_List__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Commit_Link_List.Repr"}.AssignLink(nil)
 }
-func (na *_Commit_Link_List__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Commit_Link_List__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -2538,8 +2843,8 @@ func (na *_Commit_Link_List__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Commit_Link_List.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Commit_Link_List.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -2553,7 +2858,7 @@ func (na *_Commit_Link_List__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Commit_Link_List__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_Commit_Link_List__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _Commit_Link_List__ReprPrototype{}
 }
 func (la *_Commit_Link_List__ReprAssembler) valueFinishTidy() bool {
@@ -2568,7 +2873,7 @@ func (la *_Commit_Link_List__ReprAssembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_Commit_Link_List__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (la *_Commit_Link_List__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -2601,636 +2906,641 @@ func (la *_Commit_Link_List__ReprAssembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_Commit_Link_List__ReprAssembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_Commit_Link_List__ReprAssembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _Commit_Link__ReprPrototype{}
 }
 
-func (n GpgSig) String() string {
-	return n.x
-}
-func (_GpgSig__Prototype) fromString(w *_GpgSig, v string) error {
-	*w = _GpgSig{v}
-	return nil
+func (n _Header) FieldKey() String {
+	return &n.key
 }
-func (_GpgSig__Prototype) FromString(v string) (GpgSig, error) {
-	n := _GpgSig{v}
-	return &n, nil
+func (n _Header) FieldValue() String {
+	return &n.value
 }
 
-type _GpgSig__Maybe struct {
+type _Header__Maybe struct {
 	m schema.Maybe
-	v _GpgSig
+	v Header
 }
-type MaybeGpgSig = *_GpgSig__Maybe
+type MaybeHeader = *_Header__Maybe
 
-func (m MaybeGpgSig) IsNull() bool {
+func (m MaybeHeader) IsNull() bool {
 	return m.m == schema.Maybe_Null
 }
-func (m MaybeGpgSig) IsAbsent() bool {
+func (m MaybeHeader) IsAbsent() bool {
 	return m.m == schema.Maybe_Absent
 }
-func (m MaybeGpgSig) Exists() bool {
+func (m MaybeHeader) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeGpgSig) AsNode() ipld.Node {
+func (m MaybeHeader) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
-		return &m.v
+		return m.v
 	default:
 		panic("unreachable")
 	}
 }
-func (m MaybeGpgSig) Must() GpgSig {
+func (m MaybeHeader) Must() Header {
 	if !m.Exists() {
 		panic("unbox of a maybe rejected")
 	}
-	return &m.v
+	return m.v
 }
 
-var _ ipld.Node = (GpgSig)(&_GpgSig{})
-var _ schema.TypedNode = (GpgSig)(&_GpgSig{})
+var (
+	fieldName__Header_Key   = _String{"key"}
+	fieldName__Header_Value = _String{"value"}
+)
+var _ datamodel.Node = (Header)(&_Header{})
+var _ schema.TypedNode = (Header)(&_Header{})
 
-func (GpgSig) Kind() ipld.Kind {
-	return ipld.Kind_String
+func (Header) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+func (n Header) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "key":
+		return &n.key, nil
+	case "value":
+		return &n.value, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
+	}
 }
-func (GpgSig) LookupByString(string) (ipld.Node, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.LookupByString("")
+func (n Header) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
 }
-func (GpgSig) LookupByNode(ipld.Node) (ipld.Node, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.LookupByNode(nil)
+func (Header) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.LookupByIndex(0)
 }
-func (GpgSig) LookupByIndex(idx int64) (ipld.Node, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.LookupByIndex(0)
+func (n Header) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return n.LookupByString(seg.String())
 }
-func (GpgSig) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.LookupBySegment(seg)
+func (n Header) MapIterator() datamodel.MapIterator {
+	return &_Header__MapItr{n, 0}
 }
-func (GpgSig) MapIterator() ipld.MapIterator {
-	return nil
+
+type _Header__MapItr struct {
+	n   Header
+	idx int
+}
+
+func (itr *_Header__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__Header_Key
+		v = &itr.n.key
+	case 1:
+		k = &fieldName__Header_Value
+		v = &itr.n.value
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_Header__MapItr) Done() bool {
+	return itr.idx >= 2
 }
-func (GpgSig) ListIterator() ipld.ListIterator {
+
+func (Header) ListIterator() datamodel.ListIterator {
 	return nil
 }
-func (GpgSig) Length() int64 {
-	return -1
+func (Header) Length() int64 {
+	return 2
 }
-func (GpgSig) IsAbsent() bool {
+func (Header) IsAbsent() bool {
 	return false
 }
-func (GpgSig) IsNull() bool {
+func (Header) IsNull() bool {
 	return false
 }
-func (GpgSig) AsBool() (bool, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.AsBool()
+func (Header) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsBool()
 }
-func (GpgSig) AsInt() (int64, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.AsInt()
+func (Header) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsInt()
 }
-func (GpgSig) AsFloat() (float64, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.AsFloat()
+func (Header) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsFloat()
 }
-func (n GpgSig) AsString() (string, error) {
-	return n.x, nil
+func (Header) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsString()
 }
-func (GpgSig) AsBytes() ([]byte, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.AsBytes()
+func (Header) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsBytes()
 }
-func (GpgSig) AsLink() (ipld.Link, error) {
-	return mixins.String{TypeName: "ipldgit.GpgSig"}.AsLink()
+func (Header) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.Header"}.AsLink()
 }
-func (GpgSig) Prototype() ipld.NodePrototype {
-	return _GpgSig__Prototype{}
+func (Header) Prototype() datamodel.NodePrototype {
+	return _Header__Prototype{}
 }
 
-type _GpgSig__Prototype struct{}
+type _Header__Prototype struct{}
 
-func (_GpgSig__Prototype) NewBuilder() ipld.NodeBuilder {
-	var nb _GpgSig__Builder
+func (_Header__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Header__Builder
 	nb.Reset()
 	return &nb
 }
 
-type _GpgSig__Builder struct {
-	_GpgSig__Assembler
+type _Header__Builder struct {
+	_Header__Assembler
 }
 
-func (nb *_GpgSig__Builder) Build() ipld.Node {
+func (nb *_Header__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
 	return nb.w
 }
-func (nb *_GpgSig__Builder) Reset() {
-	var w _GpgSig
+func (nb *_Header__Builder) Reset() {
+	var w _Header
 	var m schema.Maybe
-	*nb = _GpgSig__Builder{_GpgSig__Assembler{w: &w, m: &m}}
-}
-
-type _GpgSig__Assembler struct {
-	w *_GpgSig
-	m *schema.Maybe
+	*nb = _Header__Builder{_Header__Assembler{w: &w, m: &m}}
 }
 
-func (na *_GpgSig__Assembler) reset() {}
-func (_GpgSig__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.BeginMap(0)
-}
-func (_GpgSig__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.BeginList(0)
-}
-func (na *_GpgSig__Assembler) AssignNull() error {
+type _Header__Assembler struct {
+	w     *_Header
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm       schema.Maybe
+	ca_key   _String__Assembler
+	ca_value _String__Assembler
+}
+
+func (na *_Header__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_key.reset()
+	na.ca_value.reset()
+}
+
+var (
+	fieldBit__Header_Key         = 1 << 0
+	fieldBit__Header_Value       = 1 << 1
+	fieldBits__Header_sufficient = 0 + 1<<0 + 1<<1
+)
+
+func (na *_Header__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Header{}
+	}
+	return na, nil
+}
+func (_Header__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.BeginList(0)
+}
+func (na *_Header__Assembler) AssignNull() error {
 	switch *na.m {
 	case allowNull:
 		*na.m = schema.Maybe_Null
 		return nil
 	case schema.Maybe_Absent:
-		return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignNull()
+		return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignNull()
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
 	}
 	panic("unreachable")
 }
-func (_GpgSig__Assembler) AssignBool(bool) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignBool(false)
+func (_Header__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignBool(false)
 }
-func (_GpgSig__Assembler) AssignInt(int64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignInt(0)
+func (_Header__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignInt(0)
 }
-func (_GpgSig__Assembler) AssignFloat(float64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignFloat(0)
+func (_Header__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignFloat(0)
 }
-func (na *_GpgSig__Assembler) AssignString(v string) error {
-	switch *na.m {
-	case schema.Maybe_Value, schema.Maybe_Null:
-		panic("invalid state: cannot assign into assembler that's already finished")
-	}
-	na.w.x = v
-	*na.m = schema.Maybe_Value
-	return nil
+func (_Header__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignString("")
 }
-func (_GpgSig__Assembler) AssignBytes([]byte) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignBytes(nil)
+func (_Header__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignBytes(nil)
 }
-func (_GpgSig__Assembler) AssignLink(ipld.Link) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.GpgSig"}.AssignLink(nil)
+func (_Header__Assembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header"}.AssignLink(nil)
 }
-func (na *_GpgSig__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Header__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
-	if v2, ok := v.(*_GpgSig); ok {
+	if v2, ok := v.(*_Header); ok {
 		switch *na.m {
 		case schema.Maybe_Value, schema.Maybe_Null:
 			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
 		}
 		*na.w = *v2
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v2, err := v.AsString(); err != nil {
-		return err
-	} else {
-		return na.AssignString(v2)
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Header", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
 }
-func (_GpgSig__Assembler) Prototype() ipld.NodePrototype {
-	return _GpgSig__Prototype{}
-}
-func (GpgSig) Type() schema.Type {
-	return nil /*TODO:typelit*/
-}
-func (n GpgSig) Representation() ipld.Node {
-	return (*_GpgSig__Repr)(n)
-}
-
-type _GpgSig__Repr = _GpgSig
-
-var _ ipld.Node = &_GpgSig__Repr{}
-
-type _GpgSig__ReprPrototype = _GpgSig__Prototype
-type _GpgSig__ReprAssembler = _GpgSig__Assembler
-
-func (n Link) Link() ipld.Link {
-	return n.x
-}
-func (_Link__Prototype) FromLink(v ipld.Link) (Link, error) {
-	n := _Link{v}
-	return &n, nil
-}
-
-type _Link__Maybe struct {
-	m schema.Maybe
-	v _Link
+func (_Header__Assembler) Prototype() datamodel.NodePrototype {
+	return _Header__Prototype{}
 }
-type MaybeLink = *_Link__Maybe
-
-func (m MaybeLink) IsNull() bool {
-	return m.m == schema.Maybe_Null
+func (ma *_Header__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_key.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_value.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
 }
-func (m MaybeLink) IsAbsent() bool {
-	return m.m == schema.Maybe_Absent
+func (ma *_Header__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "key":
+		if ma.s&fieldBit__Header_Key != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Key}
+		}
+		ma.s += fieldBit__Header_Key
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_key.w = &ma.w.key
+		ma.ca_key.m = &ma.cm
+		return &ma.ca_key, nil
+	case "value":
+		if ma.s&fieldBit__Header_Value != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Value}
+		}
+		ma.s += fieldBit__Header_Value
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_value.w = &ma.w.value
+		ma.ca_value.m = &ma.cm
+		return &ma.ca_value, nil
+	}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Header", Key: &_String{k}}
 }
-func (m MaybeLink) Exists() bool {
-	return m.m == schema.Maybe_Value
+func (ma *_Header__Assembler) AssembleKey() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Header__KeyAssembler)(ma)
 }
-func (m MaybeLink) AsNode() ipld.Node {
-	switch m.m {
-	case schema.Maybe_Absent:
-		return ipld.Absent
-	case schema.Maybe_Null:
-		return ipld.Null
-	case schema.Maybe_Value:
-		return &m.v
+func (ma *_Header__Assembler) AssembleValue() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_key.w = &ma.w.key
+		ma.ca_key.m = &ma.cm
+		return &ma.ca_key
+	case 1:
+		ma.ca_value.w = &ma.w.value
+		ma.ca_value.m = &ma.cm
+		return &ma.ca_value
 	default:
 		panic("unreachable")
 	}
 }
-func (m MaybeLink) Must() Link {
-	if !m.Exists() {
-		panic("unbox of a maybe rejected")
+func (ma *_Header__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
-	return &m.v
-}
-
-var _ ipld.Node = (Link)(&_Link{})
-var _ schema.TypedNode = (Link)(&_Link{})
-
-func (Link) Kind() ipld.Kind {
-	return ipld.Kind_Link
-}
-func (Link) LookupByString(string) (ipld.Node, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByString("")
+	if ma.s&fieldBits__Header_sufficient != fieldBits__Header_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Header_Key == 0 {
+			err.Missing = append(err.Missing, "key")
+		}
+		if ma.s&fieldBit__Header_Value == 0 {
+			err.Missing = append(err.Missing, "value")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
 }
-func (Link) LookupByNode(ipld.Node) (ipld.Node, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByNode(nil)
+func (ma *_Header__Assembler) KeyPrototype() datamodel.NodePrototype {
+	return _String__Prototype{}
 }
-func (Link) LookupByIndex(idx int64) (ipld.Node, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByIndex(0)
+func (ma *_Header__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
 }
-func (Link) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.LookupBySegment(seg)
+
+type _Header__KeyAssembler _Header__Assembler
+
+func (_Header__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.BeginMap(0)
 }
-func (Link) MapIterator() ipld.MapIterator {
-	return nil
+func (_Header__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.BeginList(0)
 }
-func (Link) ListIterator() ipld.ListIterator {
-	return nil
+func (na *_Header__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignNull()
 }
-func (Link) Length() int64 {
-	return -1
+func (_Header__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignBool(false)
 }
-func (Link) IsAbsent() bool {
-	return false
+func (_Header__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignInt(0)
 }
-func (Link) IsNull() bool {
-	return false
+func (_Header__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignFloat(0)
 }
-func (Link) AsBool() (bool, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.AsBool()
+func (ka *_Header__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "key":
+		if ka.s&fieldBit__Header_Key != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Key}
+		}
+		ka.s += fieldBit__Header_Key
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "value":
+		if ka.s&fieldBit__Header_Value != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Value}
+		}
+		ka.s += fieldBit__Header_Value
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	default:
+		return schema.ErrInvalidKey{TypeName: "ipldgit.Header", Key: &_String{k}}
+	}
 }
-func (Link) AsInt() (int64, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.AsInt()
+func (_Header__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignBytes(nil)
 }
-func (Link) AsFloat() (float64, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.AsFloat()
+func (_Header__KeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.KeyAssembler"}.AssignLink(nil)
 }
-func (Link) AsString() (string, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.AsString()
+func (ka *_Header__KeyAssembler) AssignNode(v datamodel.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
 }
-func (Link) AsBytes() ([]byte, error) {
-	return mixins.Link{TypeName: "ipldgit.Link"}.AsBytes()
+func (_Header__KeyAssembler) Prototype() datamodel.NodePrototype {
+	return _String__Prototype{}
 }
-func (n Link) AsLink() (ipld.Link, error) {
-	return n.x, nil
+func (Header) Type() schema.Type {
+	return nil /*TODO:typelit*/
 }
-func (Link) Prototype() ipld.NodePrototype {
-	return _Link__Prototype{}
+func (n Header) Representation() datamodel.Node {
+	return (*_Header__Repr)(n)
 }
 
-type _Link__Prototype struct{}
+type _Header__Repr _Header
 
-func (_Link__Prototype) NewBuilder() ipld.NodeBuilder {
-	var nb _Link__Builder
-	nb.Reset()
-	return &nb
-}
+var (
+	fieldName__Header_Key_serial   = _String{"key"}
+	fieldName__Header_Value_serial = _String{"value"}
+)
+var _ datamodel.Node = &_Header__Repr{}
 
-type _Link__Builder struct {
-	_Link__Assembler
+func (_Header__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-
-func (nb *_Link__Builder) Build() ipld.Node {
-	if *nb.m != schema.Maybe_Value {
-		panic("invalid state: cannot call Build on an assembler that's not finished")
-	}
-	return nb.w
-}
-func (nb *_Link__Builder) Reset() {
-	var w _Link
-	var m schema.Maybe
-	*nb = _Link__Builder{_Link__Assembler{w: &w, m: &m}}
-}
-
-type _Link__Assembler struct {
-	w *_Link
-	m *schema.Maybe
-}
-
-func (na *_Link__Assembler) reset() {}
-func (_Link__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.BeginMap(0)
-}
-func (_Link__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.BeginList(0)
-}
-func (na *_Link__Assembler) AssignNull() error {
-	switch *na.m {
-	case allowNull:
-		*na.m = schema.Maybe_Null
-		return nil
-	case schema.Maybe_Absent:
-		return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignNull()
-	case schema.Maybe_Value, schema.Maybe_Null:
-		panic("invalid state: cannot assign into assembler that's already finished")
-	}
-	panic("unreachable")
-}
-func (_Link__Assembler) AssignBool(bool) error {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignBool(false)
-}
-func (_Link__Assembler) AssignInt(int64) error {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignInt(0)
-}
-func (_Link__Assembler) AssignFloat(float64) error {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignFloat(0)
-}
-func (_Link__Assembler) AssignString(string) error {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignString("")
-}
-func (_Link__Assembler) AssignBytes([]byte) error {
-	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignBytes(nil)
-}
-func (na *_Link__Assembler) AssignLink(v ipld.Link) error {
-	switch *na.m {
-	case schema.Maybe_Value, schema.Maybe_Null:
-		panic("invalid state: cannot assign into assembler that's already finished")
-	}
-	na.w.x = v
-	*na.m = schema.Maybe_Value
-	return nil
-}
-func (na *_Link__Assembler) AssignNode(v ipld.Node) error {
-	if v.IsNull() {
-		return na.AssignNull()
-	}
-	if v2, ok := v.(*_Link); ok {
-		switch *na.m {
-		case schema.Maybe_Value, schema.Maybe_Null:
-			panic("invalid state: cannot assign into assembler that's already finished")
-		}
-		*na.w = *v2
-		*na.m = schema.Maybe_Value
-		return nil
-	}
-	if v2, err := v.AsLink(); err != nil {
-		return err
-	} else {
-		return na.AssignLink(v2)
-	}
-}
-func (_Link__Assembler) Prototype() ipld.NodePrototype {
-	return _Link__Prototype{}
-}
-func (Link) Type() schema.Type {
-	return nil /*TODO:typelit*/
-}
-func (n Link) Representation() ipld.Node {
-	return (*_Link__Repr)(n)
-}
-
-type _Link__Repr = _Link
-
-var _ ipld.Node = &_Link__Repr{}
-
-type _Link__ReprPrototype = _Link__Prototype
-type _Link__ReprAssembler = _Link__Assembler
-
-func (n _PersonInfo) FieldDate() String {
-	return &n.date
-}
-func (n _PersonInfo) FieldTimezone() String {
-	return &n.timezone
-}
-func (n _PersonInfo) FieldEmail() String {
-	return &n.email
-}
-func (n _PersonInfo) FieldName() String {
-	return &n.name
-}
-
-type _PersonInfo__Maybe struct {
-	m schema.Maybe
-	v PersonInfo
-}
-type MaybePersonInfo = *_PersonInfo__Maybe
-
-func (m MaybePersonInfo) IsNull() bool {
-	return m.m == schema.Maybe_Null
-}
-func (m MaybePersonInfo) IsAbsent() bool {
-	return m.m == schema.Maybe_Absent
-}
-func (m MaybePersonInfo) Exists() bool {
-	return m.m == schema.Maybe_Value
-}
-func (m MaybePersonInfo) AsNode() ipld.Node {
-	switch m.m {
-	case schema.Maybe_Absent:
-		return ipld.Absent
-	case schema.Maybe_Null:
-		return ipld.Null
-	case schema.Maybe_Value:
-		return m.v
-	default:
-		panic("unreachable")
-	}
-}
-func (m MaybePersonInfo) Must() PersonInfo {
-	if !m.Exists() {
-		panic("unbox of a maybe rejected")
-	}
-	return m.v
-}
-
-var (
-	fieldName__PersonInfo_Date     = _String{"date"}
-	fieldName__PersonInfo_Timezone = _String{"timezone"}
-	fieldName__PersonInfo_Email    = _String{"email"}
-	fieldName__PersonInfo_Name     = _String{"name"}
-)
-var _ ipld.Node = (PersonInfo)(&_PersonInfo{})
-var _ schema.TypedNode = (PersonInfo)(&_PersonInfo{})
-
-func (PersonInfo) Kind() ipld.Kind {
-	return ipld.Kind_Map
-}
-func (n PersonInfo) LookupByString(key string) (ipld.Node, error) {
-	switch key {
-	case "date":
-		return &n.date, nil
-	case "timezone":
-		return &n.timezone, nil
-	case "email":
-		return &n.email, nil
-	case "name":
-		return &n.name, nil
-	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+func (n *_Header__Repr) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "key":
+		return n.key.Representation(), nil
+	case "value":
+		return n.value.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n PersonInfo) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n *_Header__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (PersonInfo) LookupByIndex(idx int64) (ipld.Node, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.LookupByIndex(0)
+func (_Header__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.LookupByIndex(0)
 }
-func (n PersonInfo) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Header__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n PersonInfo) MapIterator() ipld.MapIterator {
-	return &_PersonInfo__MapItr{n, 0}
+func (n *_Header__Repr) MapIterator() datamodel.MapIterator {
+	return &_Header__ReprMapItr{n, 0}
 }
 
-type _PersonInfo__MapItr struct {
-	n   PersonInfo
+type _Header__ReprMapItr struct {
+	n   *_Header__Repr
 	idx int
 }
 
-func (itr *_PersonInfo__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
-	if itr.idx >= 4 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+func (itr *_Header__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
-		k = &fieldName__PersonInfo_Date
-		v = &itr.n.date
+		k = &fieldName__Header_Key_serial
+		v = itr.n.key.Representation()
 	case 1:
-		k = &fieldName__PersonInfo_Timezone
-		v = &itr.n.timezone
-	case 2:
-		k = &fieldName__PersonInfo_Email
-		v = &itr.n.email
-	case 3:
-		k = &fieldName__PersonInfo_Name
-		v = &itr.n.name
+		k = &fieldName__Header_Value_serial
+		v = itr.n.value.Representation()
 	default:
 		panic("unreachable")
 	}
 	itr.idx++
 	return
 }
-func (itr *_PersonInfo__MapItr) Done() bool {
-	return itr.idx >= 4
+func (itr *_Header__ReprMapItr) Done() bool {
+	return itr.idx >= 2
 }
-
-func (PersonInfo) ListIterator() ipld.ListIterator {
+func (_Header__Repr) ListIterator() datamodel.ListIterator {
 	return nil
 }
-func (PersonInfo) Length() int64 {
-	return 4
+func (rn *_Header__Repr) Length() int64 {
+	l := 2
+	return int64(l)
 }
-func (PersonInfo) IsAbsent() bool {
+func (_Header__Repr) IsAbsent() bool {
 	return false
 }
-func (PersonInfo) IsNull() bool {
+func (_Header__Repr) IsNull() bool {
 	return false
 }
-func (PersonInfo) AsBool() (bool, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsBool()
+func (_Header__Repr) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsBool()
 }
-func (PersonInfo) AsInt() (int64, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsInt()
+func (_Header__Repr) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsInt()
 }
-func (PersonInfo) AsFloat() (float64, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsFloat()
+func (_Header__Repr) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsFloat()
 }
-func (PersonInfo) AsString() (string, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsString()
+func (_Header__Repr) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsString()
 }
-func (PersonInfo) AsBytes() ([]byte, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsBytes()
+func (_Header__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsBytes()
 }
-func (PersonInfo) AsLink() (ipld.Link, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsLink()
+func (_Header__Repr) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.Header.Repr"}.AsLink()
 }
-func (PersonInfo) Prototype() ipld.NodePrototype {
-	return _PersonInfo__Prototype{}
+func (_Header__Repr) Prototype() datamodel.NodePrototype {
+	return _Header__ReprPrototype{}
 }
 
-type _PersonInfo__Prototype struct{}
+type _Header__ReprPrototype struct{}
 
-func (_PersonInfo__Prototype) NewBuilder() ipld.NodeBuilder {
-	var nb _PersonInfo__Builder
+func (_Header__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Header__ReprBuilder
 	nb.Reset()
 	return &nb
 }
 
-type _PersonInfo__Builder struct {
-	_PersonInfo__Assembler
+type _Header__ReprBuilder struct {
+	_Header__ReprAssembler
 }
 
-func (nb *_PersonInfo__Builder) Build() ipld.Node {
+func (nb *_Header__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
 	return nb.w
 }
-func (nb *_PersonInfo__Builder) Reset() {
-	var w _PersonInfo
+func (nb *_Header__ReprBuilder) Reset() {
+	var w _Header
 	var m schema.Maybe
-	*nb = _PersonInfo__Builder{_PersonInfo__Assembler{w: &w, m: &m}}
+	*nb = _Header__ReprBuilder{_Header__ReprAssembler{w: &w, m: &m}}
 }
 
-type _PersonInfo__Assembler struct {
-	w     *_PersonInfo
+type _Header__ReprAssembler struct {
+	w     *_Header
 	m     *schema.Maybe
 	state maState
 	s     int
 	f     int
 
-	cm          schema.Maybe
-	ca_date     _String__Assembler
-	ca_timezone _String__Assembler
-	ca_email    _String__Assembler
-	ca_name     _String__Assembler
+	cm       schema.Maybe
+	ca_key   _String__ReprAssembler
+	ca_value _String__ReprAssembler
 }
 
-func (na *_PersonInfo__Assembler) reset() {
+func (na *_Header__ReprAssembler) reset() {
 	na.state = maState_initial
 	na.s = 0
-	na.ca_date.reset()
-	na.ca_timezone.reset()
-	na.ca_email.reset()
-	na.ca_name.reset()
+	na.ca_key.reset()
+	na.ca_value.reset()
 }
-
-var (
-	fieldBit__PersonInfo_Date        = 1 << 0
-	fieldBit__PersonInfo_Timezone    = 1 << 1
-	fieldBit__PersonInfo_Email       = 1 << 2
-	fieldBit__PersonInfo_Name        = 1 << 3
-	fieldBits__PersonInfo_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<3
-)
-
-func (na *_PersonInfo__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_Header__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -3239,20 +3549,20 @@ func (na *_PersonInfo__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	*na.m = midvalue
 	if na.w == nil {
-		na.w = &_PersonInfo{}
+		na.w = &_Header{}
 	}
 	return na, nil
 }
-func (_PersonInfo__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.BeginList(0)
+func (_Header__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.BeginList(0)
 }
-func (na *_PersonInfo__Assembler) AssignNull() error {
+func (na *_Header__ReprAssembler) AssignNull() error {
 	switch *na.m {
 	case allowNull:
 		*na.m = schema.Maybe_Null
 		return nil
 	case schema.Maybe_Absent:
-		return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignNull()
+		return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr.Repr"}.AssignNull()
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
 	case midvalue:
@@ -3260,29 +3570,29 @@ func (na *_PersonInfo__Assembler) AssignNull() error {
 	}
 	panic("unreachable")
 }
-func (_PersonInfo__Assembler) AssignBool(bool) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignBool(false)
+func (_Header__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignBool(false)
 }
-func (_PersonInfo__Assembler) AssignInt(int64) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignInt(0)
+func (_Header__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignInt(0)
 }
-func (_PersonInfo__Assembler) AssignFloat(float64) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignFloat(0)
+func (_Header__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignFloat(0)
 }
-func (_PersonInfo__Assembler) AssignString(string) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignString("")
+func (_Header__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignString("")
 }
-func (_PersonInfo__Assembler) AssignBytes([]byte) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignBytes(nil)
+func (_Header__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignBytes(nil)
 }
-func (_PersonInfo__Assembler) AssignLink(ipld.Link) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignLink(nil)
+func (_Header__ReprAssembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Header.Repr"}.AssignLink(nil)
 }
-func (na *_PersonInfo__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Header__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
-	if v2, ok := v.(*_PersonInfo); ok {
+	if v2, ok := v.(*_Header); ok {
 		switch *na.m {
 		case schema.Maybe_Value, schema.Maybe_Null:
 			panic("invalid state: cannot assign into assembler that's already finished")
@@ -3298,8 +3608,8 @@ func (na *_PersonInfo__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.PersonInfo", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Header.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -3316,15 +3626,14 @@ func (na *_PersonInfo__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_PersonInfo__Assembler) Prototype() ipld.NodePrototype {
-	return _PersonInfo__Prototype{}
+func (_Header__ReprAssembler) Prototype() datamodel.NodePrototype {
+	return _Header__ReprPrototype{}
 }
-func (ma *_PersonInfo__Assembler) valueFinishTidy() bool {
+func (ma *_Header__ReprAssembler) valueFinishTidy() bool {
 	switch ma.f {
 	case 0:
 		switch ma.cm {
 		case schema.Maybe_Value:
-			ma.ca_date.w = nil
 			ma.cm = schema.Maybe_Absent
 			ma.state = maState_initial
 			return true
@@ -3334,27 +3643,6 @@ func (ma *_PersonInfo__Assembler) valueFinishTidy() bool {
 	case 1:
 		switch ma.cm {
 		case schema.Maybe_Value:
-			ma.ca_timezone.w = nil
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
-	case 2:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.ca_email.w = nil
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
-	case 3:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.ca_name.w = nil
 			ma.cm = schema.Maybe_Absent
 			ma.state = maState_initial
 			return true
@@ -3365,7 +3653,7 @@ func (ma *_PersonInfo__Assembler) valueFinishTidy() bool {
 		panic("unreachable")
 	}
 }
-func (ma *_PersonInfo__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Header__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -3381,50 +3669,31 @@ func (ma *_PersonInfo__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, e
 		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
 	}
 	switch k {
-	case "date":
-		if ma.s&fieldBit__PersonInfo_Date != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date}
+	case "key":
+		if ma.s&fieldBit__Header_Key != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Key_serial}
 		}
-		ma.s += fieldBit__PersonInfo_Date
+		ma.s += fieldBit__Header_Key
 		ma.state = maState_midValue
 		ma.f = 0
-		ma.ca_date.w = &ma.w.date
-		ma.ca_date.m = &ma.cm
-		return &ma.ca_date, nil
-	case "timezone":
-		if ma.s&fieldBit__PersonInfo_Timezone != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone}
-		}
-		ma.s += fieldBit__PersonInfo_Timezone
+		ma.ca_key.w = &ma.w.key
+		ma.ca_key.m = &ma.cm
+		return &ma.ca_key, nil
+	case "value":
+		if ma.s&fieldBit__Header_Value != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Value_serial}
+		}
+		ma.s += fieldBit__Header_Value
 		ma.state = maState_midValue
 		ma.f = 1
-		ma.ca_timezone.w = &ma.w.timezone
-		ma.ca_timezone.m = &ma.cm
-		return &ma.ca_timezone, nil
-	case "email":
-		if ma.s&fieldBit__PersonInfo_Email != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email}
-		}
-		ma.s += fieldBit__PersonInfo_Email
-		ma.state = maState_midValue
-		ma.f = 2
-		ma.ca_email.w = &ma.w.email
-		ma.ca_email.m = &ma.cm
-		return &ma.ca_email, nil
-	case "name":
-		if ma.s&fieldBit__PersonInfo_Name != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name}
-		}
-		ma.s += fieldBit__PersonInfo_Name
-		ma.state = maState_midValue
-		ma.f = 3
-		ma.ca_name.w = &ma.w.name
-		ma.ca_name.m = &ma.cm
-		return &ma.ca_name, nil
+		ma.ca_value.w = &ma.w.value
+		ma.ca_value.m = &ma.cm
+		return &ma.ca_value, nil
+	default:
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.PersonInfo", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Header.Repr", Key: &_String{k}}
 }
-func (ma *_PersonInfo__Assembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Header__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -3440,9 +3709,9 @@ func (ma *_PersonInfo__Assembler) AssembleKey() ipld.NodeAssembler {
 		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
 	}
 	ma.state = maState_midKey
-	return (*_PersonInfo__KeyAssembler)(ma)
+	return (*_Header__ReprKeyAssembler)(ma)
 }
-func (ma *_PersonInfo__Assembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Header__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -3458,26 +3727,18 @@ func (ma *_PersonInfo__Assembler) AssembleValue() ipld.NodeAssembler {
 	ma.state = maState_midValue
 	switch ma.f {
 	case 0:
-		ma.ca_date.w = &ma.w.date
-		ma.ca_date.m = &ma.cm
-		return &ma.ca_date
+		ma.ca_key.w = &ma.w.key
+		ma.ca_key.m = &ma.cm
+		return &ma.ca_key
 	case 1:
-		ma.ca_timezone.w = &ma.w.timezone
-		ma.ca_timezone.m = &ma.cm
-		return &ma.ca_timezone
-	case 2:
-		ma.ca_email.w = &ma.w.email
-		ma.ca_email.m = &ma.cm
-		return &ma.ca_email
-	case 3:
-		ma.ca_name.w = &ma.w.name
-		ma.ca_name.m = &ma.cm
-		return &ma.ca_name
+		ma.ca_value.w = &ma.w.value
+		ma.ca_value.m = &ma.cm
+		return &ma.ca_value
 	default:
 		panic("unreachable")
 	}
 }
-func (ma *_PersonInfo__Assembler) Finish() error {
+func (ma *_Header__ReprAssembler) Finish() error {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -3492,19 +3753,13 @@ func (ma *_PersonInfo__Assembler) Finish() error {
 	case maState_finished:
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
-	if ma.s&fieldBits__PersonInfo_sufficient != fieldBits__PersonInfo_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
-		if ma.s&fieldBit__PersonInfo_Date == 0 {
-			err.Missing = append(err.Missing, "date")
-		}
-		if ma.s&fieldBit__PersonInfo_Timezone == 0 {
-			err.Missing = append(err.Missing, "timezone")
-		}
-		if ma.s&fieldBit__PersonInfo_Email == 0 {
-			err.Missing = append(err.Missing, "email")
+	if ma.s&fieldBits__Header_sufficient != fieldBits__Header_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Header_Key == 0 {
+			err.Missing = append(err.Missing, "key")
 		}
-		if ma.s&fieldBit__PersonInfo_Name == 0 {
-			err.Missing = append(err.Missing, "name")
+		if ma.s&fieldBit__Header_Value == 0 {
+			err.Missing = append(err.Missing, "value")
 		}
 		return err
 	}
@@ -3512,254 +3767,278 @@ func (ma *_PersonInfo__Assembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_PersonInfo__Assembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Header__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_PersonInfo__Assembler) ValuePrototype(k string) ipld.NodePrototype {
-	panic("todo structbuilder mapassembler valueprototype")
+func (ma *_Header__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
 }
 
-type _PersonInfo__KeyAssembler _PersonInfo__Assembler
+type _Header__ReprKeyAssembler _Header__ReprAssembler
 
-func (_PersonInfo__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.BeginMap(0)
+func (_Header__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.BeginMap(0)
 }
-func (_PersonInfo__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.BeginList(0)
+func (_Header__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.BeginList(0)
 }
-func (na *_PersonInfo__KeyAssembler) AssignNull() error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignNull()
+func (na *_Header__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignNull()
 }
-func (_PersonInfo__KeyAssembler) AssignBool(bool) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignBool(false)
+func (_Header__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignBool(false)
 }
-func (_PersonInfo__KeyAssembler) AssignInt(int64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignInt(0)
+func (_Header__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignInt(0)
 }
-func (_PersonInfo__KeyAssembler) AssignFloat(float64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignFloat(0)
+func (_Header__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignFloat(0)
 }
-func (ka *_PersonInfo__KeyAssembler) AssignString(k string) error {
+func (ka *_Header__ReprKeyAssembler) AssignString(k string) error {
 	if ka.state != maState_midKey {
 		panic("misuse: KeyAssembler held beyond its valid lifetime")
 	}
 	switch k {
-	case "date":
-		if ka.s&fieldBit__PersonInfo_Date != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date}
+	case "key":
+		if ka.s&fieldBit__Header_Key != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Key_serial}
 		}
-		ka.s += fieldBit__PersonInfo_Date
+		ka.s += fieldBit__Header_Key
 		ka.state = maState_expectValue
 		ka.f = 0
 		return nil
-	case "timezone":
-		if ka.s&fieldBit__PersonInfo_Timezone != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone}
+	case "value":
+		if ka.s&fieldBit__Header_Value != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Header_Value_serial}
 		}
-		ka.s += fieldBit__PersonInfo_Timezone
+		ka.s += fieldBit__Header_Value
 		ka.state = maState_expectValue
 		ka.f = 1
 		return nil
-	case "email":
-		if ka.s&fieldBit__PersonInfo_Email != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email}
-		}
-		ka.s += fieldBit__PersonInfo_Email
-		ka.state = maState_expectValue
-		ka.f = 2
-		return nil
-	case "name":
-		if ka.s&fieldBit__PersonInfo_Name != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name}
-		}
-		ka.s += fieldBit__PersonInfo_Name
-		ka.state = maState_expectValue
-		ka.f = 3
-		return nil
-	default:
-		return ipld.ErrInvalidKey{TypeName: "ipldgit.PersonInfo", Key: &_String{k}}
 	}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.Header.Repr", Key: &_String{k}}
 }
-func (_PersonInfo__KeyAssembler) AssignBytes([]byte) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignBytes(nil)
+func (_Header__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignBytes(nil)
 }
-func (_PersonInfo__KeyAssembler) AssignLink(ipld.Link) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignLink(nil)
+func (_Header__ReprKeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Header.Repr.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_PersonInfo__KeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_Header__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_PersonInfo__KeyAssembler) Prototype() ipld.NodePrototype {
+func (_Header__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (PersonInfo) Type() schema.Type {
-	return nil /*TODO:typelit*/
+
+func (n *_Header_List) Lookup(idx int64) Header {
+	if n.Length() <= idx {
+		return nil
+	}
+	v := &n.x[idx]
+	return v
 }
-func (n PersonInfo) Representation() ipld.Node {
-	return (*_PersonInfo__Repr)(n)
+func (n *_Header_List) LookupMaybe(idx int64) MaybeHeader {
+	if n.Length() <= idx {
+		return nil
+	}
+	v := &n.x[idx]
+	return &_Header__Maybe{
+		m: schema.Maybe_Value,
+		v: v,
+	}
 }
 
-type _PersonInfo__Repr _PersonInfo
+var _Header_List__valueAbsent = _Header__Maybe{m: schema.Maybe_Absent}
 
-var (
-	fieldName__PersonInfo_Date_serial     = _String{"date"}
-	fieldName__PersonInfo_Timezone_serial = _String{"timezone"}
-	fieldName__PersonInfo_Email_serial    = _String{"email"}
-	fieldName__PersonInfo_Name_serial     = _String{"name"}
-)
-var _ ipld.Node = &_PersonInfo__Repr{}
+func (n Header_List) Iterator() *Header_List__Itr {
+	return &Header_List__Itr{n, 0}
+}
 
-func (_PersonInfo__Repr) Kind() ipld.Kind {
-	return ipld.Kind_Map
+type Header_List__Itr struct {
+	n   Header_List
+	idx int
 }
-func (n *_PersonInfo__Repr) LookupByString(key string) (ipld.Node, error) {
-	switch key {
-	case "date":
-		return n.date.Representation(), nil
-	case "timezone":
-		return n.timezone.Representation(), nil
-	case "email":
-		return n.email.Representation(), nil
-	case "name":
-		return n.name.Representation(), nil
+
+func (itr *Header_List__Itr) Next() (idx int64, v Header) {
+	if itr.idx >= len(itr.n.x) {
+		return -1, nil
+	}
+	idx = int64(itr.idx)
+	v = &itr.n.x[itr.idx]
+	itr.idx++
+	return
+}
+func (itr *Header_List__Itr) Done() bool {
+	return itr.idx >= len(itr.n.x)
+}
+
+type _Header_List__Maybe struct {
+	m schema.Maybe
+	v _Header_List
+}
+type MaybeHeader_List = *_Header_List__Maybe
+
+func (m MaybeHeader_List) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeHeader_List) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeHeader_List) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeHeader_List) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return &m.v
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		panic("unreachable")
 	}
 }
-func (n *_PersonInfo__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
-	ks, err := key.AsString()
+func (m MaybeHeader_List) Must() Header_List {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ datamodel.Node = (Header_List)(&_Header_List{})
+var _ schema.TypedNode = (Header_List)(&_Header_List{})
+
+func (Header_List) Kind() datamodel.Kind {
+	return datamodel.Kind_List
+}
+func (Header_List) LookupByString(string) (datamodel.Node, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.LookupByString("")
+}
+func (n Header_List) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
+	idx, err := k.AsInt()
 	if err != nil {
 		return nil, err
 	}
-	return n.LookupByString(ks)
+	return n.LookupByIndex(idx)
 }
-func (_PersonInfo__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.LookupByIndex(0)
+func (n Header_List) LookupByIndex(idx int64) (datamodel.Node, error) {
+	if n.Length() <= idx {
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfInt(idx)}
+	}
+	v := &n.x[idx]
+	return v, nil
 }
-func (n _PersonInfo__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
-	return n.LookupByString(seg.String())
+func (n Header_List) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	i, err := seg.Index()
+	if err != nil {
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Header_List", TroubleSegment: seg, Reason: err}
+	}
+	return n.LookupByIndex(i)
 }
-func (n *_PersonInfo__Repr) MapIterator() ipld.MapIterator {
-	return &_PersonInfo__ReprMapItr{n, 0}
+func (Header_List) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (n Header_List) ListIterator() datamodel.ListIterator {
+	return &_Header_List__ListItr{n, 0}
 }
 
-type _PersonInfo__ReprMapItr struct {
-	n   *_PersonInfo__Repr
+type _Header_List__ListItr struct {
+	n   Header_List
 	idx int
 }
 
-func (itr *_PersonInfo__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
-	if itr.idx >= 4 {
-		return nil, nil, ipld.ErrIteratorOverread{}
-	}
-	switch itr.idx {
-	case 0:
-		k = &fieldName__PersonInfo_Date_serial
-		v = itr.n.date.Representation()
-	case 1:
-		k = &fieldName__PersonInfo_Timezone_serial
-		v = itr.n.timezone.Representation()
-	case 2:
-		k = &fieldName__PersonInfo_Email_serial
-		v = itr.n.email.Representation()
-	case 3:
-		k = &fieldName__PersonInfo_Name_serial
-		v = itr.n.name.Representation()
-	default:
-		panic("unreachable")
+func (itr *_Header_List__ListItr) Next() (idx int64, v datamodel.Node, _ error) {
+	if itr.idx >= len(itr.n.x) {
+		return -1, nil, datamodel.ErrIteratorOverread{}
 	}
+	idx = int64(itr.idx)
+	x := &itr.n.x[itr.idx]
+	v = x
 	itr.idx++
 	return
 }
-func (itr *_PersonInfo__ReprMapItr) Done() bool {
-	return itr.idx >= 4
-}
-func (_PersonInfo__Repr) ListIterator() ipld.ListIterator {
-	return nil
+func (itr *_Header_List__ListItr) Done() bool {
+	return itr.idx >= len(itr.n.x)
 }
-func (rn *_PersonInfo__Repr) Length() int64 {
-	l := 4
-	return int64(l)
+
+func (n Header_List) Length() int64 {
+	return int64(len(n.x))
 }
-func (_PersonInfo__Repr) IsAbsent() bool {
+func (Header_List) IsAbsent() bool {
 	return false
 }
-func (_PersonInfo__Repr) IsNull() bool {
+func (Header_List) IsNull() bool {
 	return false
 }
-func (_PersonInfo__Repr) AsBool() (bool, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsBool()
+func (Header_List) AsBool() (bool, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsBool()
 }
-func (_PersonInfo__Repr) AsInt() (int64, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsInt()
+func (Header_List) AsInt() (int64, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsInt()
 }
-func (_PersonInfo__Repr) AsFloat() (float64, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsFloat()
+func (Header_List) AsFloat() (float64, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsFloat()
 }
-func (_PersonInfo__Repr) AsString() (string, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsString()
+func (Header_List) AsString() (string, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsString()
 }
-func (_PersonInfo__Repr) AsBytes() ([]byte, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsBytes()
+func (Header_List) AsBytes() ([]byte, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsBytes()
 }
-func (_PersonInfo__Repr) AsLink() (ipld.Link, error) {
-	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsLink()
+func (Header_List) AsLink() (datamodel.Link, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List"}.AsLink()
 }
-func (_PersonInfo__Repr) Prototype() ipld.NodePrototype {
-	return _PersonInfo__ReprPrototype{}
+func (Header_List) Prototype() datamodel.NodePrototype {
+	return _Header_List__Prototype{}
 }
 
-type _PersonInfo__ReprPrototype struct{}
+type _Header_List__Prototype struct{}
 
-func (_PersonInfo__ReprPrototype) NewBuilder() ipld.NodeBuilder {
-	var nb _PersonInfo__ReprBuilder
+func (_Header_List__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Header_List__Builder
 	nb.Reset()
 	return &nb
 }
 
-type _PersonInfo__ReprBuilder struct {
-	_PersonInfo__ReprAssembler
+type _Header_List__Builder struct {
+	_Header_List__Assembler
 }
 
-func (nb *_PersonInfo__ReprBuilder) Build() ipld.Node {
+func (nb *_Header_List__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
 	return nb.w
 }
-func (nb *_PersonInfo__ReprBuilder) Reset() {
-	var w _PersonInfo
+func (nb *_Header_List__Builder) Reset() {
+	var w _Header_List
 	var m schema.Maybe
-	*nb = _PersonInfo__ReprBuilder{_PersonInfo__ReprAssembler{w: &w, m: &m}}
+	*nb = _Header_List__Builder{_Header_List__Assembler{w: &w, m: &m}}
 }
 
-type _PersonInfo__ReprAssembler struct {
-	w     *_PersonInfo
+type _Header_List__Assembler struct {
+	w     *_Header_List
 	m     *schema.Maybe
-	state maState
-	s     int
-	f     int
+	state laState
 
-	cm          schema.Maybe
-	ca_date     _String__ReprAssembler
-	ca_timezone _String__ReprAssembler
-	ca_email    _String__ReprAssembler
-	ca_name     _String__ReprAssembler
+	cm schema.Maybe
+	va _Header__Assembler
 }
 
-func (na *_PersonInfo__ReprAssembler) reset() {
-	na.state = maState_initial
-	na.s = 0
-	na.ca_date.reset()
-	na.ca_timezone.reset()
-	na.ca_email.reset()
-	na.ca_name.reset()
+func (na *_Header_List__Assembler) reset() {
+	na.state = laState_initial
+	na.va.reset()
 }
-func (na *_PersonInfo__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (_Header_List__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.BeginMap(0)
+}
+func (na *_Header_List__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -3767,21 +4046,21 @@ func (na *_PersonInfo__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error)
 		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
 	}
 	*na.m = midvalue
-	if na.w == nil {
-		na.w = &_PersonInfo{}
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	if sizeHint > 0 {
+		na.w.x = make([]_Header, 0, sizeHint)
 	}
 	return na, nil
 }
-func (_PersonInfo__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.BeginList(0)
-}
-func (na *_PersonInfo__ReprAssembler) AssignNull() error {
+func (na *_Header_List__Assembler) AssignNull() error {
 	switch *na.m {
 	case allowNull:
 		*na.m = schema.Maybe_Null
 		return nil
 	case schema.Maybe_Absent:
-		return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr.Repr"}.AssignNull()
+		return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignNull()
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
 	case midvalue:
@@ -3789,337 +4068,3186 @@ func (na *_PersonInfo__ReprAssembler) AssignNull() error {
 	}
 	panic("unreachable")
 }
-func (_PersonInfo__ReprAssembler) AssignBool(bool) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignBool(false)
+func (_Header_List__Assembler) AssignBool(bool) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignBool(false)
 }
-func (_PersonInfo__ReprAssembler) AssignInt(int64) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignInt(0)
+func (_Header_List__Assembler) AssignInt(int64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignInt(0)
 }
-func (_PersonInfo__ReprAssembler) AssignFloat(float64) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignFloat(0)
+func (_Header_List__Assembler) AssignFloat(float64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignFloat(0)
 }
-func (_PersonInfo__ReprAssembler) AssignString(string) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignString("")
+func (_Header_List__Assembler) AssignString(string) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignString("")
 }
-func (_PersonInfo__ReprAssembler) AssignBytes([]byte) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignBytes(nil)
+func (_Header_List__Assembler) AssignBytes([]byte) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignBytes(nil)
 }
-func (_PersonInfo__ReprAssembler) AssignLink(ipld.Link) error {
-	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignLink(nil)
+func (_Header_List__Assembler) AssignLink(datamodel.Link) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List"}.AssignLink(nil)
 }
-func (na *_PersonInfo__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Header_List__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
-	if v2, ok := v.(*_PersonInfo); ok {
+	if v2, ok := v.(*_Header_List); ok {
 		switch *na.m {
 		case schema.Maybe_Value, schema.Maybe_Null:
 			panic("invalid state: cannot assign into assembler that's already finished")
 		case midvalue:
 			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
 		}
-		if na.w == nil {
-			na.w = v2
-			*na.m = schema.Maybe_Value
-			return nil
-		}
 		*na.w = *v2
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.PersonInfo.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Header_List", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
-	itr := v.MapIterator()
+	itr := v.ListIterator()
 	for !itr.Done() {
-		k, v, err := itr.Next()
+		_, v, err := itr.Next()
 		if err != nil {
 			return err
 		}
-		if err := na.AssembleKey().AssignNode(k); err != nil {
-			return err
-		}
 		if err := na.AssembleValue().AssignNode(v); err != nil {
 			return err
 		}
 	}
 	return na.Finish()
 }
-func (_PersonInfo__ReprAssembler) Prototype() ipld.NodePrototype {
-	return _PersonInfo__ReprPrototype{}
+func (_Header_List__Assembler) Prototype() datamodel.NodePrototype {
+	return _Header_List__Prototype{}
 }
-func (ma *_PersonInfo__ReprAssembler) valueFinishTidy() bool {
-	switch ma.f {
-	case 0:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
-	case 1:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
-	case 2:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
-	case 3:
-		switch ma.cm {
-		case schema.Maybe_Value:
-			ma.cm = schema.Maybe_Absent
-			ma.state = maState_initial
-			return true
-		default:
-			return false
-		}
+func (la *_Header_List__Assembler) valueFinishTidy() bool {
+	switch la.cm {
+	case schema.Maybe_Value:
+		la.va.w = nil
+		la.cm = schema.Maybe_Absent
+		la.state = laState_initial
+		la.va.reset()
+		return true
 	default:
-		panic("unreachable")
+		return false
 	}
 }
-func (ma *_PersonInfo__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
-	switch ma.state {
-	case maState_initial:
+func (la *_Header_List__Assembler) AssembleValue() datamodel.NodeAssembler {
+	switch la.state {
+	case laState_initial:
 		// carry on
-	case maState_midKey:
-		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
-	case maState_expectValue:
-		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
-	case maState_midValue:
-		if !ma.valueFinishTidy() {
-			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when still in the middle of assembling the previous value")
 		} // if tidy success: carry on
-	case maState_finished:
-		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
-	}
-	switch k {
-	case "date":
-		if ma.s&fieldBit__PersonInfo_Date != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date_serial}
-		}
-		ma.s += fieldBit__PersonInfo_Date
-		ma.state = maState_midValue
-		ma.f = 0
-		ma.ca_date.w = &ma.w.date
-		ma.ca_date.m = &ma.cm
-		return &ma.ca_date, nil
-	case "timezone":
-		if ma.s&fieldBit__PersonInfo_Timezone != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone_serial}
-		}
-		ma.s += fieldBit__PersonInfo_Timezone
-		ma.state = maState_midValue
-		ma.f = 1
-		ma.ca_timezone.w = &ma.w.timezone
-		ma.ca_timezone.m = &ma.cm
-		return &ma.ca_timezone, nil
-	case "email":
-		if ma.s&fieldBit__PersonInfo_Email != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email_serial}
-		}
-		ma.s += fieldBit__PersonInfo_Email
-		ma.state = maState_midValue
-		ma.f = 2
-		ma.ca_email.w = &ma.w.email
-		ma.ca_email.m = &ma.cm
-		return &ma.ca_email, nil
-	case "name":
-		if ma.s&fieldBit__PersonInfo_Name != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name_serial}
-		}
-		ma.s += fieldBit__PersonInfo_Name
-		ma.state = maState_midValue
-		ma.f = 3
-		ma.ca_name.w = &ma.w.name
-		ma.ca_name.m = &ma.cm
-		return &ma.ca_name, nil
-	default:
+	case laState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.PersonInfo.Repr", Key: &_String{k}}
+	la.w.x = append(la.w.x, _Header{})
+	la.state = laState_midValue
+	row := &la.w.x[len(la.w.x)-1]
+	la.va.w = row
+	la.va.m = &la.cm
+	return &la.va
 }
-func (ma *_PersonInfo__ReprAssembler) AssembleKey() ipld.NodeAssembler {
-	switch ma.state {
-	case maState_initial:
+func (la *_Header_List__Assembler) Finish() error {
+	switch la.state {
+	case laState_initial:
 		// carry on
-	case maState_midKey:
-		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
-	case maState_expectValue:
-		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
-	case maState_midValue:
-		if !ma.valueFinishTidy() {
-			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
 		} // if tidy success: carry on
-	case maState_finished:
-		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	case laState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
-	ma.state = maState_midKey
-	return (*_PersonInfo__ReprKeyAssembler)(ma)
+	la.state = laState_finished
+	*la.m = schema.Maybe_Value
+	return nil
 }
-func (ma *_PersonInfo__ReprAssembler) AssembleValue() ipld.NodeAssembler {
-	switch ma.state {
-	case maState_initial:
-		panic("invalid state: AssembleValue cannot be called when no key is primed")
-	case maState_midKey:
-		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
-	case maState_expectValue:
-		// carry on
-	case maState_midValue:
-		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
-	case maState_finished:
-		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
-	}
-	ma.state = maState_midValue
-	switch ma.f {
-	case 0:
-		ma.ca_date.w = &ma.w.date
-		ma.ca_date.m = &ma.cm
-		return &ma.ca_date
-	case 1:
-		ma.ca_timezone.w = &ma.w.timezone
-		ma.ca_timezone.m = &ma.cm
-		return &ma.ca_timezone
-	case 2:
-		ma.ca_email.w = &ma.w.email
-		ma.ca_email.m = &ma.cm
-		return &ma.ca_email
-	case 3:
-		ma.ca_name.w = &ma.w.name
-		ma.ca_name.m = &ma.cm
-		return &ma.ca_name
-	default:
-		panic("unreachable")
+func (la *_Header_List__Assembler) ValuePrototype(_ int64) datamodel.NodePrototype {
+	return _Header__Prototype{}
+}
+func (Header_List) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Header_List) Representation() datamodel.Node {
+	return (*_Header_List__Repr)(n)
+}
+
+type _Header_List__Repr _Header_List
+
+var _ datamodel.Node = &_Header_List__Repr{}
+
+func (_Header_List__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_List
+}
+func (_Header_List__Repr) LookupByString(string) (datamodel.Node, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.LookupByString("")
+}
+func (nr *_Header_List__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
+	v, err := (Header_List)(nr).LookupByNode(k)
+	if err != nil || v == datamodel.Null {
+		return v, err
 	}
+	return v.(Header).Representation(), nil
 }
-func (ma *_PersonInfo__ReprAssembler) Finish() error {
-	switch ma.state {
-	case maState_initial:
-		// carry on
-	case maState_midKey:
-		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
-	case maState_expectValue:
-		panic("invalid state: Finish cannot be called when expecting start of value assembly")
-	case maState_midValue:
-		if !ma.valueFinishTidy() {
-			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
-		} // if tidy success: carry on
-	case maState_finished:
-		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+func (nr *_Header_List__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
+	v, err := (Header_List)(nr).LookupByIndex(idx)
+	if err != nil || v == datamodel.Null {
+		return v, err
 	}
-	if ma.s&fieldBits__PersonInfo_sufficient != fieldBits__PersonInfo_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
-		if ma.s&fieldBit__PersonInfo_Date == 0 {
-			err.Missing = append(err.Missing, "date")
-		}
-		if ma.s&fieldBit__PersonInfo_Timezone == 0 {
-			err.Missing = append(err.Missing, "timezone")
-		}
-		if ma.s&fieldBit__PersonInfo_Email == 0 {
-			err.Missing = append(err.Missing, "email")
-		}
-		if ma.s&fieldBit__PersonInfo_Name == 0 {
-			err.Missing = append(err.Missing, "name")
-		}
-		return err
+	return v.(Header).Representation(), nil
+}
+func (n _Header_List__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	i, err := seg.Index()
+	if err != nil {
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Header_List.Repr", TroubleSegment: seg, Reason: err}
 	}
-	ma.state = maState_finished
-	*ma.m = schema.Maybe_Value
+	return n.LookupByIndex(i)
+}
+func (_Header_List__Repr) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (ma *_PersonInfo__ReprAssembler) KeyPrototype() ipld.NodePrototype {
-	return _String__Prototype{}
+func (nr *_Header_List__Repr) ListIterator() datamodel.ListIterator {
+	return &_Header_List__ReprListItr{(Header_List)(nr), 0}
+}
+
+type _Header_List__ReprListItr _Header_List__ListItr
+
+func (itr *_Header_List__ReprListItr) Next() (idx int64, v datamodel.Node, err error) {
+	idx, v, err = (*_Header_List__ListItr)(itr).Next()
+	if err != nil || v == datamodel.Null {
+		return
+	}
+	return idx, v.(Header).Representation(), nil
+}
+func (itr *_Header_List__ReprListItr) Done() bool {
+	return (*_Header_List__ListItr)(itr).Done()
+}
+
+func (rn *_Header_List__Repr) Length() int64 {
+	return int64(len(rn.x))
+}
+func (_Header_List__Repr) IsAbsent() bool {
+	return false
+}
+func (_Header_List__Repr) IsNull() bool {
+	return false
+}
+func (_Header_List__Repr) AsBool() (bool, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsBool()
+}
+func (_Header_List__Repr) AsInt() (int64, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsInt()
+}
+func (_Header_List__Repr) AsFloat() (float64, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsFloat()
+}
+func (_Header_List__Repr) AsString() (string, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsString()
+}
+func (_Header_List__Repr) AsBytes() ([]byte, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsBytes()
+}
+func (_Header_List__Repr) AsLink() (datamodel.Link, error) {
+	return mixins.List{TypeName: "ipldgit.Header_List.Repr"}.AsLink()
+}
+func (_Header_List__Repr) Prototype() datamodel.NodePrototype {
+	return _Header_List__ReprPrototype{}
+}
+
+type _Header_List__ReprPrototype struct{}
+
+func (_Header_List__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Header_List__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Header_List__ReprBuilder struct {
+	_Header_List__ReprAssembler
+}
+
+func (nb *_Header_List__ReprBuilder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Header_List__ReprBuilder) Reset() {
+	var w _Header_List
+	var m schema.Maybe
+	*nb = _Header_List__ReprBuilder{_Header_List__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Header_List__ReprAssembler struct {
+	w     *_Header_List
+	m     *schema.Maybe
+	state laState
+
+	cm schema.Maybe
+	va _Header__ReprAssembler
+}
+
+func (na *_Header_List__ReprAssembler) reset() {
+	na.state = laState_initial
+	na.va.reset()
+}
+func (_Header_List__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.BeginMap(0)
+}
+func (na *_Header_List__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	if sizeHint > 0 {
+		na.w.x = make([]_Header, 0, sizeHint)
+	}
+	return na, nil
+}
+func (na *_Header_List__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Header_List__ReprAssembler) AssignBool(bool) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignBool(false)
+}
+func (_Header_List__ReprAssembler) AssignInt(int64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignInt(0)
+}
+func (_Header_List__ReprAssembler) AssignFloat(float64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignFloat(0)
+}
+func (_Header_List__ReprAssembler) AssignString(string) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignString("")
+}
+func (_Header_List__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignBytes(nil)
+}
+func (_Header_List__ReprAssembler) AssignLink(datamodel.Link) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Header_List.Repr"}.AssignLink(nil)
+}
+func (na *_Header_List__ReprAssembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Header_List); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Header_List.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
+	}
+	itr := v.ListIterator()
+	for !itr.Done() {
+		_, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Header_List__ReprAssembler) Prototype() datamodel.NodePrototype {
+	return _Header_List__ReprPrototype{}
+}
+func (la *_Header_List__ReprAssembler) valueFinishTidy() bool {
+	switch la.cm {
+	case schema.Maybe_Value:
+		la.va.w = nil
+		la.cm = schema.Maybe_Absent
+		la.state = laState_initial
+		la.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (la *_Header_List__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when still in the middle of assembling the previous value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	la.w.x = append(la.w.x, _Header{})
+	la.state = laState_midValue
+	row := &la.w.x[len(la.w.x)-1]
+	la.va.w = row
+	la.va.m = &la.cm
+	return &la.va
+}
+func (la *_Header_List__ReprAssembler) Finish() error {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	la.state = laState_finished
+	*la.m = schema.Maybe_Value
+	return nil
+}
+func (la *_Header_List__ReprAssembler) ValuePrototype(_ int64) datamodel.NodePrototype {
+	return _Header__ReprPrototype{}
+}
+
+func (n Link) Link() datamodel.Link {
+	return n.x
+}
+func (_Link__Prototype) FromLink(v datamodel.Link) (Link, error) {
+	n := _Link{v}
+	return &n, nil
+}
+
+type _Link__Maybe struct {
+	m schema.Maybe
+	v _Link
+}
+type MaybeLink = *_Link__Maybe
+
+func (m MaybeLink) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeLink) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeLink) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeLink) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeLink) Must() Link {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ datamodel.Node = (Link)(&_Link{})
+var _ schema.TypedNode = (Link)(&_Link{})
+
+func (Link) Kind() datamodel.Kind {
+	return datamodel.Kind_Link
+}
+func (Link) LookupByString(string) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByString("")
+}
+func (Link) LookupByNode(datamodel.Node) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByNode(nil)
+}
+func (Link) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.LookupByIndex(0)
+}
+func (Link) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.LookupBySegment(seg)
+}
+func (Link) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (Link) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (Link) Length() int64 {
+	return -1
+}
+func (Link) IsAbsent() bool {
+	return false
+}
+func (Link) IsNull() bool {
+	return false
+}
+func (Link) AsBool() (bool, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.AsBool()
+}
+func (Link) AsInt() (int64, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.AsInt()
+}
+func (Link) AsFloat() (float64, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.AsFloat()
+}
+func (Link) AsString() (string, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.AsString()
+}
+func (Link) AsBytes() ([]byte, error) {
+	return mixins.Link{TypeName: "ipldgit.Link"}.AsBytes()
+}
+func (n Link) AsLink() (datamodel.Link, error) {
+	return n.x, nil
+}
+func (Link) Prototype() datamodel.NodePrototype {
+	return _Link__Prototype{}
+}
+
+type _Link__Prototype struct{}
+
+func (_Link__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Link__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Link__Builder struct {
+	_Link__Assembler
+}
+
+func (nb *_Link__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Link__Builder) Reset() {
+	var w _Link
+	var m schema.Maybe
+	*nb = _Link__Builder{_Link__Assembler{w: &w, m: &m}}
+}
+
+type _Link__Assembler struct {
+	w *_Link
+	m *schema.Maybe
+}
+
+func (na *_Link__Assembler) reset() {}
+func (_Link__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.BeginMap(0)
+}
+func (_Link__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.BeginList(0)
+}
+func (na *_Link__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_Link__Assembler) AssignBool(bool) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignBool(false)
+}
+func (_Link__Assembler) AssignInt(int64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignInt(0)
+}
+func (_Link__Assembler) AssignFloat(float64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignFloat(0)
+}
+func (_Link__Assembler) AssignString(string) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignString("")
+}
+func (_Link__Assembler) AssignBytes([]byte) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Link"}.AssignBytes(nil)
+}
+func (na *_Link__Assembler) AssignLink(v datamodel.Link) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (na *_Link__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Link); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsLink(); err != nil {
+		return err
+	} else {
+		return na.AssignLink(v2)
+	}
+}
+func (_Link__Assembler) Prototype() datamodel.NodePrototype {
+	return _Link__Prototype{}
+}
+func (Link) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Link) Representation() datamodel.Node {
+	return (*_Link__Repr)(n)
+}
+
+type _Link__Repr = _Link
+
+var _ datamodel.Node = &_Link__Repr{}
+
+type _Link__ReprPrototype = _Link__Prototype
+type _Link__ReprAssembler = _Link__Assembler
+
+func (n _PersonInfo) FieldDate() String {
+	return &n.date
+}
+func (n _PersonInfo) FieldTimezone() String {
+	return &n.timezone
+}
+func (n _PersonInfo) FieldEmail() String {
+	return &n.email
+}
+func (n _PersonInfo) FieldName() String {
+	return &n.name
+}
+
+type _PersonInfo__Maybe struct {
+	m schema.Maybe
+	v PersonInfo
+}
+type MaybePersonInfo = *_PersonInfo__Maybe
+
+func (m MaybePersonInfo) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybePersonInfo) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybePersonInfo) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybePersonInfo) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybePersonInfo) Must() PersonInfo {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__PersonInfo_Date     = _String{"date"}
+	fieldName__PersonInfo_Timezone = _String{"timezone"}
+	fieldName__PersonInfo_Email    = _String{"email"}
+	fieldName__PersonInfo_Name     = _String{"name"}
+)
+var _ datamodel.Node = (PersonInfo)(&_PersonInfo{})
+var _ schema.TypedNode = (PersonInfo)(&_PersonInfo{})
+
+func (PersonInfo) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+func (n PersonInfo) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "date":
+		return &n.date, nil
+	case "timezone":
+		return &n.timezone, nil
+	case "email":
+		return &n.email, nil
+	case "name":
+		return &n.name, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
+	}
+}
+func (n PersonInfo) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (PersonInfo) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.LookupByIndex(0)
+}
+func (n PersonInfo) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n PersonInfo) MapIterator() datamodel.MapIterator {
+	return &_PersonInfo__MapItr{n, 0}
+}
+
+type _PersonInfo__MapItr struct {
+	n   PersonInfo
+	idx int
+}
+
+func (itr *_PersonInfo__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 4 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__PersonInfo_Date
+		v = &itr.n.date
+	case 1:
+		k = &fieldName__PersonInfo_Timezone
+		v = &itr.n.timezone
+	case 2:
+		k = &fieldName__PersonInfo_Email
+		v = &itr.n.email
+	case 3:
+		k = &fieldName__PersonInfo_Name
+		v = &itr.n.name
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_PersonInfo__MapItr) Done() bool {
+	return itr.idx >= 4
+}
+
+func (PersonInfo) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (PersonInfo) Length() int64 {
+	return 4
+}
+func (PersonInfo) IsAbsent() bool {
+	return false
+}
+func (PersonInfo) IsNull() bool {
+	return false
+}
+func (PersonInfo) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsBool()
+}
+func (PersonInfo) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsInt()
+}
+func (PersonInfo) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsFloat()
+}
+func (PersonInfo) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsString()
+}
+func (PersonInfo) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsBytes()
+}
+func (PersonInfo) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo"}.AsLink()
+}
+func (PersonInfo) Prototype() datamodel.NodePrototype {
+	return _PersonInfo__Prototype{}
+}
+
+type _PersonInfo__Prototype struct{}
+
+func (_PersonInfo__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _PersonInfo__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _PersonInfo__Builder struct {
+	_PersonInfo__Assembler
+}
+
+func (nb *_PersonInfo__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_PersonInfo__Builder) Reset() {
+	var w _PersonInfo
+	var m schema.Maybe
+	*nb = _PersonInfo__Builder{_PersonInfo__Assembler{w: &w, m: &m}}
+}
+
+type _PersonInfo__Assembler struct {
+	w     *_PersonInfo
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_date     _String__Assembler
+	ca_timezone _String__Assembler
+	ca_email    _String__Assembler
+	ca_name     _String__Assembler
+}
+
+func (na *_PersonInfo__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_date.reset()
+	na.ca_timezone.reset()
+	na.ca_email.reset()
+	na.ca_name.reset()
+}
+
+var (
+	fieldBit__PersonInfo_Date        = 1 << 0
+	fieldBit__PersonInfo_Timezone    = 1 << 1
+	fieldBit__PersonInfo_Email       = 1 << 2
+	fieldBit__PersonInfo_Name        = 1 << 3
+	fieldBits__PersonInfo_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<3
+)
+
+func (na *_PersonInfo__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_PersonInfo{}
+	}
+	return na, nil
+}
+func (_PersonInfo__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.BeginList(0)
+}
+func (na *_PersonInfo__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_PersonInfo__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignBool(false)
+}
+func (_PersonInfo__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignInt(0)
+}
+func (_PersonInfo__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignFloat(0)
+}
+func (_PersonInfo__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignString("")
+}
+func (_PersonInfo__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignBytes(nil)
+}
+func (_PersonInfo__Assembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo"}.AssignLink(nil)
+}
+func (na *_PersonInfo__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_PersonInfo); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.PersonInfo", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_PersonInfo__Assembler) Prototype() datamodel.NodePrototype {
+	return _PersonInfo__Prototype{}
+}
+func (ma *_PersonInfo__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_date.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_timezone.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_email.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 3:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_name.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_PersonInfo__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "date":
+		if ma.s&fieldBit__PersonInfo_Date != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date}
+		}
+		ma.s += fieldBit__PersonInfo_Date
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_date.w = &ma.w.date
+		ma.ca_date.m = &ma.cm
+		return &ma.ca_date, nil
+	case "timezone":
+		if ma.s&fieldBit__PersonInfo_Timezone != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone}
+		}
+		ma.s += fieldBit__PersonInfo_Timezone
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_timezone.w = &ma.w.timezone
+		ma.ca_timezone.m = &ma.cm
+		return &ma.ca_timezone, nil
+	case "email":
+		if ma.s&fieldBit__PersonInfo_Email != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email}
+		}
+		ma.s += fieldBit__PersonInfo_Email
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_email.w = &ma.w.email
+		ma.ca_email.m = &ma.cm
+		return &ma.ca_email, nil
+	case "name":
+		if ma.s&fieldBit__PersonInfo_Name != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name}
+		}
+		ma.s += fieldBit__PersonInfo_Name
+		ma.state = maState_midValue
+		ma.f = 3
+		ma.ca_name.w = &ma.w.name
+		ma.ca_name.m = &ma.cm
+		return &ma.ca_name, nil
+	}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.PersonInfo", Key: &_String{k}}
+}
+func (ma *_PersonInfo__Assembler) AssembleKey() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_PersonInfo__KeyAssembler)(ma)
+}
+func (ma *_PersonInfo__Assembler) AssembleValue() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_date.w = &ma.w.date
+		ma.ca_date.m = &ma.cm
+		return &ma.ca_date
+	case 1:
+		ma.ca_timezone.w = &ma.w.timezone
+		ma.ca_timezone.m = &ma.cm
+		return &ma.ca_timezone
+	case 2:
+		ma.ca_email.w = &ma.w.email
+		ma.ca_email.m = &ma.cm
+		return &ma.ca_email
+	case 3:
+		ma.ca_name.w = &ma.w.name
+		ma.ca_name.m = &ma.cm
+		return &ma.ca_name
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_PersonInfo__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__PersonInfo_sufficient != fieldBits__PersonInfo_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__PersonInfo_Date == 0 {
+			err.Missing = append(err.Missing, "date")
+		}
+		if ma.s&fieldBit__PersonInfo_Timezone == 0 {
+			err.Missing = append(err.Missing, "timezone")
+		}
+		if ma.s&fieldBit__PersonInfo_Email == 0 {
+			err.Missing = append(err.Missing, "email")
+		}
+		if ma.s&fieldBit__PersonInfo_Name == 0 {
+			err.Missing = append(err.Missing, "name")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_PersonInfo__Assembler) KeyPrototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_PersonInfo__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _PersonInfo__KeyAssembler _PersonInfo__Assembler
+
+func (_PersonInfo__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.BeginMap(0)
+}
+func (_PersonInfo__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.BeginList(0)
+}
+func (na *_PersonInfo__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignNull()
+}
+func (_PersonInfo__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignBool(false)
+}
+func (_PersonInfo__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignInt(0)
+}
+func (_PersonInfo__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_PersonInfo__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "date":
+		if ka.s&fieldBit__PersonInfo_Date != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date}
+		}
+		ka.s += fieldBit__PersonInfo_Date
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "timezone":
+		if ka.s&fieldBit__PersonInfo_Timezone != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone}
+		}
+		ka.s += fieldBit__PersonInfo_Timezone
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	case "email":
+		if ka.s&fieldBit__PersonInfo_Email != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email}
+		}
+		ka.s += fieldBit__PersonInfo_Email
+		ka.state = maState_expectValue
+		ka.f = 2
+		return nil
+	case "name":
+		if ka.s&fieldBit__PersonInfo_Name != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name}
+		}
+		ka.s += fieldBit__PersonInfo_Name
+		ka.state = maState_expectValue
+		ka.f = 3
+		return nil
+	default:
+		return schema.ErrInvalidKey{TypeName: "ipldgit.PersonInfo", Key: &_String{k}}
+	}
+}
+func (_PersonInfo__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignBytes(nil)
+}
+func (_PersonInfo__KeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_PersonInfo__KeyAssembler) AssignNode(v datamodel.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_PersonInfo__KeyAssembler) Prototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (PersonInfo) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n PersonInfo) Representation() datamodel.Node {
+	return (*_PersonInfo__Repr)(n)
+}
+
+type _PersonInfo__Repr _PersonInfo
+
+var (
+	fieldName__PersonInfo_Date_serial     = _String{"date"}
+	fieldName__PersonInfo_Timezone_serial = _String{"timezone"}
+	fieldName__PersonInfo_Email_serial    = _String{"email"}
+	fieldName__PersonInfo_Name_serial     = _String{"name"}
+)
+var _ datamodel.Node = &_PersonInfo__Repr{}
+
+func (_PersonInfo__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+func (n *_PersonInfo__Repr) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "date":
+		return n.date.Representation(), nil
+	case "timezone":
+		return n.timezone.Representation(), nil
+	case "email":
+		return n.email.Representation(), nil
+	case "name":
+		return n.name.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
+	}
+}
+func (n *_PersonInfo__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_PersonInfo__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.LookupByIndex(0)
+}
+func (n _PersonInfo__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_PersonInfo__Repr) MapIterator() datamodel.MapIterator {
+	return &_PersonInfo__ReprMapItr{n, 0}
+}
+
+type _PersonInfo__ReprMapItr struct {
+	n   *_PersonInfo__Repr
+	idx int
+}
+
+func (itr *_PersonInfo__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 4 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__PersonInfo_Date_serial
+		v = itr.n.date.Representation()
+	case 1:
+		k = &fieldName__PersonInfo_Timezone_serial
+		v = itr.n.timezone.Representation()
+	case 2:
+		k = &fieldName__PersonInfo_Email_serial
+		v = itr.n.email.Representation()
+	case 3:
+		k = &fieldName__PersonInfo_Name_serial
+		v = itr.n.name.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_PersonInfo__ReprMapItr) Done() bool {
+	return itr.idx >= 4
+}
+func (_PersonInfo__Repr) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (rn *_PersonInfo__Repr) Length() int64 {
+	l := 4
+	return int64(l)
+}
+func (_PersonInfo__Repr) IsAbsent() bool {
+	return false
+}
+func (_PersonInfo__Repr) IsNull() bool {
+	return false
+}
+func (_PersonInfo__Repr) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsBool()
+}
+func (_PersonInfo__Repr) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsInt()
+}
+func (_PersonInfo__Repr) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsFloat()
+}
+func (_PersonInfo__Repr) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsString()
+}
+func (_PersonInfo__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsBytes()
+}
+func (_PersonInfo__Repr) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.PersonInfo.Repr"}.AsLink()
+}
+func (_PersonInfo__Repr) Prototype() datamodel.NodePrototype {
+	return _PersonInfo__ReprPrototype{}
+}
+
+type _PersonInfo__ReprPrototype struct{}
+
+func (_PersonInfo__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _PersonInfo__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _PersonInfo__ReprBuilder struct {
+	_PersonInfo__ReprAssembler
+}
+
+func (nb *_PersonInfo__ReprBuilder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_PersonInfo__ReprBuilder) Reset() {
+	var w _PersonInfo
+	var m schema.Maybe
+	*nb = _PersonInfo__ReprBuilder{_PersonInfo__ReprAssembler{w: &w, m: &m}}
+}
+
+type _PersonInfo__ReprAssembler struct {
+	w     *_PersonInfo
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm          schema.Maybe
+	ca_date     _String__ReprAssembler
+	ca_timezone _String__ReprAssembler
+	ca_email    _String__ReprAssembler
+	ca_name     _String__ReprAssembler
+}
+
+func (na *_PersonInfo__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_date.reset()
+	na.ca_timezone.reset()
+	na.ca_email.reset()
+	na.ca_name.reset()
+}
+func (na *_PersonInfo__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_PersonInfo{}
+	}
+	return na, nil
+}
+func (_PersonInfo__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.BeginList(0)
+}
+func (na *_PersonInfo__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_PersonInfo__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignBool(false)
+}
+func (_PersonInfo__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignInt(0)
+}
+func (_PersonInfo__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignFloat(0)
+}
+func (_PersonInfo__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignString("")
+}
+func (_PersonInfo__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignBytes(nil)
+}
+func (_PersonInfo__ReprAssembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.PersonInfo.Repr"}.AssignLink(nil)
+}
+func (na *_PersonInfo__ReprAssembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_PersonInfo); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.PersonInfo.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_PersonInfo__ReprAssembler) Prototype() datamodel.NodePrototype {
+	return _PersonInfo__ReprPrototype{}
+}
+func (ma *_PersonInfo__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 2:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 3:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_PersonInfo__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "date":
+		if ma.s&fieldBit__PersonInfo_Date != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date_serial}
+		}
+		ma.s += fieldBit__PersonInfo_Date
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_date.w = &ma.w.date
+		ma.ca_date.m = &ma.cm
+		return &ma.ca_date, nil
+	case "timezone":
+		if ma.s&fieldBit__PersonInfo_Timezone != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone_serial}
+		}
+		ma.s += fieldBit__PersonInfo_Timezone
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_timezone.w = &ma.w.timezone
+		ma.ca_timezone.m = &ma.cm
+		return &ma.ca_timezone, nil
+	case "email":
+		if ma.s&fieldBit__PersonInfo_Email != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email_serial}
+		}
+		ma.s += fieldBit__PersonInfo_Email
+		ma.state = maState_midValue
+		ma.f = 2
+		ma.ca_email.w = &ma.w.email
+		ma.ca_email.m = &ma.cm
+		return &ma.ca_email, nil
+	case "name":
+		if ma.s&fieldBit__PersonInfo_Name != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name_serial}
+		}
+		ma.s += fieldBit__PersonInfo_Name
+		ma.state = maState_midValue
+		ma.f = 3
+		ma.ca_name.w = &ma.w.name
+		ma.ca_name.m = &ma.cm
+		return &ma.ca_name, nil
+	default:
+	}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.PersonInfo.Repr", Key: &_String{k}}
+}
+func (ma *_PersonInfo__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_PersonInfo__ReprKeyAssembler)(ma)
+}
+func (ma *_PersonInfo__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_date.w = &ma.w.date
+		ma.ca_date.m = &ma.cm
+		return &ma.ca_date
+	case 1:
+		ma.ca_timezone.w = &ma.w.timezone
+		ma.ca_timezone.m = &ma.cm
+		return &ma.ca_timezone
+	case 2:
+		ma.ca_email.w = &ma.w.email
+		ma.ca_email.m = &ma.cm
+		return &ma.ca_email
+	case 3:
+		ma.ca_name.w = &ma.w.name
+		ma.ca_name.m = &ma.cm
+		return &ma.ca_name
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_PersonInfo__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__PersonInfo_sufficient != fieldBits__PersonInfo_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__PersonInfo_Date == 0 {
+			err.Missing = append(err.Missing, "date")
+		}
+		if ma.s&fieldBit__PersonInfo_Timezone == 0 {
+			err.Missing = append(err.Missing, "timezone")
+		}
+		if ma.s&fieldBit__PersonInfo_Email == 0 {
+			err.Missing = append(err.Missing, "email")
+		}
+		if ma.s&fieldBit__PersonInfo_Name == 0 {
+			err.Missing = append(err.Missing, "name")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_PersonInfo__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_PersonInfo__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _PersonInfo__ReprKeyAssembler _PersonInfo__ReprAssembler
+
+func (_PersonInfo__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_PersonInfo__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_PersonInfo__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignNull()
+}
+func (_PersonInfo__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_PersonInfo__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_PersonInfo__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_PersonInfo__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "date":
+		if ka.s&fieldBit__PersonInfo_Date != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date_serial}
+		}
+		ka.s += fieldBit__PersonInfo_Date
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "timezone":
+		if ka.s&fieldBit__PersonInfo_Timezone != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone_serial}
+		}
+		ka.s += fieldBit__PersonInfo_Timezone
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	case "email":
+		if ka.s&fieldBit__PersonInfo_Email != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email_serial}
+		}
+		ka.s += fieldBit__PersonInfo_Email
+		ka.state = maState_expectValue
+		ka.f = 2
+		return nil
+	case "name":
+		if ka.s&fieldBit__PersonInfo_Name != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name_serial}
+		}
+		ka.s += fieldBit__PersonInfo_Name
+		ka.state = maState_expectValue
+		ka.f = 3
+		return nil
+	}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.PersonInfo.Repr", Key: &_String{k}}
+}
+func (_PersonInfo__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_PersonInfo__ReprKeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_PersonInfo__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_PersonInfo__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+
+func (n _Signature) FieldHeader() String {
+	return &n.header
+}
+func (n _Signature) FieldText() String {
+	return &n.text
+}
+
+type _Signature__Maybe struct {
+	m schema.Maybe
+	v Signature
+}
+type MaybeSignature = *_Signature__Maybe
+
+func (m MaybeSignature) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeSignature) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeSignature) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeSignature) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeSignature) Must() Signature {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return m.v
+}
+
+var (
+	fieldName__Signature_Header = _String{"header"}
+	fieldName__Signature_Text   = _String{"text"}
+)
+var _ datamodel.Node = (Signature)(&_Signature{})
+var _ schema.TypedNode = (Signature)(&_Signature{})
+
+func (Signature) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+func (n Signature) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "header":
+		return &n.header, nil
+	case "text":
+		return &n.text, nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
+	}
+}
+func (n Signature) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (Signature) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.LookupByIndex(0)
+}
+func (n Signature) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n Signature) MapIterator() datamodel.MapIterator {
+	return &_Signature__MapItr{n, 0}
+}
+
+type _Signature__MapItr struct {
+	n   Signature
+	idx int
+}
+
+func (itr *_Signature__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__Signature_Header
+		v = &itr.n.header
+	case 1:
+		k = &fieldName__Signature_Text
+		v = &itr.n.text
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_Signature__MapItr) Done() bool {
+	return itr.idx >= 2
+}
+
+func (Signature) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (Signature) Length() int64 {
+	return 2
+}
+func (Signature) IsAbsent() bool {
+	return false
+}
+func (Signature) IsNull() bool {
+	return false
+}
+func (Signature) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsBool()
+}
+func (Signature) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsInt()
+}
+func (Signature) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsFloat()
+}
+func (Signature) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsString()
+}
+func (Signature) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsBytes()
+}
+func (Signature) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature"}.AsLink()
+}
+func (Signature) Prototype() datamodel.NodePrototype {
+	return _Signature__Prototype{}
+}
+
+type _Signature__Prototype struct{}
+
+func (_Signature__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Signature__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Signature__Builder struct {
+	_Signature__Assembler
+}
+
+func (nb *_Signature__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Signature__Builder) Reset() {
+	var w _Signature
+	var m schema.Maybe
+	*nb = _Signature__Builder{_Signature__Assembler{w: &w, m: &m}}
+}
+
+type _Signature__Assembler struct {
+	w     *_Signature
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm        schema.Maybe
+	ca_header _String__Assembler
+	ca_text   _String__Assembler
+}
+
+func (na *_Signature__Assembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_header.reset()
+	na.ca_text.reset()
+}
+
+var (
+	fieldBit__Signature_Header      = 1 << 0
+	fieldBit__Signature_Text        = 1 << 1
+	fieldBits__Signature_sufficient = 0 + 1<<0 + 1<<1
+)
+
+func (na *_Signature__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Signature{}
+	}
+	return na, nil
+}
+func (_Signature__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.BeginList(0)
+}
+func (na *_Signature__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Signature__Assembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignBool(false)
+}
+func (_Signature__Assembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignInt(0)
+}
+func (_Signature__Assembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignFloat(0)
+}
+func (_Signature__Assembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignString("")
+}
+func (_Signature__Assembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignBytes(nil)
+}
+func (_Signature__Assembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature"}.AssignLink(nil)
+}
+func (na *_Signature__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Signature); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Signature", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Signature__Assembler) Prototype() datamodel.NodePrototype {
+	return _Signature__Prototype{}
+}
+func (ma *_Signature__Assembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_header.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.ca_text.w = nil
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Signature__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "header":
+		if ma.s&fieldBit__Signature_Header != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Header}
+		}
+		ma.s += fieldBit__Signature_Header
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_header.w = &ma.w.header
+		ma.ca_header.m = &ma.cm
+		return &ma.ca_header, nil
+	case "text":
+		if ma.s&fieldBit__Signature_Text != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Text}
+		}
+		ma.s += fieldBit__Signature_Text
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_text.w = &ma.w.text
+		ma.ca_text.m = &ma.cm
+		return &ma.ca_text, nil
+	}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Signature", Key: &_String{k}}
+}
+func (ma *_Signature__Assembler) AssembleKey() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Signature__KeyAssembler)(ma)
+}
+func (ma *_Signature__Assembler) AssembleValue() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_header.w = &ma.w.header
+		ma.ca_header.m = &ma.cm
+		return &ma.ca_header
+	case 1:
+		ma.ca_text.w = &ma.w.text
+		ma.ca_text.m = &ma.cm
+		return &ma.ca_text
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Signature__Assembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Signature_sufficient != fieldBits__Signature_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Signature_Header == 0 {
+			err.Missing = append(err.Missing, "header")
+		}
+		if ma.s&fieldBit__Signature_Text == 0 {
+			err.Missing = append(err.Missing, "text")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Signature__Assembler) KeyPrototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Signature__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler valueprototype")
+}
+
+type _Signature__KeyAssembler _Signature__Assembler
+
+func (_Signature__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.BeginMap(0)
+}
+func (_Signature__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.BeginList(0)
+}
+func (na *_Signature__KeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignNull()
+}
+func (_Signature__KeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignBool(false)
+}
+func (_Signature__KeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignInt(0)
+}
+func (_Signature__KeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Signature__KeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "header":
+		if ka.s&fieldBit__Signature_Header != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Header}
+		}
+		ka.s += fieldBit__Signature_Header
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "text":
+		if ka.s&fieldBit__Signature_Text != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Text}
+		}
+		ka.s += fieldBit__Signature_Text
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	default:
+		return schema.ErrInvalidKey{TypeName: "ipldgit.Signature", Key: &_String{k}}
+	}
+}
+func (_Signature__KeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Signature__KeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Signature__KeyAssembler) AssignNode(v datamodel.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Signature__KeyAssembler) Prototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (Signature) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Signature) Representation() datamodel.Node {
+	return (*_Signature__Repr)(n)
+}
+
+type _Signature__Repr _Signature
+
+var (
+	fieldName__Signature_Header_serial = _String{"header"}
+	fieldName__Signature_Text_serial   = _String{"text"}
+)
+var _ datamodel.Node = &_Signature__Repr{}
+
+func (_Signature__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
+}
+func (n *_Signature__Repr) LookupByString(key string) (datamodel.Node, error) {
+	switch key {
+	case "header":
+		return n.header.Representation(), nil
+	case "text":
+		return n.text.Representation(), nil
+	default:
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
+	}
+}
+func (n *_Signature__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
+	ks, err := key.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByString(ks)
+}
+func (_Signature__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.LookupByIndex(0)
+}
+func (n _Signature__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return n.LookupByString(seg.String())
+}
+func (n *_Signature__Repr) MapIterator() datamodel.MapIterator {
+	return &_Signature__ReprMapItr{n, 0}
+}
+
+type _Signature__ReprMapItr struct {
+	n   *_Signature__Repr
+	idx int
+}
+
+func (itr *_Signature__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 2 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
+	}
+	switch itr.idx {
+	case 0:
+		k = &fieldName__Signature_Header_serial
+		v = itr.n.header.Representation()
+	case 1:
+		k = &fieldName__Signature_Text_serial
+		v = itr.n.text.Representation()
+	default:
+		panic("unreachable")
+	}
+	itr.idx++
+	return
+}
+func (itr *_Signature__ReprMapItr) Done() bool {
+	return itr.idx >= 2
+}
+func (_Signature__Repr) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (rn *_Signature__Repr) Length() int64 {
+	l := 2
+	return int64(l)
+}
+func (_Signature__Repr) IsAbsent() bool {
+	return false
+}
+func (_Signature__Repr) IsNull() bool {
+	return false
+}
+func (_Signature__Repr) AsBool() (bool, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsBool()
+}
+func (_Signature__Repr) AsInt() (int64, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsInt()
+}
+func (_Signature__Repr) AsFloat() (float64, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsFloat()
+}
+func (_Signature__Repr) AsString() (string, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsString()
+}
+func (_Signature__Repr) AsBytes() ([]byte, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsBytes()
+}
+func (_Signature__Repr) AsLink() (datamodel.Link, error) {
+	return mixins.Map{TypeName: "ipldgit.Signature.Repr"}.AsLink()
+}
+func (_Signature__Repr) Prototype() datamodel.NodePrototype {
+	return _Signature__ReprPrototype{}
+}
+
+type _Signature__ReprPrototype struct{}
+
+func (_Signature__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Signature__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Signature__ReprBuilder struct {
+	_Signature__ReprAssembler
+}
+
+func (nb *_Signature__ReprBuilder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Signature__ReprBuilder) Reset() {
+	var w _Signature
+	var m schema.Maybe
+	*nb = _Signature__ReprBuilder{_Signature__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Signature__ReprAssembler struct {
+	w     *_Signature
+	m     *schema.Maybe
+	state maState
+	s     int
+	f     int
+
+	cm        schema.Maybe
+	ca_header _String__ReprAssembler
+	ca_text   _String__ReprAssembler
+}
+
+func (na *_Signature__ReprAssembler) reset() {
+	na.state = maState_initial
+	na.s = 0
+	na.ca_header.reset()
+	na.ca_text.reset()
+}
+func (na *_Signature__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if na.w == nil {
+		na.w = &_Signature{}
+	}
+	return na, nil
+}
+func (_Signature__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.BeginList(0)
+}
+func (na *_Signature__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Signature__ReprAssembler) AssignBool(bool) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignBool(false)
+}
+func (_Signature__ReprAssembler) AssignInt(int64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignInt(0)
+}
+func (_Signature__ReprAssembler) AssignFloat(float64) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignFloat(0)
+}
+func (_Signature__ReprAssembler) AssignString(string) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignString("")
+}
+func (_Signature__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignBytes(nil)
+}
+func (_Signature__ReprAssembler) AssignLink(datamodel.Link) error {
+	return mixins.MapAssembler{TypeName: "ipldgit.Signature.Repr"}.AssignLink(nil)
+}
+func (na *_Signature__ReprAssembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Signature); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		if na.w == nil {
+			na.w = v2
+			*na.m = schema.Maybe_Value
+			return nil
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Signature.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
+	}
+	itr := v.MapIterator()
+	for !itr.Done() {
+		k, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleKey().AssignNode(k); err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Signature__ReprAssembler) Prototype() datamodel.NodePrototype {
+	return _Signature__ReprPrototype{}
+}
+func (ma *_Signature__ReprAssembler) valueFinishTidy() bool {
+	switch ma.f {
+	case 0:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 1:
+		switch ma.cm {
+		case schema.Maybe_Value:
+			ma.cm = schema.Maybe_Absent
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Signature__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleEntry cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleEntry cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleEntry cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleEntry cannot be called on an assembler that's already finished")
+	}
+	switch k {
+	case "header":
+		if ma.s&fieldBit__Signature_Header != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Header_serial}
+		}
+		ma.s += fieldBit__Signature_Header
+		ma.state = maState_midValue
+		ma.f = 0
+		ma.ca_header.w = &ma.w.header
+		ma.ca_header.m = &ma.cm
+		return &ma.ca_header, nil
+	case "text":
+		if ma.s&fieldBit__Signature_Text != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Text_serial}
+		}
+		ma.s += fieldBit__Signature_Text
+		ma.state = maState_midValue
+		ma.f = 1
+		ma.ca_text.w = &ma.w.text
+		ma.ca_text.m = &ma.cm
+		return &ma.ca_text, nil
+	default:
+	}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Signature.Repr", Key: &_String{k}}
+}
+func (ma *_Signature__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: AssembleKey cannot be called when in the middle of assembling another key")
+	case maState_expectValue:
+		panic("invalid state: AssembleKey cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: AssembleKey cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: AssembleKey cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midKey
+	return (*_Signature__ReprKeyAssembler)(ma)
+}
+func (ma *_Signature__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
+	switch ma.state {
+	case maState_initial:
+		panic("invalid state: AssembleValue cannot be called when no key is primed")
+	case maState_midKey:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		// carry on
+	case maState_midValue:
+		panic("invalid state: AssembleValue cannot be called when in the middle of assembling another value")
+	case maState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	ma.state = maState_midValue
+	switch ma.f {
+	case 0:
+		ma.ca_header.w = &ma.w.header
+		ma.ca_header.m = &ma.cm
+		return &ma.ca_header
+	case 1:
+		ma.ca_text.w = &ma.w.text
+		ma.ca_text.m = &ma.cm
+		return &ma.ca_text
+	default:
+		panic("unreachable")
+	}
+}
+func (ma *_Signature__ReprAssembler) Finish() error {
+	switch ma.state {
+	case maState_initial:
+		// carry on
+	case maState_midKey:
+		panic("invalid state: Finish cannot be called when in the middle of assembling a key")
+	case maState_expectValue:
+		panic("invalid state: Finish cannot be called when expecting start of value assembly")
+	case maState_midValue:
+		if !ma.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case maState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	if ma.s&fieldBits__Signature_sufficient != fieldBits__Signature_sufficient {
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
+		if ma.s&fieldBit__Signature_Header == 0 {
+			err.Missing = append(err.Missing, "header")
+		}
+		if ma.s&fieldBit__Signature_Text == 0 {
+			err.Missing = append(err.Missing, "text")
+		}
+		return err
+	}
+	ma.state = maState_finished
+	*ma.m = schema.Maybe_Value
+	return nil
+}
+func (ma *_Signature__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+func (ma *_Signature__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
+	panic("todo structbuilder mapassembler repr valueprototype")
+}
+
+type _Signature__ReprKeyAssembler _Signature__ReprAssembler
+
+func (_Signature__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.BeginMap(0)
+}
+func (_Signature__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.BeginList(0)
+}
+func (na *_Signature__ReprKeyAssembler) AssignNull() error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignNull()
+}
+func (_Signature__ReprKeyAssembler) AssignBool(bool) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignBool(false)
+}
+func (_Signature__ReprKeyAssembler) AssignInt(int64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignInt(0)
+}
+func (_Signature__ReprKeyAssembler) AssignFloat(float64) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignFloat(0)
+}
+func (ka *_Signature__ReprKeyAssembler) AssignString(k string) error {
+	if ka.state != maState_midKey {
+		panic("misuse: KeyAssembler held beyond its valid lifetime")
+	}
+	switch k {
+	case "header":
+		if ka.s&fieldBit__Signature_Header != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Header_serial}
+		}
+		ka.s += fieldBit__Signature_Header
+		ka.state = maState_expectValue
+		ka.f = 0
+		return nil
+	case "text":
+		if ka.s&fieldBit__Signature_Text != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Signature_Text_serial}
+		}
+		ka.s += fieldBit__Signature_Text
+		ka.state = maState_expectValue
+		ka.f = 1
+		return nil
+	}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.Signature.Repr", Key: &_String{k}}
+}
+func (_Signature__ReprKeyAssembler) AssignBytes([]byte) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignBytes(nil)
+}
+func (_Signature__ReprKeyAssembler) AssignLink(datamodel.Link) error {
+	return mixins.StringAssembler{TypeName: "ipldgit.Signature.Repr.KeyAssembler"}.AssignLink(nil)
+}
+func (ka *_Signature__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
+	if v2, err := v.AsString(); err != nil {
+		return err
+	} else {
+		return ka.AssignString(v2)
+	}
+}
+func (_Signature__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
+	return _String__Prototype{}
+}
+
+func (n *_Signature_List) Lookup(idx int64) Signature {
+	if n.Length() <= idx {
+		return nil
+	}
+	v := &n.x[idx]
+	return v
+}
+func (n *_Signature_List) LookupMaybe(idx int64) MaybeSignature {
+	if n.Length() <= idx {
+		return nil
+	}
+	v := &n.x[idx]
+	return &_Signature__Maybe{
+		m: schema.Maybe_Value,
+		v: v,
+	}
+}
+
+var _Signature_List__valueAbsent = _Signature__Maybe{m: schema.Maybe_Absent}
+
+func (n Signature_List) Iterator() *Signature_List__Itr {
+	return &Signature_List__Itr{n, 0}
+}
+
+type Signature_List__Itr struct {
+	n   Signature_List
+	idx int
+}
+
+func (itr *Signature_List__Itr) Next() (idx int64, v Signature) {
+	if itr.idx >= len(itr.n.x) {
+		return -1, nil
+	}
+	idx = int64(itr.idx)
+	v = &itr.n.x[itr.idx]
+	itr.idx++
+	return
+}
+func (itr *Signature_List__Itr) Done() bool {
+	return itr.idx >= len(itr.n.x)
+}
+
+type _Signature_List__Maybe struct {
+	m schema.Maybe
+	v _Signature_List
+}
+type MaybeSignature_List = *_Signature_List__Maybe
+
+func (m MaybeSignature_List) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeSignature_List) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeSignature_List) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeSignature_List) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeSignature_List) Must() Signature_List {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ datamodel.Node = (Signature_List)(&_Signature_List{})
+var _ schema.TypedNode = (Signature_List)(&_Signature_List{})
+
+func (Signature_List) Kind() datamodel.Kind {
+	return datamodel.Kind_List
+}
+func (Signature_List) LookupByString(string) (datamodel.Node, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.LookupByString("")
+}
+func (n Signature_List) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
+	idx, err := k.AsInt()
+	if err != nil {
+		return nil, err
+	}
+	return n.LookupByIndex(idx)
+}
+func (n Signature_List) LookupByIndex(idx int64) (datamodel.Node, error) {
+	if n.Length() <= idx {
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfInt(idx)}
+	}
+	v := &n.x[idx]
+	return v, nil
+}
+func (n Signature_List) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	i, err := seg.Index()
+	if err != nil {
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Signature_List", TroubleSegment: seg, Reason: err}
+	}
+	return n.LookupByIndex(i)
+}
+func (Signature_List) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (n Signature_List) ListIterator() datamodel.ListIterator {
+	return &_Signature_List__ListItr{n, 0}
+}
+
+type _Signature_List__ListItr struct {
+	n   Signature_List
+	idx int
+}
+
+func (itr *_Signature_List__ListItr) Next() (idx int64, v datamodel.Node, _ error) {
+	if itr.idx >= len(itr.n.x) {
+		return -1, nil, datamodel.ErrIteratorOverread{}
+	}
+	idx = int64(itr.idx)
+	x := &itr.n.x[itr.idx]
+	v = x
+	itr.idx++
+	return
+}
+func (itr *_Signature_List__ListItr) Done() bool {
+	return itr.idx >= len(itr.n.x)
+}
+
+func (n Signature_List) Length() int64 {
+	return int64(len(n.x))
+}
+func (Signature_List) IsAbsent() bool {
+	return false
+}
+func (Signature_List) IsNull() bool {
+	return false
+}
+func (Signature_List) AsBool() (bool, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsBool()
+}
+func (Signature_List) AsInt() (int64, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsInt()
+}
+func (Signature_List) AsFloat() (float64, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsFloat()
+}
+func (Signature_List) AsString() (string, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsString()
+}
+func (Signature_List) AsBytes() ([]byte, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsBytes()
+}
+func (Signature_List) AsLink() (datamodel.Link, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List"}.AsLink()
+}
+func (Signature_List) Prototype() datamodel.NodePrototype {
+	return _Signature_List__Prototype{}
+}
+
+type _Signature_List__Prototype struct{}
+
+func (_Signature_List__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Signature_List__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Signature_List__Builder struct {
+	_Signature_List__Assembler
+}
+
+func (nb *_Signature_List__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Signature_List__Builder) Reset() {
+	var w _Signature_List
+	var m schema.Maybe
+	*nb = _Signature_List__Builder{_Signature_List__Assembler{w: &w, m: &m}}
+}
+
+type _Signature_List__Assembler struct {
+	w     *_Signature_List
+	m     *schema.Maybe
+	state laState
+
+	cm schema.Maybe
+	va _Signature__Assembler
+}
+
+func (na *_Signature_List__Assembler) reset() {
+	na.state = laState_initial
+	na.va.reset()
+}
+func (_Signature_List__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.BeginMap(0)
+}
+func (na *_Signature_List__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	if sizeHint > 0 {
+		na.w.x = make([]_Signature, 0, sizeHint)
+	}
+	return na, nil
+}
+func (na *_Signature_List__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
+}
+func (_Signature_List__Assembler) AssignBool(bool) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignBool(false)
+}
+func (_Signature_List__Assembler) AssignInt(int64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignInt(0)
+}
+func (_Signature_List__Assembler) AssignFloat(float64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignFloat(0)
+}
+func (_Signature_List__Assembler) AssignString(string) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignString("")
+}
+func (_Signature_List__Assembler) AssignBytes([]byte) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignBytes(nil)
+}
+func (_Signature_List__Assembler) AssignLink(datamodel.Link) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List"}.AssignLink(nil)
+}
+func (na *_Signature_List__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Signature_List); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Signature_List", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
+	}
+	itr := v.ListIterator()
+	for !itr.Done() {
+		_, v, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
+		}
+	}
+	return na.Finish()
+}
+func (_Signature_List__Assembler) Prototype() datamodel.NodePrototype {
+	return _Signature_List__Prototype{}
+}
+func (la *_Signature_List__Assembler) valueFinishTidy() bool {
+	switch la.cm {
+	case schema.Maybe_Value:
+		la.va.w = nil
+		la.cm = schema.Maybe_Absent
+		la.state = laState_initial
+		la.va.reset()
+		return true
+	default:
+		return false
+	}
+}
+func (la *_Signature_List__Assembler) AssembleValue() datamodel.NodeAssembler {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when still in the middle of assembling the previous value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
+	}
+	la.w.x = append(la.w.x, _Signature{})
+	la.state = laState_midValue
+	row := &la.w.x[len(la.w.x)-1]
+	la.va.w = row
+	la.va.m = &la.cm
+	return &la.va
+}
+func (la *_Signature_List__Assembler) Finish() error {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	la.state = laState_finished
+	*la.m = schema.Maybe_Value
+	return nil
+}
+func (la *_Signature_List__Assembler) ValuePrototype(_ int64) datamodel.NodePrototype {
+	return _Signature__Prototype{}
+}
+func (Signature_List) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (n Signature_List) Representation() datamodel.Node {
+	return (*_Signature_List__Repr)(n)
+}
+
+type _Signature_List__Repr _Signature_List
+
+var _ datamodel.Node = &_Signature_List__Repr{}
+
+func (_Signature_List__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_List
+}
+func (_Signature_List__Repr) LookupByString(string) (datamodel.Node, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.LookupByString("")
+}
+func (nr *_Signature_List__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
+	v, err := (Signature_List)(nr).LookupByNode(k)
+	if err != nil || v == datamodel.Null {
+		return v, err
+	}
+	return v.(Signature).Representation(), nil
+}
+func (nr *_Signature_List__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
+	v, err := (Signature_List)(nr).LookupByIndex(idx)
+	if err != nil || v == datamodel.Null {
+		return v, err
+	}
+	return v.(Signature).Representation(), nil
+}
+func (n _Signature_List__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	i, err := seg.Index()
+	if err != nil {
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Signature_List.Repr", TroubleSegment: seg, Reason: err}
+	}
+	return n.LookupByIndex(i)
+}
+func (_Signature_List__Repr) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (nr *_Signature_List__Repr) ListIterator() datamodel.ListIterator {
+	return &_Signature_List__ReprListItr{(Signature_List)(nr), 0}
+}
+
+type _Signature_List__ReprListItr _Signature_List__ListItr
+
+func (itr *_Signature_List__ReprListItr) Next() (idx int64, v datamodel.Node, err error) {
+	idx, v, err = (*_Signature_List__ListItr)(itr).Next()
+	if err != nil || v == datamodel.Null {
+		return
+	}
+	return idx, v.(Signature).Representation(), nil
+}
+func (itr *_Signature_List__ReprListItr) Done() bool {
+	return (*_Signature_List__ListItr)(itr).Done()
+}
+
+func (rn *_Signature_List__Repr) Length() int64 {
+	return int64(len(rn.x))
+}
+func (_Signature_List__Repr) IsAbsent() bool {
+	return false
+}
+func (_Signature_List__Repr) IsNull() bool {
+	return false
+}
+func (_Signature_List__Repr) AsBool() (bool, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsBool()
+}
+func (_Signature_List__Repr) AsInt() (int64, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsInt()
+}
+func (_Signature_List__Repr) AsFloat() (float64, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsFloat()
+}
+func (_Signature_List__Repr) AsString() (string, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsString()
+}
+func (_Signature_List__Repr) AsBytes() ([]byte, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsBytes()
+}
+func (_Signature_List__Repr) AsLink() (datamodel.Link, error) {
+	return mixins.List{TypeName: "ipldgit.Signature_List.Repr"}.AsLink()
+}
+func (_Signature_List__Repr) Prototype() datamodel.NodePrototype {
+	return _Signature_List__ReprPrototype{}
+}
+
+type _Signature_List__ReprPrototype struct{}
+
+func (_Signature_List__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Signature_List__ReprBuilder
+	nb.Reset()
+	return &nb
+}
+
+type _Signature_List__ReprBuilder struct {
+	_Signature_List__ReprAssembler
+}
+
+func (nb *_Signature_List__ReprBuilder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Signature_List__ReprBuilder) Reset() {
+	var w _Signature_List
+	var m schema.Maybe
+	*nb = _Signature_List__ReprBuilder{_Signature_List__ReprAssembler{w: &w, m: &m}}
+}
+
+type _Signature_List__ReprAssembler struct {
+	w     *_Signature_List
+	m     *schema.Maybe
+	state laState
+
+	cm schema.Maybe
+	va _Signature__ReprAssembler
+}
+
+func (na *_Signature_List__ReprAssembler) reset() {
+	na.state = laState_initial
+	na.va.reset()
+}
+func (_Signature_List__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.BeginMap(0)
 }
-func (ma *_PersonInfo__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
-	panic("todo structbuilder mapassembler repr valueprototype")
+func (na *_Signature_List__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: it makes no sense to 'begin' twice on the same assembler!")
+	}
+	*na.m = midvalue
+	if sizeHint < 0 {
+		sizeHint = 0
+	}
+	if sizeHint > 0 {
+		na.w.x = make([]_Signature, 0, sizeHint)
+	}
+	return na, nil
 }
-
-type _PersonInfo__ReprKeyAssembler _PersonInfo__ReprAssembler
-
-func (_PersonInfo__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.BeginMap(0)
+func (na *_Signature_List__ReprAssembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr.Repr"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	case midvalue:
+		panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
+	}
+	panic("unreachable")
 }
-func (_PersonInfo__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.BeginList(0)
+func (_Signature_List__ReprAssembler) AssignBool(bool) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignBool(false)
 }
-func (na *_PersonInfo__ReprKeyAssembler) AssignNull() error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignNull()
+func (_Signature_List__ReprAssembler) AssignInt(int64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignInt(0)
 }
-func (_PersonInfo__ReprKeyAssembler) AssignBool(bool) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignBool(false)
+func (_Signature_List__ReprAssembler) AssignFloat(float64) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignFloat(0)
 }
-func (_PersonInfo__ReprKeyAssembler) AssignInt(int64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignInt(0)
+func (_Signature_List__ReprAssembler) AssignString(string) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignString("")
 }
-func (_PersonInfo__ReprKeyAssembler) AssignFloat(float64) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignFloat(0)
+func (_Signature_List__ReprAssembler) AssignBytes([]byte) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignBytes(nil)
 }
-func (ka *_PersonInfo__ReprKeyAssembler) AssignString(k string) error {
-	if ka.state != maState_midKey {
-		panic("misuse: KeyAssembler held beyond its valid lifetime")
+func (_Signature_List__ReprAssembler) AssignLink(datamodel.Link) error {
+	return mixins.ListAssembler{TypeName: "ipldgit.Signature_List.Repr"}.AssignLink(nil)
+}
+func (na *_Signature_List__ReprAssembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
 	}
-	switch k {
-	case "date":
-		if ka.s&fieldBit__PersonInfo_Date != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Date_serial}
-		}
-		ka.s += fieldBit__PersonInfo_Date
-		ka.state = maState_expectValue
-		ka.f = 0
-		return nil
-	case "timezone":
-		if ka.s&fieldBit__PersonInfo_Timezone != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Timezone_serial}
+	if v2, ok := v.(*_Signature_List); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		case midvalue:
+			panic("invalid state: cannot assign null into an assembler that's already begun working on recursive structures!")
 		}
-		ka.s += fieldBit__PersonInfo_Timezone
-		ka.state = maState_expectValue
-		ka.f = 1
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
 		return nil
-	case "email":
-		if ka.s&fieldBit__PersonInfo_Email != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Email_serial}
+	}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Signature_List.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
+	}
+	itr := v.ListIterator()
+	for !itr.Done() {
+		_, v, err := itr.Next()
+		if err != nil {
+			return err
 		}
-		ka.s += fieldBit__PersonInfo_Email
-		ka.state = maState_expectValue
-		ka.f = 2
-		return nil
-	case "name":
-		if ka.s&fieldBit__PersonInfo_Name != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__PersonInfo_Name_serial}
+		if err := na.AssembleValue().AssignNode(v); err != nil {
+			return err
 		}
-		ka.s += fieldBit__PersonInfo_Name
-		ka.state = maState_expectValue
-		ka.f = 3
-		return nil
 	}
-	return ipld.ErrInvalidKey{TypeName: "ipldgit.PersonInfo.Repr", Key: &_String{k}}
+	return na.Finish()
 }
-func (_PersonInfo__ReprKeyAssembler) AssignBytes([]byte) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignBytes(nil)
+func (_Signature_List__ReprAssembler) Prototype() datamodel.NodePrototype {
+	return _Signature_List__ReprPrototype{}
 }
-func (_PersonInfo__ReprKeyAssembler) AssignLink(ipld.Link) error {
-	return mixins.StringAssembler{TypeName: "ipldgit.PersonInfo.Repr.KeyAssembler"}.AssignLink(nil)
+func (la *_Signature_List__ReprAssembler) valueFinishTidy() bool {
+	switch la.cm {
+	case schema.Maybe_Value:
+		la.va.w = nil
+		la.cm = schema.Maybe_Absent
+		la.state = laState_initial
+		la.va.reset()
+		return true
+	default:
+		return false
+	}
 }
-func (ka *_PersonInfo__ReprKeyAssembler) AssignNode(v ipld.Node) error {
-	if v2, err := v.AsString(); err != nil {
-		return err
-	} else {
-		return ka.AssignString(v2)
+func (la *_Signature_List__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: AssembleValue cannot be called when still in the middle of assembling the previous value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: AssembleValue cannot be called on an assembler that's already finished")
 	}
+	la.w.x = append(la.w.x, _Signature{})
+	la.state = laState_midValue
+	row := &la.w.x[len(la.w.x)-1]
+	la.va.w = row
+	la.va.m = &la.cm
+	return &la.va
 }
-func (_PersonInfo__ReprKeyAssembler) Prototype() ipld.NodePrototype {
-	return _String__Prototype{}
+func (la *_Signature_List__ReprAssembler) Finish() error {
+	switch la.state {
+	case laState_initial:
+		// carry on
+	case laState_midValue:
+		if !la.valueFinishTidy() {
+			panic("invalid state: Finish cannot be called when in the middle of assembling a value")
+		} // if tidy success: carry on
+	case laState_finished:
+		panic("invalid state: Finish cannot be called on an assembler that's already finished")
+	}
+	la.state = laState_finished
+	*la.m = schema.Maybe_Value
+	return nil
+}
+func (la *_Signature_List__ReprAssembler) ValuePrototype(_ int64) datamodel.NodePrototype {
+	return _Signature__ReprPrototype{}
 }
 
 func (n String) String() string {
@@ -4149,12 +7277,12 @@ func (m MaybeString) IsAbsent() bool {
 func (m MaybeString) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeString) AsNode() ipld.Node {
+func (m MaybeString) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -4168,28 +7296,28 @@ func (m MaybeString) Must() String {
 	return &m.v
 }
 
-var _ ipld.Node = (String)(&_String{})
+var _ datamodel.Node = (String)(&_String{})
 var _ schema.TypedNode = (String)(&_String{})
 
-func (String) Kind() ipld.Kind {
-	return ipld.Kind_String
+func (String) Kind() datamodel.Kind {
+	return datamodel.Kind_String
 }
-func (String) LookupByString(string) (ipld.Node, error) {
+func (String) LookupByString(string) (datamodel.Node, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.LookupByString("")
 }
-func (String) LookupByNode(ipld.Node) (ipld.Node, error) {
+func (String) LookupByNode(datamodel.Node) (datamodel.Node, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.LookupByNode(nil)
 }
-func (String) LookupByIndex(idx int64) (ipld.Node, error) {
+func (String) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.LookupByIndex(0)
 }
-func (String) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (String) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.LookupBySegment(seg)
 }
-func (String) MapIterator() ipld.MapIterator {
+func (String) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (String) ListIterator() ipld.ListIterator {
+func (String) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (String) Length() int64 {
@@ -4216,16 +7344,16 @@ func (n String) AsString() (string, error) {
 func (String) AsBytes() ([]byte, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.AsBytes()
 }
-func (String) AsLink() (ipld.Link, error) {
+func (String) AsLink() (datamodel.Link, error) {
 	return mixins.String{TypeName: "ipldgit.String"}.AsLink()
 }
-func (String) Prototype() ipld.NodePrototype {
+func (String) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 
 type _String__Prototype struct{}
 
-func (_String__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_String__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _String__Builder
 	nb.Reset()
 	return &nb
@@ -4235,7 +7363,7 @@ type _String__Builder struct {
 	_String__Assembler
 }
 
-func (nb *_String__Builder) Build() ipld.Node {
+func (nb *_String__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -4253,10 +7381,10 @@ type _String__Assembler struct {
 }
 
 func (na *_String__Assembler) reset() {}
-func (_String__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_String__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.String"}.BeginMap(0)
 }
-func (_String__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_String__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.String"}.BeginList(0)
 }
 func (na *_String__Assembler) AssignNull() error {
@@ -4292,10 +7420,10 @@ func (na *_String__Assembler) AssignString(v string) error {
 func (_String__Assembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.String"}.AssignBytes(nil)
 }
-func (_String__Assembler) AssignLink(ipld.Link) error {
+func (_String__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.String"}.AssignLink(nil)
 }
-func (na *_String__Assembler) AssignNode(v ipld.Node) error {
+func (na *_String__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -4314,19 +7442,19 @@ func (na *_String__Assembler) AssignNode(v ipld.Node) error {
 		return na.AssignString(v2)
 	}
 }
-func (_String__Assembler) Prototype() ipld.NodePrototype {
+func (_String__Assembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 func (String) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n String) Representation() ipld.Node {
+func (n String) Representation() datamodel.Node {
 	return (*_String__Repr)(n)
 }
 
 type _String__Repr = _String
 
-var _ ipld.Node = &_String__Repr{}
+var _ datamodel.Node = &_String__Repr{}
 
 type _String__ReprPrototype = _String__Prototype
 type _String__ReprAssembler = _String__Assembler
@@ -4388,12 +7516,12 @@ func (m MaybeString_List) IsAbsent() bool {
 func (m MaybeString_List) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeString_List) AsNode() ipld.Node {
+func (m MaybeString_List) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -4407,40 +7535,40 @@ func (m MaybeString_List) Must() String_List {
 	return &m.v
 }
 
-var _ ipld.Node = (String_List)(&_String_List{})
+var _ datamodel.Node = (String_List)(&_String_List{})
 var _ schema.TypedNode = (String_List)(&_String_List{})
 
-func (String_List) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (String_List) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (String_List) LookupByString(string) (ipld.Node, error) {
+func (String_List) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.String_List"}.LookupByString("")
 }
-func (n String_List) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (n String_List) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	idx, err := k.AsInt()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByIndex(idx)
 }
-func (n String_List) LookupByIndex(idx int64) (ipld.Node, error) {
+func (n String_List) LookupByIndex(idx int64) (datamodel.Node, error) {
 	if n.Length() <= idx {
-		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfInt(idx)}
 	}
 	v := &n.x[idx]
 	return v, nil
 }
-func (n String_List) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n String_List) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.String_List", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.String_List", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (String_List) MapIterator() ipld.MapIterator {
+func (String_List) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (n String_List) ListIterator() ipld.ListIterator {
+func (n String_List) ListIterator() datamodel.ListIterator {
 	return &_String_List__ListItr{n, 0}
 }
 
@@ -4449,9 +7577,9 @@ type _String_List__ListItr struct {
 	idx int
 }
 
-func (itr *_String_List__ListItr) Next() (idx int64, v ipld.Node, _ error) {
+func (itr *_String_List__ListItr) Next() (idx int64, v datamodel.Node, _ error) {
 	if itr.idx >= len(itr.n.x) {
-		return -1, nil, ipld.ErrIteratorOverread{}
+		return -1, nil, datamodel.ErrIteratorOverread{}
 	}
 	idx = int64(itr.idx)
 	x := &itr.n.x[itr.idx]
@@ -4487,16 +7615,16 @@ func (String_List) AsString() (string, error) {
 func (String_List) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.String_List"}.AsBytes()
 }
-func (String_List) AsLink() (ipld.Link, error) {
+func (String_List) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.String_List"}.AsLink()
 }
-func (String_List) Prototype() ipld.NodePrototype {
+func (String_List) Prototype() datamodel.NodePrototype {
 	return _String_List__Prototype{}
 }
 
 type _String_List__Prototype struct{}
 
-func (_String_List__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_String_List__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _String_List__Builder
 	nb.Reset()
 	return &nb
@@ -4506,7 +7634,7 @@ type _String_List__Builder struct {
 	_String_List__Assembler
 }
 
-func (nb *_String_List__Builder) Build() ipld.Node {
+func (nb *_String_List__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -4531,10 +7659,10 @@ func (na *_String_List__Assembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_String_List__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_String_List__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List"}.BeginMap(0)
 }
-func (na *_String_List__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_String_List__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -4579,10 +7707,10 @@ func (_String_List__Assembler) AssignString(string) error {
 func (_String_List__Assembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List"}.AssignBytes(nil)
 }
-func (_String_List__Assembler) AssignLink(ipld.Link) error {
+func (_String_List__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List"}.AssignLink(nil)
 }
-func (na *_String_List__Assembler) AssignNode(v ipld.Node) error {
+func (na *_String_List__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -4597,8 +7725,8 @@ func (na *_String_List__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.String_List", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.String_List", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -4612,7 +7740,7 @@ func (na *_String_List__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_String_List__Assembler) Prototype() ipld.NodePrototype {
+func (_String_List__Assembler) Prototype() datamodel.NodePrototype {
 	return _String_List__Prototype{}
 }
 func (la *_String_List__Assembler) valueFinishTidy() bool {
@@ -4627,7 +7755,7 @@ func (la *_String_List__Assembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_String_List__Assembler) AssembleValue() ipld.NodeAssembler {
+func (la *_String_List__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -4660,59 +7788,59 @@ func (la *_String_List__Assembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_String_List__Assembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_String_List__Assembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 func (String_List) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n String_List) Representation() ipld.Node {
+func (n String_List) Representation() datamodel.Node {
 	return (*_String_List__Repr)(n)
 }
 
 type _String_List__Repr _String_List
 
-var _ ipld.Node = &_String_List__Repr{}
+var _ datamodel.Node = &_String_List__Repr{}
 
-func (_String_List__Repr) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (_String_List__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (_String_List__Repr) LookupByString(string) (ipld.Node, error) {
+func (_String_List__Repr) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.String_List.Repr"}.LookupByString("")
 }
-func (nr *_String_List__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (nr *_String_List__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	v, err := (String_List)(nr).LookupByNode(k)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(String).Representation(), nil
 }
-func (nr *_String_List__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (nr *_String_List__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	v, err := (String_List)(nr).LookupByIndex(idx)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(String).Representation(), nil
 }
-func (n _String_List__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _String_List__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.String_List.Repr", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.String_List.Repr", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (_String_List__Repr) MapIterator() ipld.MapIterator {
+func (_String_List__Repr) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (nr *_String_List__Repr) ListIterator() ipld.ListIterator {
+func (nr *_String_List__Repr) ListIterator() datamodel.ListIterator {
 	return &_String_List__ReprListItr{(String_List)(nr), 0}
 }
 
 type _String_List__ReprListItr _String_List__ListItr
 
-func (itr *_String_List__ReprListItr) Next() (idx int64, v ipld.Node, err error) {
+func (itr *_String_List__ReprListItr) Next() (idx int64, v datamodel.Node, err error) {
 	idx, v, err = (*_String_List__ListItr)(itr).Next()
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return
 	}
 	return idx, v.(String).Representation(), nil
@@ -4745,16 +7873,16 @@ func (_String_List__Repr) AsString() (string, error) {
 func (_String_List__Repr) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.String_List.Repr"}.AsBytes()
 }
-func (_String_List__Repr) AsLink() (ipld.Link, error) {
+func (_String_List__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.String_List.Repr"}.AsLink()
 }
-func (_String_List__Repr) Prototype() ipld.NodePrototype {
+func (_String_List__Repr) Prototype() datamodel.NodePrototype {
 	return _String_List__ReprPrototype{}
 }
 
 type _String_List__ReprPrototype struct{}
 
-func (_String_List__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_String_List__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _String_List__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -4764,7 +7892,7 @@ type _String_List__ReprBuilder struct {
 	_String_List__ReprAssembler
 }
 
-func (nb *_String_List__ReprBuilder) Build() ipld.Node {
+func (nb *_String_List__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -4789,10 +7917,10 @@ func (na *_String_List__ReprAssembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_String_List__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_String_List__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List.Repr"}.BeginMap(0)
 }
-func (na *_String_List__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_String_List__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -4837,10 +7965,10 @@ func (_String_List__ReprAssembler) AssignString(string) error {
 func (_String_List__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List.Repr"}.AssignBytes(nil)
 }
-func (_String_List__ReprAssembler) AssignLink(ipld.Link) error {
+func (_String_List__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.String_List.Repr"}.AssignLink(nil)
 }
-func (na *_String_List__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_String_List__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -4855,8 +7983,8 @@ func (na *_String_List__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.String_List.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.String_List.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -4870,7 +7998,7 @@ func (na *_String_List__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_String_List__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_String_List__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _String_List__ReprPrototype{}
 }
 func (la *_String_List__ReprAssembler) valueFinishTidy() bool {
@@ -4885,7 +8013,7 @@ func (la *_String_List__ReprAssembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_String_List__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (la *_String_List__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -4918,7 +8046,7 @@ func (la *_String_List__ReprAssembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_String_List__ReprAssembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_String_List__ReprAssembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _String__ReprPrototype{}
 }
 
@@ -4931,12 +8059,24 @@ func (n _Tag) FieldType() String {
 func (n _Tag) FieldTag() String {
 	return &n.tag
 }
-func (n _Tag) FieldTagger() PersonInfo {
+func (n _Tag) FieldTagger() MaybePersonInfo {
 	return &n.tagger
 }
 func (n _Tag) FieldMessage() String {
 	return &n.message
 }
+func (n _Tag) FieldSignature() MaybeString {
+	return &n.signature
+}
+func (n _Tag) FieldSignatures() MaybeSignature_List {
+	return &n.signatures
+}
+func (n _Tag) FieldOther() MaybeHeader_List {
+	return &n.other
+}
+func (n _Tag) FieldHeaderOrder() MaybeString_List {
+	return &n.headerOrder
+}
 
 type _Tag__Maybe struct {
 	m schema.Maybe
@@ -4953,12 +8093,12 @@ func (m MaybeTag) IsAbsent() bool {
 func (m MaybeTag) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeTag) AsNode() ipld.Node {
+func (m MaybeTag) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return m.v
 	default:
@@ -4973,19 +8113,23 @@ func (m MaybeTag) Must() Tag {
 }
 
 var (
-	fieldName__Tag_Object  = _String{"object"}
-	fieldName__Tag_Type    = _String{"type"}
-	fieldName__Tag_Tag     = _String{"tag"}
-	fieldName__Tag_Tagger  = _String{"tagger"}
-	fieldName__Tag_Message = _String{"message"}
+	fieldName__Tag_Object      = _String{"object"}
+	fieldName__Tag_Type        = _String{"type"}
+	fieldName__Tag_Tag         = _String{"tag"}
+	fieldName__Tag_Tagger      = _String{"tagger"}
+	fieldName__Tag_Message     = _String{"message"}
+	fieldName__Tag_Signature   = _String{"signature"}
+	fieldName__Tag_Signatures  = _String{"signatures"}
+	fieldName__Tag_Other       = _String{"other"}
+	fieldName__Tag_HeaderOrder = _String{"headerOrder"}
 )
-var _ ipld.Node = (Tag)(&_Tag{})
+var _ datamodel.Node = (Tag)(&_Tag{})
 var _ schema.TypedNode = (Tag)(&_Tag{})
 
-func (Tag) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (Tag) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n Tag) LookupByString(key string) (ipld.Node, error) {
+func (n Tag) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "object":
 		return &n.object, nil
@@ -4994,27 +8138,50 @@ func (n Tag) LookupByString(key string) (ipld.Node, error) {
 	case "tag":
 		return &n.tag, nil
 	case "tagger":
-		return &n.tagger, nil
+		if n.tagger.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return n.tagger.v, nil
 	case "message":
 		return &n.message, nil
+	case "signature":
+		if n.signature.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return &n.signature.v, nil
+	case "signatures":
+		if n.signatures.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return &n.signatures.v, nil
+	case "other":
+		if n.other.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return &n.other.v, nil
+	case "headerOrder":
+		if n.headerOrder.m == schema.Maybe_Absent {
+			return datamodel.Absent, nil
+		}
+		return &n.headerOrder.v, nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n Tag) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n Tag) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (Tag) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Tag) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag"}.LookupByIndex(0)
 }
-func (n Tag) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n Tag) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n Tag) MapIterator() ipld.MapIterator {
+func (n Tag) MapIterator() datamodel.MapIterator {
 	return &_Tag__MapItr{n, 0}
 }
 
@@ -5023,9 +8190,9 @@ type _Tag__MapItr struct {
 	idx int
 }
 
-func (itr *_Tag__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
-	if itr.idx >= 5 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+func (itr *_Tag__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+	if itr.idx >= 9 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -5039,10 +8206,42 @@ func (itr *_Tag__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 		v = &itr.n.tag
 	case 3:
 		k = &fieldName__Tag_Tagger
-		v = &itr.n.tagger
+		if itr.n.tagger.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = itr.n.tagger.v
 	case 4:
 		k = &fieldName__Tag_Message
 		v = &itr.n.message
+	case 5:
+		k = &fieldName__Tag_Signature
+		if itr.n.signature.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = &itr.n.signature.v
+	case 6:
+		k = &fieldName__Tag_Signatures
+		if itr.n.signatures.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = &itr.n.signatures.v
+	case 7:
+		k = &fieldName__Tag_Other
+		if itr.n.other.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = &itr.n.other.v
+	case 8:
+		k = &fieldName__Tag_HeaderOrder
+		if itr.n.headerOrder.m == schema.Maybe_Absent {
+			v = datamodel.Absent
+			break
+		}
+		v = &itr.n.headerOrder.v
 	default:
 		panic("unreachable")
 	}
@@ -5050,14 +8249,14 @@ func (itr *_Tag__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 	return
 }
 func (itr *_Tag__MapItr) Done() bool {
-	return itr.idx >= 5
+	return itr.idx >= 9
 }
 
-func (Tag) ListIterator() ipld.ListIterator {
+func (Tag) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (Tag) Length() int64 {
-	return 5
+	return 9
 }
 func (Tag) IsAbsent() bool {
 	return false
@@ -5080,16 +8279,16 @@ func (Tag) AsString() (string, error) {
 func (Tag) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag"}.AsBytes()
 }
-func (Tag) AsLink() (ipld.Link, error) {
+func (Tag) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag"}.AsLink()
 }
-func (Tag) Prototype() ipld.NodePrototype {
+func (Tag) Prototype() datamodel.NodePrototype {
 	return _Tag__Prototype{}
 }
 
 type _Tag__Prototype struct{}
 
-func (_Tag__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Tag__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tag__Builder
 	nb.Reset()
 	return &nb
@@ -5099,7 +8298,7 @@ type _Tag__Builder struct {
 	_Tag__Assembler
 }
 
-func (nb *_Tag__Builder) Build() ipld.Node {
+func (nb *_Tag__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -5118,12 +8317,16 @@ type _Tag__Assembler struct {
 	s     int
 	f     int
 
-	cm         schema.Maybe
-	ca_object  _Link__Assembler
-	ca_typ     _String__Assembler
-	ca_tag     _String__Assembler
-	ca_tagger  _PersonInfo__Assembler
-	ca_message _String__Assembler
+	cm             schema.Maybe
+	ca_object      _Link__Assembler
+	ca_typ         _String__Assembler
+	ca_tag         _String__Assembler
+	ca_tagger      _PersonInfo__Assembler
+	ca_message     _String__Assembler
+	ca_signature   _String__Assembler
+	ca_signatures  _Signature_List__Assembler
+	ca_other       _Header_List__Assembler
+	ca_headerOrder _String_List__Assembler
 }
 
 func (na *_Tag__Assembler) reset() {
@@ -5134,6 +8337,10 @@ func (na *_Tag__Assembler) reset() {
 	na.ca_tag.reset()
 	na.ca_tagger.reset()
 	na.ca_message.reset()
+	na.ca_signature.reset()
+	na.ca_signatures.reset()
+	na.ca_other.reset()
+	na.ca_headerOrder.reset()
 }
 
 var (
@@ -5142,10 +8349,14 @@ var (
 	fieldBit__Tag_Tag         = 1 << 2
 	fieldBit__Tag_Tagger      = 1 << 3
 	fieldBit__Tag_Message     = 1 << 4
-	fieldBits__Tag_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<3 + 1<<4
+	fieldBit__Tag_Signature   = 1 << 5
+	fieldBit__Tag_Signatures  = 1 << 6
+	fieldBit__Tag_Other       = 1 << 7
+	fieldBit__Tag_HeaderOrder = 1 << 8
+	fieldBits__Tag_sufficient = 0 + 1<<0 + 1<<1 + 1<<2 + 1<<4
 )
 
-func (na *_Tag__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_Tag__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -5158,7 +8369,7 @@ func (na *_Tag__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	return na, nil
 }
-func (_Tag__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tag__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag"}.BeginList(0)
 }
 func (na *_Tag__Assembler) AssignNull() error {
@@ -5190,10 +8401,10 @@ func (_Tag__Assembler) AssignString(string) error {
 func (_Tag__Assembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag"}.AssignBytes(nil)
 }
-func (_Tag__Assembler) AssignLink(ipld.Link) error {
+func (_Tag__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag"}.AssignLink(nil)
 }
-func (na *_Tag__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Tag__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -5213,8 +8424,8 @@ func (na *_Tag__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tag", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tag", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -5231,7 +8442,7 @@ func (na *_Tag__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tag__Assembler) Prototype() ipld.NodePrototype {
+func (_Tag__Assembler) Prototype() datamodel.NodePrototype {
 	return _Tag__Prototype{}
 }
 func (ma *_Tag__Assembler) valueFinishTidy() bool {
@@ -5267,10 +8478,9 @@ func (ma *_Tag__Assembler) valueFinishTidy() bool {
 			return false
 		}
 	case 3:
-		switch ma.cm {
+		switch ma.w.tagger.m {
 		case schema.Maybe_Value:
-			ma.ca_tagger.w = nil
-			ma.cm = schema.Maybe_Absent
+			ma.w.tagger.v = ma.ca_tagger.w
 			ma.state = maState_initial
 			return true
 		default:
@@ -5286,11 +8496,43 @@ func (ma *_Tag__Assembler) valueFinishTidy() bool {
 		default:
 			return false
 		}
+	case 5:
+		switch ma.w.signature.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 6:
+		switch ma.w.signatures.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 7:
+		switch ma.w.other.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 8:
+		switch ma.w.headerOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
 	default:
 		panic("unreachable")
 	}
 }
-func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Tag__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -5308,7 +8550,7 @@ func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
 	switch k {
 	case "object":
 		if ma.s&fieldBit__Tag_Object != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Object}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Object}
 		}
 		ma.s += fieldBit__Tag_Object
 		ma.state = maState_midValue
@@ -5318,7 +8560,7 @@ func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
 		return &ma.ca_object, nil
 	case "type":
 		if ma.s&fieldBit__Tag_Type != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Type}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Type}
 		}
 		ma.s += fieldBit__Tag_Type
 		ma.state = maState_midValue
@@ -5328,7 +8570,7 @@ func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
 		return &ma.ca_typ, nil
 	case "tag":
 		if ma.s&fieldBit__Tag_Tag != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag}
 		}
 		ma.s += fieldBit__Tag_Tag
 		ma.state = maState_midValue
@@ -5338,17 +8580,17 @@ func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
 		return &ma.ca_tag, nil
 	case "tagger":
 		if ma.s&fieldBit__Tag_Tagger != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger}
 		}
 		ma.s += fieldBit__Tag_Tagger
 		ma.state = maState_midValue
 		ma.f = 3
-		ma.ca_tagger.w = &ma.w.tagger
-		ma.ca_tagger.m = &ma.cm
+		ma.ca_tagger.w = ma.w.tagger.v
+		ma.ca_tagger.m = &ma.w.tagger.m
 		return &ma.ca_tagger, nil
 	case "message":
 		if ma.s&fieldBit__Tag_Message != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Message}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Message}
 		}
 		ma.s += fieldBit__Tag_Message
 		ma.state = maState_midValue
@@ -5356,10 +8598,50 @@ func (ma *_Tag__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
 		ma.ca_message.w = &ma.w.message
 		ma.ca_message.m = &ma.cm
 		return &ma.ca_message, nil
+	case "signature":
+		if ma.s&fieldBit__Tag_Signature != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signature}
+		}
+		ma.s += fieldBit__Tag_Signature
+		ma.state = maState_midValue
+		ma.f = 5
+		ma.ca_signature.w = &ma.w.signature.v
+		ma.ca_signature.m = &ma.w.signature.m
+		return &ma.ca_signature, nil
+	case "signatures":
+		if ma.s&fieldBit__Tag_Signatures != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signatures}
+		}
+		ma.s += fieldBit__Tag_Signatures
+		ma.state = maState_midValue
+		ma.f = 6
+		ma.ca_signatures.w = &ma.w.signatures.v
+		ma.ca_signatures.m = &ma.w.signatures.m
+		return &ma.ca_signatures, nil
+	case "other":
+		if ma.s&fieldBit__Tag_Other != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Other}
+		}
+		ma.s += fieldBit__Tag_Other
+		ma.state = maState_midValue
+		ma.f = 7
+		ma.ca_other.w = &ma.w.other.v
+		ma.ca_other.m = &ma.w.other.m
+		return &ma.ca_other, nil
+	case "headerOrder":
+		if ma.s&fieldBit__Tag_HeaderOrder != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_HeaderOrder}
+		}
+		ma.s += fieldBit__Tag_HeaderOrder
+		ma.state = maState_midValue
+		ma.f = 8
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+		return &ma.ca_headerOrder, nil
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.Tag", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Tag", Key: &_String{k}}
 }
-func (ma *_Tag__Assembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Tag__Assembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -5377,7 +8659,7 @@ func (ma *_Tag__Assembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_Tag__KeyAssembler)(ma)
 }
-func (ma *_Tag__Assembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Tag__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -5405,13 +8687,29 @@ func (ma *_Tag__Assembler) AssembleValue() ipld.NodeAssembler {
 		ma.ca_tag.m = &ma.cm
 		return &ma.ca_tag
 	case 3:
-		ma.ca_tagger.w = &ma.w.tagger
-		ma.ca_tagger.m = &ma.cm
+		ma.ca_tagger.w = ma.w.tagger.v
+		ma.ca_tagger.m = &ma.w.tagger.m
 		return &ma.ca_tagger
 	case 4:
 		ma.ca_message.w = &ma.w.message
 		ma.ca_message.m = &ma.cm
 		return &ma.ca_message
+	case 5:
+		ma.ca_signature.w = &ma.w.signature.v
+		ma.ca_signature.m = &ma.w.signature.m
+		return &ma.ca_signature
+	case 6:
+		ma.ca_signatures.w = &ma.w.signatures.v
+		ma.ca_signatures.m = &ma.w.signatures.m
+		return &ma.ca_signatures
+	case 7:
+		ma.ca_other.w = &ma.w.other.v
+		ma.ca_other.m = &ma.w.other.m
+		return &ma.ca_other
+	case 8:
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+		return &ma.ca_headerOrder
 	default:
 		panic("unreachable")
 	}
@@ -5432,7 +8730,7 @@ func (ma *_Tag__Assembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__Tag_sufficient != fieldBits__Tag_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__Tag_Object == 0 {
 			err.Missing = append(err.Missing, "object")
 		}
@@ -5442,9 +8740,6 @@ func (ma *_Tag__Assembler) Finish() error {
 		if ma.s&fieldBit__Tag_Tag == 0 {
 			err.Missing = append(err.Missing, "tag")
 		}
-		if ma.s&fieldBit__Tag_Tagger == 0 {
-			err.Missing = append(err.Missing, "tagger")
-		}
 		if ma.s&fieldBit__Tag_Message == 0 {
 			err.Missing = append(err.Missing, "message")
 		}
@@ -5454,19 +8749,19 @@ func (ma *_Tag__Assembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Tag__Assembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Tag__Assembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_Tag__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_Tag__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler valueprototype")
 }
 
 type _Tag__KeyAssembler _Tag__Assembler
 
-func (_Tag__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Tag__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.KeyAssembler"}.BeginMap(0)
 }
-func (_Tag__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tag__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.KeyAssembler"}.BeginList(0)
 }
 func (na *_Tag__KeyAssembler) AssignNull() error {
@@ -5488,7 +8783,7 @@ func (ka *_Tag__KeyAssembler) AssignString(k string) error {
 	switch k {
 	case "object":
 		if ka.s&fieldBit__Tag_Object != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Object}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Object}
 		}
 		ka.s += fieldBit__Tag_Object
 		ka.state = maState_expectValue
@@ -5496,7 +8791,7 @@ func (ka *_Tag__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "type":
 		if ka.s&fieldBit__Tag_Type != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Type}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Type}
 		}
 		ka.s += fieldBit__Tag_Type
 		ka.state = maState_expectValue
@@ -5504,7 +8799,7 @@ func (ka *_Tag__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "tag":
 		if ka.s&fieldBit__Tag_Tag != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag}
 		}
 		ka.s += fieldBit__Tag_Tag
 		ka.state = maState_expectValue
@@ -5512,7 +8807,7 @@ func (ka *_Tag__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "tagger":
 		if ka.s&fieldBit__Tag_Tagger != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger}
 		}
 		ka.s += fieldBit__Tag_Tagger
 		ka.state = maState_expectValue
@@ -5520,54 +8815,90 @@ func (ka *_Tag__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "message":
 		if ka.s&fieldBit__Tag_Message != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Message}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Message}
 		}
 		ka.s += fieldBit__Tag_Message
 		ka.state = maState_expectValue
 		ka.f = 4
 		return nil
+	case "signature":
+		if ka.s&fieldBit__Tag_Signature != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signature}
+		}
+		ka.s += fieldBit__Tag_Signature
+		ka.state = maState_expectValue
+		ka.f = 5
+		return nil
+	case "signatures":
+		if ka.s&fieldBit__Tag_Signatures != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signatures}
+		}
+		ka.s += fieldBit__Tag_Signatures
+		ka.state = maState_expectValue
+		ka.f = 6
+		return nil
+	case "other":
+		if ka.s&fieldBit__Tag_Other != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Other}
+		}
+		ka.s += fieldBit__Tag_Other
+		ka.state = maState_expectValue
+		ka.f = 7
+		return nil
+	case "headerOrder":
+		if ka.s&fieldBit__Tag_HeaderOrder != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_HeaderOrder}
+		}
+		ka.s += fieldBit__Tag_HeaderOrder
+		ka.state = maState_expectValue
+		ka.f = 8
+		return nil
 	default:
-		return ipld.ErrInvalidKey{TypeName: "ipldgit.Tag", Key: &_String{k}}
+		return schema.ErrInvalidKey{TypeName: "ipldgit.Tag", Key: &_String{k}}
 	}
 }
 func (_Tag__KeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.KeyAssembler"}.AssignBytes(nil)
 }
-func (_Tag__KeyAssembler) AssignLink(ipld.Link) error {
+func (_Tag__KeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_Tag__KeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_Tag__KeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_Tag__KeyAssembler) Prototype() ipld.NodePrototype {
+func (_Tag__KeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 func (Tag) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Tag) Representation() ipld.Node {
+func (n Tag) Representation() datamodel.Node {
 	return (*_Tag__Repr)(n)
 }
 
 type _Tag__Repr _Tag
 
 var (
-	fieldName__Tag_Object_serial  = _String{"object"}
-	fieldName__Tag_Type_serial    = _String{"type"}
-	fieldName__Tag_Tag_serial     = _String{"tag"}
-	fieldName__Tag_Tagger_serial  = _String{"tagger"}
-	fieldName__Tag_Message_serial = _String{"message"}
+	fieldName__Tag_Object_serial      = _String{"object"}
+	fieldName__Tag_Type_serial        = _String{"type"}
+	fieldName__Tag_Tag_serial         = _String{"tag"}
+	fieldName__Tag_Tagger_serial      = _String{"tagger"}
+	fieldName__Tag_Message_serial     = _String{"message"}
+	fieldName__Tag_Signature_serial   = _String{"signature"}
+	fieldName__Tag_Signatures_serial  = _String{"signatures"}
+	fieldName__Tag_Other_serial       = _String{"other"}
+	fieldName__Tag_HeaderOrder_serial = _String{"headerOrder"}
 )
-var _ ipld.Node = &_Tag__Repr{}
+var _ datamodel.Node = &_Tag__Repr{}
 
-func (_Tag__Repr) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (_Tag__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n *_Tag__Repr) LookupByString(key string) (ipld.Node, error) {
+func (n *_Tag__Repr) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "object":
 		return n.object.Representation(), nil
@@ -5576,38 +8907,85 @@ func (n *_Tag__Repr) LookupByString(key string) (ipld.Node, error) {
 	case "tag":
 		return n.tag.Representation(), nil
 	case "tagger":
-		return n.tagger.Representation(), nil
+		if n.tagger.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.tagger.v.Representation(), nil
 	case "message":
 		return n.message.Representation(), nil
+	case "signature":
+		if n.signature.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.signature.v.Representation(), nil
+	case "signatures":
+		if n.signatures.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.signatures.v.Representation(), nil
+	case "other":
+		if n.other.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.other.v.Representation(), nil
+	case "headerOrder":
+		if n.headerOrder.m == schema.Maybe_Absent {
+			return datamodel.Absent, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(key)}
+		}
+		return n.headerOrder.v.Representation(), nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n *_Tag__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n *_Tag__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (_Tag__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (_Tag__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag.Repr"}.LookupByIndex(0)
 }
-func (n _Tag__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Tag__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n *_Tag__Repr) MapIterator() ipld.MapIterator {
-	return &_Tag__ReprMapItr{n, 0}
+func (n *_Tag__Repr) MapIterator() datamodel.MapIterator {
+	end := 9
+	if n.headerOrder.m == schema.Maybe_Absent {
+		end = 8
+	} else {
+		goto done
+	}
+	if n.other.m == schema.Maybe_Absent {
+		end = 7
+	} else {
+		goto done
+	}
+	if n.signatures.m == schema.Maybe_Absent {
+		end = 6
+	} else {
+		goto done
+	}
+	if n.signature.m == schema.Maybe_Absent {
+		end = 5
+	} else {
+		goto done
+	}
+done:
+	return &_Tag__ReprMapItr{n, 0, end}
 }
 
 type _Tag__ReprMapItr struct {
 	n   *_Tag__Repr
 	idx int
+	end int
 }
 
-func (itr *_Tag__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
-	if itr.idx >= 5 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+func (itr *_Tag__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
+advance:
+	if itr.idx >= 9 {
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -5621,10 +8999,42 @@ func (itr *_Tag__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 		v = itr.n.tag.Representation()
 	case 3:
 		k = &fieldName__Tag_Tagger_serial
-		v = itr.n.tagger.Representation()
+		if itr.n.tagger.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.tagger.v.Representation()
 	case 4:
 		k = &fieldName__Tag_Message_serial
 		v = itr.n.message.Representation()
+	case 5:
+		k = &fieldName__Tag_Signature_serial
+		if itr.n.signature.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.signature.v.Representation()
+	case 6:
+		k = &fieldName__Tag_Signatures_serial
+		if itr.n.signatures.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.signatures.v.Representation()
+	case 7:
+		k = &fieldName__Tag_Other_serial
+		if itr.n.other.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.other.v.Representation()
+	case 8:
+		k = &fieldName__Tag_HeaderOrder_serial
+		if itr.n.headerOrder.m == schema.Maybe_Absent {
+			itr.idx++
+			goto advance
+		}
+		v = itr.n.headerOrder.v.Representation()
 	default:
 		panic("unreachable")
 	}
@@ -5632,13 +9042,28 @@ func (itr *_Tag__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 	return
 }
 func (itr *_Tag__ReprMapItr) Done() bool {
-	return itr.idx >= 5
+	return itr.idx >= itr.end
 }
-func (_Tag__Repr) ListIterator() ipld.ListIterator {
+func (_Tag__Repr) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (rn *_Tag__Repr) Length() int64 {
-	l := 5
+	l := 9
+	if rn.tagger.m == schema.Maybe_Absent {
+		l--
+	}
+	if rn.signature.m == schema.Maybe_Absent {
+		l--
+	}
+	if rn.signatures.m == schema.Maybe_Absent {
+		l--
+	}
+	if rn.other.m == schema.Maybe_Absent {
+		l--
+	}
+	if rn.headerOrder.m == schema.Maybe_Absent {
+		l--
+	}
 	return int64(l)
 }
 func (_Tag__Repr) IsAbsent() bool {
@@ -5662,16 +9087,16 @@ func (_Tag__Repr) AsString() (string, error) {
 func (_Tag__Repr) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag.Repr"}.AsBytes()
 }
-func (_Tag__Repr) AsLink() (ipld.Link, error) {
+func (_Tag__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Tag.Repr"}.AsLink()
 }
-func (_Tag__Repr) Prototype() ipld.NodePrototype {
+func (_Tag__Repr) Prototype() datamodel.NodePrototype {
 	return _Tag__ReprPrototype{}
 }
 
 type _Tag__ReprPrototype struct{}
 
-func (_Tag__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_Tag__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tag__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -5681,7 +9106,7 @@ type _Tag__ReprBuilder struct {
 	_Tag__ReprAssembler
 }
 
-func (nb *_Tag__ReprBuilder) Build() ipld.Node {
+func (nb *_Tag__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -5700,12 +9125,16 @@ type _Tag__ReprAssembler struct {
 	s     int
 	f     int
 
-	cm         schema.Maybe
-	ca_object  _Link__ReprAssembler
-	ca_typ     _String__ReprAssembler
-	ca_tag     _String__ReprAssembler
-	ca_tagger  _PersonInfo__ReprAssembler
-	ca_message _String__ReprAssembler
+	cm             schema.Maybe
+	ca_object      _Link__ReprAssembler
+	ca_typ         _String__ReprAssembler
+	ca_tag         _String__ReprAssembler
+	ca_tagger      _PersonInfo__ReprAssembler
+	ca_message     _String__ReprAssembler
+	ca_signature   _String__ReprAssembler
+	ca_signatures  _Signature_List__ReprAssembler
+	ca_other       _Header_List__ReprAssembler
+	ca_headerOrder _String_List__ReprAssembler
 }
 
 func (na *_Tag__ReprAssembler) reset() {
@@ -5716,8 +9145,12 @@ func (na *_Tag__ReprAssembler) reset() {
 	na.ca_tag.reset()
 	na.ca_tagger.reset()
 	na.ca_message.reset()
+	na.ca_signature.reset()
+	na.ca_signatures.reset()
+	na.ca_other.reset()
+	na.ca_headerOrder.reset()
 }
-func (na *_Tag__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_Tag__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -5730,7 +9163,7 @@ func (na *_Tag__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	return na, nil
 }
-func (_Tag__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tag__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag.Repr"}.BeginList(0)
 }
 func (na *_Tag__ReprAssembler) AssignNull() error {
@@ -5762,10 +9195,10 @@ func (_Tag__ReprAssembler) AssignString(string) error {
 func (_Tag__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag.Repr"}.AssignBytes(nil)
 }
-func (_Tag__ReprAssembler) AssignLink(ipld.Link) error {
+func (_Tag__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tag.Repr"}.AssignLink(nil)
 }
-func (na *_Tag__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Tag__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -5785,8 +9218,8 @@ func (na *_Tag__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tag.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tag.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -5803,7 +9236,7 @@ func (na *_Tag__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tag__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_Tag__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _Tag__ReprPrototype{}
 }
 func (ma *_Tag__ReprAssembler) valueFinishTidy() bool {
@@ -5836,9 +9269,9 @@ func (ma *_Tag__ReprAssembler) valueFinishTidy() bool {
 			return false
 		}
 	case 3:
-		switch ma.cm {
+		switch ma.w.tagger.m {
 		case schema.Maybe_Value:
-			ma.cm = schema.Maybe_Absent
+			ma.w.tagger.v = ma.ca_tagger.w
 			ma.state = maState_initial
 			return true
 		default:
@@ -5853,11 +9286,43 @@ func (ma *_Tag__ReprAssembler) valueFinishTidy() bool {
 		default:
 			return false
 		}
+	case 5:
+		switch ma.w.signature.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 6:
+		switch ma.w.signatures.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 7:
+		switch ma.w.other.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
+	case 8:
+		switch ma.w.headerOrder.m {
+		case schema.Maybe_Value:
+			ma.state = maState_initial
+			return true
+		default:
+			return false
+		}
 	default:
 		panic("unreachable")
 	}
 }
-func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -5875,7 +9340,7 @@ func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 	switch k {
 	case "object":
 		if ma.s&fieldBit__Tag_Object != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Object_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Object_serial}
 		}
 		ma.s += fieldBit__Tag_Object
 		ma.state = maState_midValue
@@ -5885,7 +9350,7 @@ func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 		return &ma.ca_object, nil
 	case "type":
 		if ma.s&fieldBit__Tag_Type != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Type_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Type_serial}
 		}
 		ma.s += fieldBit__Tag_Type
 		ma.state = maState_midValue
@@ -5895,7 +9360,7 @@ func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 		return &ma.ca_typ, nil
 	case "tag":
 		if ma.s&fieldBit__Tag_Tag != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag_serial}
 		}
 		ma.s += fieldBit__Tag_Tag
 		ma.state = maState_midValue
@@ -5905,17 +9370,18 @@ func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 		return &ma.ca_tag, nil
 	case "tagger":
 		if ma.s&fieldBit__Tag_Tagger != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger_serial}
 		}
 		ma.s += fieldBit__Tag_Tagger
 		ma.state = maState_midValue
 		ma.f = 3
-		ma.ca_tagger.w = &ma.w.tagger
-		ma.ca_tagger.m = &ma.cm
+		ma.ca_tagger.w = ma.w.tagger.v
+		ma.ca_tagger.m = &ma.w.tagger.m
+
 		return &ma.ca_tagger, nil
 	case "message":
 		if ma.s&fieldBit__Tag_Message != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Message_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Message_serial}
 		}
 		ma.s += fieldBit__Tag_Message
 		ma.state = maState_midValue
@@ -5923,11 +9389,55 @@ func (ma *_Tag__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, erro
 		ma.ca_message.w = &ma.w.message
 		ma.ca_message.m = &ma.cm
 		return &ma.ca_message, nil
+	case "signature":
+		if ma.s&fieldBit__Tag_Signature != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signature_serial}
+		}
+		ma.s += fieldBit__Tag_Signature
+		ma.state = maState_midValue
+		ma.f = 5
+		ma.ca_signature.w = &ma.w.signature.v
+		ma.ca_signature.m = &ma.w.signature.m
+
+		return &ma.ca_signature, nil
+	case "signatures":
+		if ma.s&fieldBit__Tag_Signatures != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signatures_serial}
+		}
+		ma.s += fieldBit__Tag_Signatures
+		ma.state = maState_midValue
+		ma.f = 6
+		ma.ca_signatures.w = &ma.w.signatures.v
+		ma.ca_signatures.m = &ma.w.signatures.m
+
+		return &ma.ca_signatures, nil
+	case "other":
+		if ma.s&fieldBit__Tag_Other != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Other_serial}
+		}
+		ma.s += fieldBit__Tag_Other
+		ma.state = maState_midValue
+		ma.f = 7
+		ma.ca_other.w = &ma.w.other.v
+		ma.ca_other.m = &ma.w.other.m
+
+		return &ma.ca_other, nil
+	case "headerOrder":
+		if ma.s&fieldBit__Tag_HeaderOrder != 0 {
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_HeaderOrder_serial}
+		}
+		ma.s += fieldBit__Tag_HeaderOrder
+		ma.state = maState_midValue
+		ma.f = 8
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+
+		return &ma.ca_headerOrder, nil
 	default:
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.Tag.Repr", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.Tag.Repr", Key: &_String{k}}
 }
-func (ma *_Tag__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Tag__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -5945,7 +9455,7 @@ func (ma *_Tag__ReprAssembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_Tag__ReprKeyAssembler)(ma)
 }
-func (ma *_Tag__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Tag__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -5973,13 +9483,34 @@ func (ma *_Tag__ReprAssembler) AssembleValue() ipld.NodeAssembler {
 		ma.ca_tag.m = &ma.cm
 		return &ma.ca_tag
 	case 3:
-		ma.ca_tagger.w = &ma.w.tagger
-		ma.ca_tagger.m = &ma.cm
+		ma.ca_tagger.w = ma.w.tagger.v
+		ma.ca_tagger.m = &ma.w.tagger.m
+
 		return &ma.ca_tagger
 	case 4:
 		ma.ca_message.w = &ma.w.message
 		ma.ca_message.m = &ma.cm
 		return &ma.ca_message
+	case 5:
+		ma.ca_signature.w = &ma.w.signature.v
+		ma.ca_signature.m = &ma.w.signature.m
+
+		return &ma.ca_signature
+	case 6:
+		ma.ca_signatures.w = &ma.w.signatures.v
+		ma.ca_signatures.m = &ma.w.signatures.m
+
+		return &ma.ca_signatures
+	case 7:
+		ma.ca_other.w = &ma.w.other.v
+		ma.ca_other.m = &ma.w.other.m
+
+		return &ma.ca_other
+	case 8:
+		ma.ca_headerOrder.w = &ma.w.headerOrder.v
+		ma.ca_headerOrder.m = &ma.w.headerOrder.m
+
+		return &ma.ca_headerOrder
 	default:
 		panic("unreachable")
 	}
@@ -6000,7 +9531,7 @@ func (ma *_Tag__ReprAssembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__Tag_sufficient != fieldBits__Tag_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__Tag_Object == 0 {
 			err.Missing = append(err.Missing, "object")
 		}
@@ -6010,9 +9541,6 @@ func (ma *_Tag__ReprAssembler) Finish() error {
 		if ma.s&fieldBit__Tag_Tag == 0 {
 			err.Missing = append(err.Missing, "tag")
 		}
-		if ma.s&fieldBit__Tag_Tagger == 0 {
-			err.Missing = append(err.Missing, "tagger")
-		}
 		if ma.s&fieldBit__Tag_Message == 0 {
 			err.Missing = append(err.Missing, "message")
 		}
@@ -6022,19 +9550,19 @@ func (ma *_Tag__ReprAssembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Tag__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Tag__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_Tag__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_Tag__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler repr valueprototype")
 }
 
 type _Tag__ReprKeyAssembler _Tag__ReprAssembler
 
-func (_Tag__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Tag__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.Repr.KeyAssembler"}.BeginMap(0)
 }
-func (_Tag__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tag__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.Repr.KeyAssembler"}.BeginList(0)
 }
 func (na *_Tag__ReprKeyAssembler) AssignNull() error {
@@ -6056,7 +9584,7 @@ func (ka *_Tag__ReprKeyAssembler) AssignString(k string) error {
 	switch k {
 	case "object":
 		if ka.s&fieldBit__Tag_Object != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Object_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Object_serial}
 		}
 		ka.s += fieldBit__Tag_Object
 		ka.state = maState_expectValue
@@ -6064,7 +9592,7 @@ func (ka *_Tag__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "type":
 		if ka.s&fieldBit__Tag_Type != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Type_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Type_serial}
 		}
 		ka.s += fieldBit__Tag_Type
 		ka.state = maState_expectValue
@@ -6072,7 +9600,7 @@ func (ka *_Tag__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "tag":
 		if ka.s&fieldBit__Tag_Tag != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tag_serial}
 		}
 		ka.s += fieldBit__Tag_Tag
 		ka.state = maState_expectValue
@@ -6080,7 +9608,7 @@ func (ka *_Tag__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "tagger":
 		if ka.s&fieldBit__Tag_Tagger != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Tagger_serial}
 		}
 		ka.s += fieldBit__Tag_Tagger
 		ka.state = maState_expectValue
@@ -6088,32 +9616,272 @@ func (ka *_Tag__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "message":
 		if ka.s&fieldBit__Tag_Message != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__Tag_Message_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Message_serial}
 		}
 		ka.s += fieldBit__Tag_Message
 		ka.state = maState_expectValue
 		ka.f = 4
 		return nil
+	case "signature":
+		if ka.s&fieldBit__Tag_Signature != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signature_serial}
+		}
+		ka.s += fieldBit__Tag_Signature
+		ka.state = maState_expectValue
+		ka.f = 5
+		return nil
+	case "signatures":
+		if ka.s&fieldBit__Tag_Signatures != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Signatures_serial}
+		}
+		ka.s += fieldBit__Tag_Signatures
+		ka.state = maState_expectValue
+		ka.f = 6
+		return nil
+	case "other":
+		if ka.s&fieldBit__Tag_Other != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_Other_serial}
+		}
+		ka.s += fieldBit__Tag_Other
+		ka.state = maState_expectValue
+		ka.f = 7
+		return nil
+	case "headerOrder":
+		if ka.s&fieldBit__Tag_HeaderOrder != 0 {
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__Tag_HeaderOrder_serial}
+		}
+		ka.s += fieldBit__Tag_HeaderOrder
+		ka.state = maState_expectValue
+		ka.f = 8
+		return nil
 	}
-	return ipld.ErrInvalidKey{TypeName: "ipldgit.Tag.Repr", Key: &_String{k}}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.Tag.Repr", Key: &_String{k}}
 }
 func (_Tag__ReprKeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.Repr.KeyAssembler"}.AssignBytes(nil)
 }
-func (_Tag__ReprKeyAssembler) AssignLink(ipld.Link) error {
+func (_Tag__ReprKeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.Tag.Repr.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_Tag__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_Tag__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_Tag__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+func (_Tag__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 
+func (n Tag_Link) Link() datamodel.Link {
+	return n.x
+}
+func (_Tag_Link__Prototype) FromLink(v datamodel.Link) (Tag_Link, error) {
+	n := _Tag_Link{v}
+	return &n, nil
+}
+
+type _Tag_Link__Maybe struct {
+	m schema.Maybe
+	v _Tag_Link
+}
+type MaybeTag_Link = *_Tag_Link__Maybe
+
+func (m MaybeTag_Link) IsNull() bool {
+	return m.m == schema.Maybe_Null
+}
+func (m MaybeTag_Link) IsAbsent() bool {
+	return m.m == schema.Maybe_Absent
+}
+func (m MaybeTag_Link) Exists() bool {
+	return m.m == schema.Maybe_Value
+}
+func (m MaybeTag_Link) AsNode() datamodel.Node {
+	switch m.m {
+	case schema.Maybe_Absent:
+		return datamodel.Absent
+	case schema.Maybe_Null:
+		return datamodel.Null
+	case schema.Maybe_Value:
+		return &m.v
+	default:
+		panic("unreachable")
+	}
+}
+func (m MaybeTag_Link) Must() Tag_Link {
+	if !m.Exists() {
+		panic("unbox of a maybe rejected")
+	}
+	return &m.v
+}
+
+var _ datamodel.Node = (Tag_Link)(&_Tag_Link{})
+var _ schema.TypedNode = (Tag_Link)(&_Tag_Link{})
+
+func (Tag_Link) Kind() datamodel.Kind {
+	return datamodel.Kind_Link
+}
+func (Tag_Link) LookupByString(string) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.LookupByString("")
+}
+func (Tag_Link) LookupByNode(datamodel.Node) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.LookupByNode(nil)
+}
+func (Tag_Link) LookupByIndex(idx int64) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.LookupByIndex(0)
+}
+func (Tag_Link) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.LookupBySegment(seg)
+}
+func (Tag_Link) MapIterator() datamodel.MapIterator {
+	return nil
+}
+func (Tag_Link) ListIterator() datamodel.ListIterator {
+	return nil
+}
+func (Tag_Link) Length() int64 {
+	return -1
+}
+func (Tag_Link) IsAbsent() bool {
+	return false
+}
+func (Tag_Link) IsNull() bool {
+	return false
+}
+func (Tag_Link) AsBool() (bool, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.AsBool()
+}
+func (Tag_Link) AsInt() (int64, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.AsInt()
+}
+func (Tag_Link) AsFloat() (float64, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.AsFloat()
+}
+func (Tag_Link) AsString() (string, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.AsString()
+}
+func (Tag_Link) AsBytes() ([]byte, error) {
+	return mixins.Link{TypeName: "ipldgit.Tag_Link"}.AsBytes()
+}
+func (n Tag_Link) AsLink() (datamodel.Link, error) {
+	return n.x, nil
+}
+func (Tag_Link) Prototype() datamodel.NodePrototype {
+	return _Tag_Link__Prototype{}
+}
+
+type _Tag_Link__Prototype struct{}
+
+func (_Tag_Link__Prototype) NewBuilder() datamodel.NodeBuilder {
+	var nb _Tag_Link__Builder
+	nb.Reset()
+	return &nb
+}
+
+type _Tag_Link__Builder struct {
+	_Tag_Link__Assembler
+}
+
+func (nb *_Tag_Link__Builder) Build() datamodel.Node {
+	if *nb.m != schema.Maybe_Value {
+		panic("invalid state: cannot call Build on an assembler that's not finished")
+	}
+	return nb.w
+}
+func (nb *_Tag_Link__Builder) Reset() {
+	var w _Tag_Link
+	var m schema.Maybe
+	*nb = _Tag_Link__Builder{_Tag_Link__Assembler{w: &w, m: &m}}
+}
+
+type _Tag_Link__Assembler struct {
+	w *_Tag_Link
+	m *schema.Maybe
+}
+
+func (na *_Tag_Link__Assembler) reset() {}
+func (_Tag_Link__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.BeginMap(0)
+}
+func (_Tag_Link__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.BeginList(0)
+}
+func (na *_Tag_Link__Assembler) AssignNull() error {
+	switch *na.m {
+	case allowNull:
+		*na.m = schema.Maybe_Null
+		return nil
+	case schema.Maybe_Absent:
+		return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignNull()
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	panic("unreachable")
+}
+func (_Tag_Link__Assembler) AssignBool(bool) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignBool(false)
+}
+func (_Tag_Link__Assembler) AssignInt(int64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignInt(0)
+}
+func (_Tag_Link__Assembler) AssignFloat(float64) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignFloat(0)
+}
+func (_Tag_Link__Assembler) AssignString(string) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignString("")
+}
+func (_Tag_Link__Assembler) AssignBytes([]byte) error {
+	return mixins.LinkAssembler{TypeName: "ipldgit.Tag_Link"}.AssignBytes(nil)
+}
+func (na *_Tag_Link__Assembler) AssignLink(v datamodel.Link) error {
+	switch *na.m {
+	case schema.Maybe_Value, schema.Maybe_Null:
+		panic("invalid state: cannot assign into assembler that's already finished")
+	}
+	na.w.x = v
+	*na.m = schema.Maybe_Value
+	return nil
+}
+func (na *_Tag_Link__Assembler) AssignNode(v datamodel.Node) error {
+	if v.IsNull() {
+		return na.AssignNull()
+	}
+	if v2, ok := v.(*_Tag_Link); ok {
+		switch *na.m {
+		case schema.Maybe_Value, schema.Maybe_Null:
+			panic("invalid state: cannot assign into assembler that's already finished")
+		}
+		*na.w = *v2
+		*na.m = schema.Maybe_Value
+		return nil
+	}
+	if v2, err := v.AsLink(); err != nil {
+		return err
+	} else {
+		return na.AssignLink(v2)
+	}
+}
+func (_Tag_Link__Assembler) Prototype() datamodel.NodePrototype {
+	return _Tag_Link__Prototype{}
+}
+func (Tag_Link) Type() schema.Type {
+	return nil /*TODO:typelit*/
+}
+func (Tag_Link) LinkTargetNodePrototype() datamodel.NodePrototype {
+	return Type.Tag__Repr
+}
+func (n Tag_Link) Representation() datamodel.Node {
+	return (*_Tag_Link__Repr)(n)
+}
+
+type _Tag_Link__Repr = _Tag_Link
+
+var _ datamodel.Node = &_Tag_Link__Repr{}
+
+type _Tag_Link__ReprPrototype = _Tag_Link__Prototype
+type _Tag_Link__ReprAssembler = _Tag_Link__Assembler
+
 func (n *_Tag_List) Lookup(idx int64) Tag {
 	if n.Length() <= idx {
 		return nil
@@ -6171,12 +9939,12 @@ func (m MaybeTag_List) IsAbsent() bool {
 func (m MaybeTag_List) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeTag_List) AsNode() ipld.Node {
+func (m MaybeTag_List) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -6190,40 +9958,40 @@ func (m MaybeTag_List) Must() Tag_List {
 	return &m.v
 }
 
-var _ ipld.Node = (Tag_List)(&_Tag_List{})
+var _ datamodel.Node = (Tag_List)(&_Tag_List{})
 var _ schema.TypedNode = (Tag_List)(&_Tag_List{})
 
-func (Tag_List) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (Tag_List) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (Tag_List) LookupByString(string) (ipld.Node, error) {
+func (Tag_List) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List"}.LookupByString("")
 }
-func (n Tag_List) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (n Tag_List) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	idx, err := k.AsInt()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByIndex(idx)
 }
-func (n Tag_List) LookupByIndex(idx int64) (ipld.Node, error) {
+func (n Tag_List) LookupByIndex(idx int64) (datamodel.Node, error) {
 	if n.Length() <= idx {
-		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfInt(idx)}
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfInt(idx)}
 	}
 	v := &n.x[idx]
 	return v, nil
 }
-func (n Tag_List) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n Tag_List) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.Tag_List", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Tag_List", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (Tag_List) MapIterator() ipld.MapIterator {
+func (Tag_List) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (n Tag_List) ListIterator() ipld.ListIterator {
+func (n Tag_List) ListIterator() datamodel.ListIterator {
 	return &_Tag_List__ListItr{n, 0}
 }
 
@@ -6232,9 +10000,9 @@ type _Tag_List__ListItr struct {
 	idx int
 }
 
-func (itr *_Tag_List__ListItr) Next() (idx int64, v ipld.Node, _ error) {
+func (itr *_Tag_List__ListItr) Next() (idx int64, v datamodel.Node, _ error) {
 	if itr.idx >= len(itr.n.x) {
-		return -1, nil, ipld.ErrIteratorOverread{}
+		return -1, nil, datamodel.ErrIteratorOverread{}
 	}
 	idx = int64(itr.idx)
 	x := &itr.n.x[itr.idx]
@@ -6270,16 +10038,16 @@ func (Tag_List) AsString() (string, error) {
 func (Tag_List) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List"}.AsBytes()
 }
-func (Tag_List) AsLink() (ipld.Link, error) {
+func (Tag_List) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List"}.AsLink()
 }
-func (Tag_List) Prototype() ipld.NodePrototype {
+func (Tag_List) Prototype() datamodel.NodePrototype {
 	return _Tag_List__Prototype{}
 }
 
 type _Tag_List__Prototype struct{}
 
-func (_Tag_List__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Tag_List__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tag_List__Builder
 	nb.Reset()
 	return &nb
@@ -6289,7 +10057,7 @@ type _Tag_List__Builder struct {
 	_Tag_List__Assembler
 }
 
-func (nb *_Tag_List__Builder) Build() ipld.Node {
+func (nb *_Tag_List__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -6314,10 +10082,10 @@ func (na *_Tag_List__Assembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_Tag_List__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Tag_List__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List"}.BeginMap(0)
 }
-func (na *_Tag_List__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_Tag_List__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -6362,10 +10130,10 @@ func (_Tag_List__Assembler) AssignString(string) error {
 func (_Tag_List__Assembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List"}.AssignBytes(nil)
 }
-func (_Tag_List__Assembler) AssignLink(ipld.Link) error {
+func (_Tag_List__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List"}.AssignLink(nil)
 }
-func (na *_Tag_List__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Tag_List__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -6380,8 +10148,8 @@ func (na *_Tag_List__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tag_List", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tag_List", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -6395,7 +10163,7 @@ func (na *_Tag_List__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tag_List__Assembler) Prototype() ipld.NodePrototype {
+func (_Tag_List__Assembler) Prototype() datamodel.NodePrototype {
 	return _Tag_List__Prototype{}
 }
 func (la *_Tag_List__Assembler) valueFinishTidy() bool {
@@ -6410,7 +10178,7 @@ func (la *_Tag_List__Assembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_Tag_List__Assembler) AssembleValue() ipld.NodeAssembler {
+func (la *_Tag_List__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -6443,59 +10211,59 @@ func (la *_Tag_List__Assembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_Tag_List__Assembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_Tag_List__Assembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _Tag__Prototype{}
 }
 func (Tag_List) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Tag_List) Representation() ipld.Node {
+func (n Tag_List) Representation() datamodel.Node {
 	return (*_Tag_List__Repr)(n)
 }
 
 type _Tag_List__Repr _Tag_List
 
-var _ ipld.Node = &_Tag_List__Repr{}
+var _ datamodel.Node = &_Tag_List__Repr{}
 
-func (_Tag_List__Repr) Kind() ipld.Kind {
-	return ipld.Kind_List
+func (_Tag_List__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_List
 }
-func (_Tag_List__Repr) LookupByString(string) (ipld.Node, error) {
+func (_Tag_List__Repr) LookupByString(string) (datamodel.Node, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List.Repr"}.LookupByString("")
 }
-func (nr *_Tag_List__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (nr *_Tag_List__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	v, err := (Tag_List)(nr).LookupByNode(k)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(Tag).Representation(), nil
 }
-func (nr *_Tag_List__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (nr *_Tag_List__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	v, err := (Tag_List)(nr).LookupByIndex(idx)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(Tag).Representation(), nil
 }
-func (n _Tag_List__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Tag_List__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	i, err := seg.Index()
 	if err != nil {
-		return nil, ipld.ErrInvalidSegmentForList{TypeName: "ipldgit.Tag_List.Repr", TroubleSegment: seg, Reason: err}
+		return nil, datamodel.ErrInvalidSegmentForList{TypeName: "ipldgit.Tag_List.Repr", TroubleSegment: seg, Reason: err}
 	}
 	return n.LookupByIndex(i)
 }
-func (_Tag_List__Repr) MapIterator() ipld.MapIterator {
+func (_Tag_List__Repr) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (nr *_Tag_List__Repr) ListIterator() ipld.ListIterator {
+func (nr *_Tag_List__Repr) ListIterator() datamodel.ListIterator {
 	return &_Tag_List__ReprListItr{(Tag_List)(nr), 0}
 }
 
 type _Tag_List__ReprListItr _Tag_List__ListItr
 
-func (itr *_Tag_List__ReprListItr) Next() (idx int64, v ipld.Node, err error) {
+func (itr *_Tag_List__ReprListItr) Next() (idx int64, v datamodel.Node, err error) {
 	idx, v, err = (*_Tag_List__ListItr)(itr).Next()
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return
 	}
 	return idx, v.(Tag).Representation(), nil
@@ -6528,16 +10296,16 @@ func (_Tag_List__Repr) AsString() (string, error) {
 func (_Tag_List__Repr) AsBytes() ([]byte, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List.Repr"}.AsBytes()
 }
-func (_Tag_List__Repr) AsLink() (ipld.Link, error) {
+func (_Tag_List__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.List{TypeName: "ipldgit.Tag_List.Repr"}.AsLink()
 }
-func (_Tag_List__Repr) Prototype() ipld.NodePrototype {
+func (_Tag_List__Repr) Prototype() datamodel.NodePrototype {
 	return _Tag_List__ReprPrototype{}
 }
 
 type _Tag_List__ReprPrototype struct{}
 
-func (_Tag_List__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_Tag_List__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tag_List__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -6547,7 +10315,7 @@ type _Tag_List__ReprBuilder struct {
 	_Tag_List__ReprAssembler
 }
 
-func (nb *_Tag_List__ReprBuilder) Build() ipld.Node {
+func (nb *_Tag_List__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -6572,10 +10340,10 @@ func (na *_Tag_List__ReprAssembler) reset() {
 	na.state = laState_initial
 	na.va.reset()
 }
-func (_Tag_List__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Tag_List__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List.Repr"}.BeginMap(0)
 }
-func (na *_Tag_List__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (na *_Tag_List__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -6620,10 +10388,10 @@ func (_Tag_List__ReprAssembler) AssignString(string) error {
 func (_Tag_List__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List.Repr"}.AssignBytes(nil)
 }
-func (_Tag_List__ReprAssembler) AssignLink(ipld.Link) error {
+func (_Tag_List__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.ListAssembler{TypeName: "ipldgit.Tag_List.Repr"}.AssignLink(nil)
 }
-func (na *_Tag_List__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Tag_List__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -6638,8 +10406,8 @@ func (na *_Tag_List__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_List {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tag_List.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustList, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_List {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tag_List.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustList, ActualKind: v.Kind()}
 	}
 	itr := v.ListIterator()
 	for !itr.Done() {
@@ -6653,7 +10421,7 @@ func (na *_Tag_List__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tag_List__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_Tag_List__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _Tag_List__ReprPrototype{}
 }
 func (la *_Tag_List__ReprAssembler) valueFinishTidy() bool {
@@ -6668,7 +10436,7 @@ func (la *_Tag_List__ReprAssembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (la *_Tag_List__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (la *_Tag_List__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch la.state {
 	case laState_initial:
 		// carry on
@@ -6701,7 +10469,7 @@ func (la *_Tag_List__ReprAssembler) Finish() error {
 	*la.m = schema.Maybe_Value
 	return nil
 }
-func (la *_Tag_List__ReprAssembler) ValuePrototype(_ int64) ipld.NodePrototype {
+func (la *_Tag_List__ReprAssembler) ValuePrototype(_ int64) datamodel.NodePrototype {
 	return _Tag__ReprPrototype{}
 }
 
@@ -6763,12 +10531,12 @@ func (m MaybeTree) IsAbsent() bool {
 func (m MaybeTree) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeTree) AsNode() ipld.Node {
+func (m MaybeTree) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -6782,42 +10550,42 @@ func (m MaybeTree) Must() Tree {
 	return &m.v
 }
 
-var _ ipld.Node = (Tree)(&_Tree{})
+var _ datamodel.Node = (Tree)(&_Tree{})
 var _ schema.TypedNode = (Tree)(&_Tree{})
 
-func (Tree) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (Tree) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n Tree) LookupByString(k string) (ipld.Node, error) {
+func (n Tree) LookupByString(k string) (datamodel.Node, error) {
 	var k2 _String
-	if err := (_String__Prototype{}).fromString(&k2, k); err != nil {
+	if err := (_String__ReprPrototype{}).fromString(&k2, k); err != nil {
 		return nil, err // TODO wrap in some kind of ErrInvalidKey
 	}
 	v, exists := n.m[k2]
 	if !exists {
-		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k)}
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(k)}
 	}
 	return v, nil
 }
-func (n Tree) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (n Tree) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	k2, ok := k.(String)
 	if !ok {
 		panic("todo invalid key type error")
-		// 'ipld.ErrInvalidKey{TypeName:"ipldgit.Tree", Key:&_String{k}}' doesn't quite cut it: need room to explain the type, and it's not guaranteed k can be turned into a string at all
+		// 'schema.ErrInvalidKey{TypeName:"ipldgit.Tree", Key:&_String{k}}' doesn't quite cut it: need room to explain the type, and it's not guaranteed k can be turned into a string at all
 	}
 	v, exists := n.m[*k2]
 	if !exists {
-		return nil, ipld.ErrNotExists{Segment: ipld.PathSegmentOfString(k2.String())}
+		return nil, datamodel.ErrNotExists{Segment: datamodel.PathSegmentOfString(k2.String())}
 	}
 	return v, nil
 }
-func (Tree) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Tree) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree"}.LookupByIndex(0)
 }
-func (n Tree) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n Tree) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n Tree) MapIterator() ipld.MapIterator {
+func (n Tree) MapIterator() datamodel.MapIterator {
 	return &_Tree__MapItr{n, 0}
 }
 
@@ -6826,9 +10594,9 @@ type _Tree__MapItr struct {
 	idx int
 }
 
-func (itr *_Tree__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+func (itr *_Tree__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
 	if itr.idx >= len(itr.n.t) {
-		return nil, nil, ipld.ErrIteratorOverread{}
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	x := &itr.n.t[itr.idx]
 	k = &x.k
@@ -6840,7 +10608,7 @@ func (itr *_Tree__MapItr) Done() bool {
 	return itr.idx >= len(itr.n.t)
 }
 
-func (Tree) ListIterator() ipld.ListIterator {
+func (Tree) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (n Tree) Length() int64 {
@@ -6867,16 +10635,16 @@ func (Tree) AsString() (string, error) {
 func (Tree) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree"}.AsBytes()
 }
-func (Tree) AsLink() (ipld.Link, error) {
+func (Tree) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree"}.AsLink()
 }
-func (Tree) Prototype() ipld.NodePrototype {
+func (Tree) Prototype() datamodel.NodePrototype {
 	return _Tree__Prototype{}
 }
 
 type _Tree__Prototype struct{}
 
-func (_Tree__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Tree__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tree__Builder
 	nb.Reset()
 	return &nb
@@ -6886,7 +10654,7 @@ type _Tree__Builder struct {
 	_Tree__Assembler
 }
 
-func (nb *_Tree__Builder) Build() ipld.Node {
+func (nb *_Tree__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -6913,7 +10681,7 @@ func (na *_Tree__Assembler) reset() {
 	na.ka.reset()
 	na.va.reset()
 }
-func (na *_Tree__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (na *_Tree__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -6928,7 +10696,7 @@ func (na *_Tree__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error)
 	na.w.t = make([]_Tree__entry, 0, sizeHint)
 	return na, nil
 }
-func (_Tree__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tree__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree"}.BeginList(0)
 }
 func (na *_Tree__Assembler) AssignNull() error {
@@ -6960,10 +10728,10 @@ func (_Tree__Assembler) AssignString(string) error {
 func (_Tree__Assembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree"}.AssignBytes(nil)
 }
-func (_Tree__Assembler) AssignLink(ipld.Link) error {
+func (_Tree__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree"}.AssignLink(nil)
 }
-func (na *_Tree__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Tree__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -6978,8 +10746,8 @@ func (na *_Tree__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tree", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tree", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -6996,7 +10764,7 @@ func (na *_Tree__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tree__Assembler) Prototype() ipld.NodePrototype {
+func (_Tree__Assembler) Prototype() datamodel.NodePrototype {
 	return _Tree__Prototype{}
 }
 func (ma *_Tree__Assembler) keyFinishTidy() bool {
@@ -7027,7 +10795,7 @@ func (ma *_Tree__Assembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (ma *_Tree__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Tree__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7044,11 +10812,11 @@ func (ma *_Tree__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error)
 	}
 
 	var k2 _String
-	if err := (_String__Prototype{}).fromString(&k2, k); err != nil {
+	if err := (_String__ReprPrototype{}).fromString(&k2, k); err != nil {
 		return nil, err // TODO wrap in some kind of ErrInvalidKey
 	}
 	if _, exists := ma.w.m[k2]; exists {
-		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+		return nil, datamodel.ErrRepeatedMapKey{Key: &k2}
 	}
 	ma.w.t = append(ma.w.t, _Tree__entry{k: k2})
 	tz := &ma.w.t[len(ma.w.t)-1]
@@ -7059,7 +10827,7 @@ func (ma *_Tree__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error)
 	ma.va.m = &ma.cm
 	return &ma.va, nil
 }
-func (ma *_Tree__Assembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Tree__Assembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7080,7 +10848,7 @@ func (ma *_Tree__Assembler) AssembleKey() ipld.NodeAssembler {
 	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
 	return &ma.ka
 }
-func (ma *_Tree__Assembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Tree__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -7117,55 +10885,55 @@ func (ma *_Tree__Assembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Tree__Assembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Tree__Assembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_Tree__Assembler) ValuePrototype(_ string) ipld.NodePrototype {
+func (ma *_Tree__Assembler) ValuePrototype(_ string) datamodel.NodePrototype {
 	return _TreeEntry__Prototype{}
 }
 func (Tree) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n Tree) Representation() ipld.Node {
+func (n Tree) Representation() datamodel.Node {
 	return (*_Tree__Repr)(n)
 }
 
 type _Tree__Repr _Tree
 
-var _ ipld.Node = &_Tree__Repr{}
+var _ datamodel.Node = &_Tree__Repr{}
 
-func (_Tree__Repr) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (_Tree__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (nr *_Tree__Repr) LookupByString(k string) (ipld.Node, error) {
+func (nr *_Tree__Repr) LookupByString(k string) (datamodel.Node, error) {
 	v, err := (Tree)(nr).LookupByString(k)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(TreeEntry).Representation(), nil
 }
-func (nr *_Tree__Repr) LookupByNode(k ipld.Node) (ipld.Node, error) {
+func (nr *_Tree__Repr) LookupByNode(k datamodel.Node) (datamodel.Node, error) {
 	v, err := (Tree)(nr).LookupByNode(k)
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return v, err
 	}
 	return v.(TreeEntry).Representation(), nil
 }
-func (_Tree__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (_Tree__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree.Repr"}.LookupByIndex(0)
 }
-func (n _Tree__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _Tree__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (nr *_Tree__Repr) MapIterator() ipld.MapIterator {
+func (nr *_Tree__Repr) MapIterator() datamodel.MapIterator {
 	return &_Tree__ReprMapItr{(Tree)(nr), 0}
 }
 
 type _Tree__ReprMapItr _Tree__MapItr
 
-func (itr *_Tree__ReprMapItr) Next() (k ipld.Node, v ipld.Node, err error) {
+func (itr *_Tree__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, err error) {
 	k, v, err = (*_Tree__MapItr)(itr).Next()
-	if err != nil || v == ipld.Null {
+	if err != nil || v == datamodel.Null {
 		return
 	}
 	return k, v.(TreeEntry).Representation(), nil
@@ -7174,7 +10942,7 @@ func (itr *_Tree__ReprMapItr) Done() bool {
 	return (*_Tree__MapItr)(itr).Done()
 }
 
-func (_Tree__Repr) ListIterator() ipld.ListIterator {
+func (_Tree__Repr) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (rn *_Tree__Repr) Length() int64 {
@@ -7201,16 +10969,16 @@ func (_Tree__Repr) AsString() (string, error) {
 func (_Tree__Repr) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree.Repr"}.AsBytes()
 }
-func (_Tree__Repr) AsLink() (ipld.Link, error) {
+func (_Tree__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.Tree.Repr"}.AsLink()
 }
-func (_Tree__Repr) Prototype() ipld.NodePrototype {
+func (_Tree__Repr) Prototype() datamodel.NodePrototype {
 	return _Tree__ReprPrototype{}
 }
 
 type _Tree__ReprPrototype struct{}
 
-func (_Tree__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_Tree__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tree__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -7220,7 +10988,7 @@ type _Tree__ReprBuilder struct {
 	_Tree__ReprAssembler
 }
 
-func (nb *_Tree__ReprBuilder) Build() ipld.Node {
+func (nb *_Tree__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -7247,7 +11015,7 @@ func (na *_Tree__ReprAssembler) reset() {
 	na.ka.reset()
 	na.va.reset()
 }
-func (na *_Tree__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (na *_Tree__ReprAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -7262,7 +11030,7 @@ func (na *_Tree__ReprAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, err
 	na.w.t = make([]_Tree__entry, 0, sizeHint)
 	return na, nil
 }
-func (_Tree__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tree__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree.Repr"}.BeginList(0)
 }
 func (na *_Tree__ReprAssembler) AssignNull() error {
@@ -7294,10 +11062,10 @@ func (_Tree__ReprAssembler) AssignString(string) error {
 func (_Tree__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree.Repr"}.AssignBytes(nil)
 }
-func (_Tree__ReprAssembler) AssignLink(ipld.Link) error {
+func (_Tree__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.Tree.Repr"}.AssignLink(nil)
 }
-func (na *_Tree__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_Tree__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -7312,8 +11080,8 @@ func (na *_Tree__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.Tree.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.Tree.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -7330,7 +11098,7 @@ func (na *_Tree__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_Tree__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_Tree__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _Tree__ReprPrototype{}
 }
 func (ma *_Tree__ReprAssembler) keyFinishTidy() bool {
@@ -7361,7 +11129,7 @@ func (ma *_Tree__ReprAssembler) valueFinishTidy() bool {
 		return false
 	}
 }
-func (ma *_Tree__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_Tree__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7382,7 +11150,7 @@ func (ma *_Tree__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, err
 		return nil, err // TODO wrap in some kind of ErrInvalidKey
 	}
 	if _, exists := ma.w.m[k2]; exists {
-		return nil, ipld.ErrRepeatedMapKey{Key: &k2}
+		return nil, datamodel.ErrRepeatedMapKey{Key: &k2}
 	}
 	ma.w.t = append(ma.w.t, _Tree__entry{k: k2})
 	tz := &ma.w.t[len(ma.w.t)-1]
@@ -7393,7 +11161,7 @@ func (ma *_Tree__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, err
 	ma.va.m = &ma.cm
 	return &ma.va, nil
 }
-func (ma *_Tree__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_Tree__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7414,7 +11182,7 @@ func (ma *_Tree__ReprAssembler) AssembleKey() ipld.NodeAssembler {
 	ma.ka.w = &ma.w.t[len(ma.w.t)-1].k
 	return &ma.ka
 }
-func (ma *_Tree__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_Tree__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -7451,10 +11219,10 @@ func (ma *_Tree__ReprAssembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_Tree__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_Tree__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__ReprPrototype{}
 }
-func (ma *_Tree__ReprAssembler) ValuePrototype(_ string) ipld.NodePrototype {
+func (ma *_Tree__ReprAssembler) ValuePrototype(_ string) datamodel.NodePrototype {
 	return _TreeEntry__ReprPrototype{}
 }
 
@@ -7480,12 +11248,12 @@ func (m MaybeTreeEntry) IsAbsent() bool {
 func (m MaybeTreeEntry) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeTreeEntry) AsNode() ipld.Node {
+func (m MaybeTreeEntry) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return m.v
 	default:
@@ -7503,36 +11271,36 @@ var (
 	fieldName__TreeEntry_Mode = _String{"mode"}
 	fieldName__TreeEntry_Hash = _String{"hash"}
 )
-var _ ipld.Node = (TreeEntry)(&_TreeEntry{})
+var _ datamodel.Node = (TreeEntry)(&_TreeEntry{})
 var _ schema.TypedNode = (TreeEntry)(&_TreeEntry{})
 
-func (TreeEntry) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (TreeEntry) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n TreeEntry) LookupByString(key string) (ipld.Node, error) {
+func (n TreeEntry) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "mode":
 		return &n.mode, nil
 	case "hash":
 		return &n.hash, nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n TreeEntry) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n TreeEntry) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (TreeEntry) LookupByIndex(idx int64) (ipld.Node, error) {
+func (TreeEntry) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry"}.LookupByIndex(0)
 }
-func (n TreeEntry) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n TreeEntry) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n TreeEntry) MapIterator() ipld.MapIterator {
+func (n TreeEntry) MapIterator() datamodel.MapIterator {
 	return &_TreeEntry__MapItr{n, 0}
 }
 
@@ -7541,9 +11309,9 @@ type _TreeEntry__MapItr struct {
 	idx int
 }
 
-func (itr *_TreeEntry__MapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+func (itr *_TreeEntry__MapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
 	if itr.idx >= 2 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -7562,7 +11330,7 @@ func (itr *_TreeEntry__MapItr) Done() bool {
 	return itr.idx >= 2
 }
 
-func (TreeEntry) ListIterator() ipld.ListIterator {
+func (TreeEntry) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (TreeEntry) Length() int64 {
@@ -7589,16 +11357,16 @@ func (TreeEntry) AsString() (string, error) {
 func (TreeEntry) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry"}.AsBytes()
 }
-func (TreeEntry) AsLink() (ipld.Link, error) {
+func (TreeEntry) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry"}.AsLink()
 }
-func (TreeEntry) Prototype() ipld.NodePrototype {
+func (TreeEntry) Prototype() datamodel.NodePrototype {
 	return _TreeEntry__Prototype{}
 }
 
 type _TreeEntry__Prototype struct{}
 
-func (_TreeEntry__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_TreeEntry__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _TreeEntry__Builder
 	nb.Reset()
 	return &nb
@@ -7608,7 +11376,7 @@ type _TreeEntry__Builder struct {
 	_TreeEntry__Assembler
 }
 
-func (nb *_TreeEntry__Builder) Build() ipld.Node {
+func (nb *_TreeEntry__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -7645,7 +11413,7 @@ var (
 	fieldBits__TreeEntry_sufficient = 0 + 1<<0 + 1<<1
 )
 
-func (na *_TreeEntry__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_TreeEntry__Assembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -7658,7 +11426,7 @@ func (na *_TreeEntry__Assembler) BeginMap(int64) (ipld.MapAssembler, error) {
 	}
 	return na, nil
 }
-func (_TreeEntry__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_TreeEntry__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry"}.BeginList(0)
 }
 func (na *_TreeEntry__Assembler) AssignNull() error {
@@ -7690,10 +11458,10 @@ func (_TreeEntry__Assembler) AssignString(string) error {
 func (_TreeEntry__Assembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry"}.AssignBytes(nil)
 }
-func (_TreeEntry__Assembler) AssignLink(ipld.Link) error {
+func (_TreeEntry__Assembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry"}.AssignLink(nil)
 }
-func (na *_TreeEntry__Assembler) AssignNode(v ipld.Node) error {
+func (na *_TreeEntry__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -7713,8 +11481,8 @@ func (na *_TreeEntry__Assembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.TreeEntry", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.TreeEntry", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -7731,7 +11499,7 @@ func (na *_TreeEntry__Assembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_TreeEntry__Assembler) Prototype() ipld.NodePrototype {
+func (_TreeEntry__Assembler) Prototype() datamodel.NodePrototype {
 	return _TreeEntry__Prototype{}
 }
 func (ma *_TreeEntry__Assembler) valueFinishTidy() bool {
@@ -7760,7 +11528,7 @@ func (ma *_TreeEntry__Assembler) valueFinishTidy() bool {
 		panic("unreachable")
 	}
 }
-func (ma *_TreeEntry__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_TreeEntry__Assembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7778,7 +11546,7 @@ func (ma *_TreeEntry__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, er
 	switch k {
 	case "mode":
 		if ma.s&fieldBit__TreeEntry_Mode != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode}
 		}
 		ma.s += fieldBit__TreeEntry_Mode
 		ma.state = maState_midValue
@@ -7788,7 +11556,7 @@ func (ma *_TreeEntry__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, er
 		return &ma.ca_mode, nil
 	case "hash":
 		if ma.s&fieldBit__TreeEntry_Hash != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash}
 		}
 		ma.s += fieldBit__TreeEntry_Hash
 		ma.state = maState_midValue
@@ -7797,9 +11565,9 @@ func (ma *_TreeEntry__Assembler) AssembleEntry(k string) (ipld.NodeAssembler, er
 		ma.ca_hash.m = &ma.cm
 		return &ma.ca_hash, nil
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.TreeEntry", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.TreeEntry", Key: &_String{k}}
 }
-func (ma *_TreeEntry__Assembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_TreeEntry__Assembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -7817,7 +11585,7 @@ func (ma *_TreeEntry__Assembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_TreeEntry__KeyAssembler)(ma)
 }
-func (ma *_TreeEntry__Assembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_TreeEntry__Assembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -7860,7 +11628,7 @@ func (ma *_TreeEntry__Assembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__TreeEntry_sufficient != fieldBits__TreeEntry_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__TreeEntry_Mode == 0 {
 			err.Missing = append(err.Missing, "mode")
 		}
@@ -7873,19 +11641,19 @@ func (ma *_TreeEntry__Assembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_TreeEntry__Assembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_TreeEntry__Assembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_TreeEntry__Assembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_TreeEntry__Assembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler valueprototype")
 }
 
 type _TreeEntry__KeyAssembler _TreeEntry__Assembler
 
-func (_TreeEntry__KeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_TreeEntry__KeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.KeyAssembler"}.BeginMap(0)
 }
-func (_TreeEntry__KeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_TreeEntry__KeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.KeyAssembler"}.BeginList(0)
 }
 func (na *_TreeEntry__KeyAssembler) AssignNull() error {
@@ -7907,7 +11675,7 @@ func (ka *_TreeEntry__KeyAssembler) AssignString(k string) error {
 	switch k {
 	case "mode":
 		if ka.s&fieldBit__TreeEntry_Mode != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode}
 		}
 		ka.s += fieldBit__TreeEntry_Mode
 		ka.state = maState_expectValue
@@ -7915,36 +11683,36 @@ func (ka *_TreeEntry__KeyAssembler) AssignString(k string) error {
 		return nil
 	case "hash":
 		if ka.s&fieldBit__TreeEntry_Hash != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash}
 		}
 		ka.s += fieldBit__TreeEntry_Hash
 		ka.state = maState_expectValue
 		ka.f = 1
 		return nil
 	default:
-		return ipld.ErrInvalidKey{TypeName: "ipldgit.TreeEntry", Key: &_String{k}}
+		return schema.ErrInvalidKey{TypeName: "ipldgit.TreeEntry", Key: &_String{k}}
 	}
 }
 func (_TreeEntry__KeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.KeyAssembler"}.AssignBytes(nil)
 }
-func (_TreeEntry__KeyAssembler) AssignLink(ipld.Link) error {
+func (_TreeEntry__KeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_TreeEntry__KeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_TreeEntry__KeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_TreeEntry__KeyAssembler) Prototype() ipld.NodePrototype {
+func (_TreeEntry__KeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 func (TreeEntry) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (n TreeEntry) Representation() ipld.Node {
+func (n TreeEntry) Representation() datamodel.Node {
 	return (*_TreeEntry__Repr)(n)
 }
 
@@ -7954,35 +11722,35 @@ var (
 	fieldName__TreeEntry_Mode_serial = _String{"mode"}
 	fieldName__TreeEntry_Hash_serial = _String{"hash"}
 )
-var _ ipld.Node = &_TreeEntry__Repr{}
+var _ datamodel.Node = &_TreeEntry__Repr{}
 
-func (_TreeEntry__Repr) Kind() ipld.Kind {
-	return ipld.Kind_Map
+func (_TreeEntry__Repr) Kind() datamodel.Kind {
+	return datamodel.Kind_Map
 }
-func (n *_TreeEntry__Repr) LookupByString(key string) (ipld.Node, error) {
+func (n *_TreeEntry__Repr) LookupByString(key string) (datamodel.Node, error) {
 	switch key {
 	case "mode":
 		return n.mode.Representation(), nil
 	case "hash":
 		return n.hash.Representation(), nil
 	default:
-		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: ipld.PathSegmentOfString(key)}
+		return nil, schema.ErrNoSuchField{Type: nil /*TODO*/, Field: datamodel.PathSegmentOfString(key)}
 	}
 }
-func (n *_TreeEntry__Repr) LookupByNode(key ipld.Node) (ipld.Node, error) {
+func (n *_TreeEntry__Repr) LookupByNode(key datamodel.Node) (datamodel.Node, error) {
 	ks, err := key.AsString()
 	if err != nil {
 		return nil, err
 	}
 	return n.LookupByString(ks)
 }
-func (_TreeEntry__Repr) LookupByIndex(idx int64) (ipld.Node, error) {
+func (_TreeEntry__Repr) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry.Repr"}.LookupByIndex(0)
 }
-func (n _TreeEntry__Repr) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (n _TreeEntry__Repr) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return n.LookupByString(seg.String())
 }
-func (n *_TreeEntry__Repr) MapIterator() ipld.MapIterator {
+func (n *_TreeEntry__Repr) MapIterator() datamodel.MapIterator {
 	return &_TreeEntry__ReprMapItr{n, 0}
 }
 
@@ -7991,9 +11759,9 @@ type _TreeEntry__ReprMapItr struct {
 	idx int
 }
 
-func (itr *_TreeEntry__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
+func (itr *_TreeEntry__ReprMapItr) Next() (k datamodel.Node, v datamodel.Node, _ error) {
 	if itr.idx >= 2 {
-		return nil, nil, ipld.ErrIteratorOverread{}
+		return nil, nil, datamodel.ErrIteratorOverread{}
 	}
 	switch itr.idx {
 	case 0:
@@ -8011,7 +11779,7 @@ func (itr *_TreeEntry__ReprMapItr) Next() (k ipld.Node, v ipld.Node, _ error) {
 func (itr *_TreeEntry__ReprMapItr) Done() bool {
 	return itr.idx >= 2
 }
-func (_TreeEntry__Repr) ListIterator() ipld.ListIterator {
+func (_TreeEntry__Repr) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (rn *_TreeEntry__Repr) Length() int64 {
@@ -8039,16 +11807,16 @@ func (_TreeEntry__Repr) AsString() (string, error) {
 func (_TreeEntry__Repr) AsBytes() ([]byte, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry.Repr"}.AsBytes()
 }
-func (_TreeEntry__Repr) AsLink() (ipld.Link, error) {
+func (_TreeEntry__Repr) AsLink() (datamodel.Link, error) {
 	return mixins.Map{TypeName: "ipldgit.TreeEntry.Repr"}.AsLink()
 }
-func (_TreeEntry__Repr) Prototype() ipld.NodePrototype {
+func (_TreeEntry__Repr) Prototype() datamodel.NodePrototype {
 	return _TreeEntry__ReprPrototype{}
 }
 
 type _TreeEntry__ReprPrototype struct{}
 
-func (_TreeEntry__ReprPrototype) NewBuilder() ipld.NodeBuilder {
+func (_TreeEntry__ReprPrototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _TreeEntry__ReprBuilder
 	nb.Reset()
 	return &nb
@@ -8058,7 +11826,7 @@ type _TreeEntry__ReprBuilder struct {
 	_TreeEntry__ReprAssembler
 }
 
-func (nb *_TreeEntry__ReprBuilder) Build() ipld.Node {
+func (nb *_TreeEntry__ReprBuilder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -8088,7 +11856,7 @@ func (na *_TreeEntry__ReprAssembler) reset() {
 	na.ca_mode.reset()
 	na.ca_hash.reset()
 }
-func (na *_TreeEntry__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error) {
+func (na *_TreeEntry__ReprAssembler) BeginMap(int64) (datamodel.MapAssembler, error) {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -8101,7 +11869,7 @@ func (na *_TreeEntry__ReprAssembler) BeginMap(int64) (ipld.MapAssembler, error)
 	}
 	return na, nil
 }
-func (_TreeEntry__ReprAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_TreeEntry__ReprAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry.Repr"}.BeginList(0)
 }
 func (na *_TreeEntry__ReprAssembler) AssignNull() error {
@@ -8133,10 +11901,10 @@ func (_TreeEntry__ReprAssembler) AssignString(string) error {
 func (_TreeEntry__ReprAssembler) AssignBytes([]byte) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry.Repr"}.AssignBytes(nil)
 }
-func (_TreeEntry__ReprAssembler) AssignLink(ipld.Link) error {
+func (_TreeEntry__ReprAssembler) AssignLink(datamodel.Link) error {
 	return mixins.MapAssembler{TypeName: "ipldgit.TreeEntry.Repr"}.AssignLink(nil)
 }
-func (na *_TreeEntry__ReprAssembler) AssignNode(v ipld.Node) error {
+func (na *_TreeEntry__ReprAssembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -8156,8 +11924,8 @@ func (na *_TreeEntry__ReprAssembler) AssignNode(v ipld.Node) error {
 		*na.m = schema.Maybe_Value
 		return nil
 	}
-	if v.Kind() != ipld.Kind_Map {
-		return ipld.ErrWrongKind{TypeName: "ipldgit.TreeEntry.Repr", MethodName: "AssignNode", AppropriateKind: ipld.KindSet_JustMap, ActualKind: v.Kind()}
+	if v.Kind() != datamodel.Kind_Map {
+		return datamodel.ErrWrongKind{TypeName: "ipldgit.TreeEntry.Repr", MethodName: "AssignNode", AppropriateKind: datamodel.KindSet_JustMap, ActualKind: v.Kind()}
 	}
 	itr := v.MapIterator()
 	for !itr.Done() {
@@ -8174,7 +11942,7 @@ func (na *_TreeEntry__ReprAssembler) AssignNode(v ipld.Node) error {
 	}
 	return na.Finish()
 }
-func (_TreeEntry__ReprAssembler) Prototype() ipld.NodePrototype {
+func (_TreeEntry__ReprAssembler) Prototype() datamodel.NodePrototype {
 	return _TreeEntry__ReprPrototype{}
 }
 func (ma *_TreeEntry__ReprAssembler) valueFinishTidy() bool {
@@ -8201,7 +11969,7 @@ func (ma *_TreeEntry__ReprAssembler) valueFinishTidy() bool {
 		panic("unreachable")
 	}
 }
-func (ma *_TreeEntry__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler, error) {
+func (ma *_TreeEntry__ReprAssembler) AssembleEntry(k string) (datamodel.NodeAssembler, error) {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -8219,7 +11987,7 @@ func (ma *_TreeEntry__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler
 	switch k {
 	case "mode":
 		if ma.s&fieldBit__TreeEntry_Mode != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode_serial}
 		}
 		ma.s += fieldBit__TreeEntry_Mode
 		ma.state = maState_midValue
@@ -8229,7 +11997,7 @@ func (ma *_TreeEntry__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler
 		return &ma.ca_mode, nil
 	case "hash":
 		if ma.s&fieldBit__TreeEntry_Hash != 0 {
-			return nil, ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash_serial}
+			return nil, datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash_serial}
 		}
 		ma.s += fieldBit__TreeEntry_Hash
 		ma.state = maState_midValue
@@ -8239,9 +12007,9 @@ func (ma *_TreeEntry__ReprAssembler) AssembleEntry(k string) (ipld.NodeAssembler
 		return &ma.ca_hash, nil
 	default:
 	}
-	return nil, ipld.ErrInvalidKey{TypeName: "ipldgit.TreeEntry.Repr", Key: &_String{k}}
+	return nil, schema.ErrInvalidKey{TypeName: "ipldgit.TreeEntry.Repr", Key: &_String{k}}
 }
-func (ma *_TreeEntry__ReprAssembler) AssembleKey() ipld.NodeAssembler {
+func (ma *_TreeEntry__ReprAssembler) AssembleKey() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		// carry on
@@ -8259,7 +12027,7 @@ func (ma *_TreeEntry__ReprAssembler) AssembleKey() ipld.NodeAssembler {
 	ma.state = maState_midKey
 	return (*_TreeEntry__ReprKeyAssembler)(ma)
 }
-func (ma *_TreeEntry__ReprAssembler) AssembleValue() ipld.NodeAssembler {
+func (ma *_TreeEntry__ReprAssembler) AssembleValue() datamodel.NodeAssembler {
 	switch ma.state {
 	case maState_initial:
 		panic("invalid state: AssembleValue cannot be called when no key is primed")
@@ -8302,7 +12070,7 @@ func (ma *_TreeEntry__ReprAssembler) Finish() error {
 		panic("invalid state: Finish cannot be called on an assembler that's already finished")
 	}
 	if ma.s&fieldBits__TreeEntry_sufficient != fieldBits__TreeEntry_sufficient {
-		err := ipld.ErrMissingRequiredField{Missing: make([]string, 0)}
+		err := schema.ErrMissingRequiredField{Missing: make([]string, 0)}
 		if ma.s&fieldBit__TreeEntry_Mode == 0 {
 			err.Missing = append(err.Missing, "mode")
 		}
@@ -8315,19 +12083,19 @@ func (ma *_TreeEntry__ReprAssembler) Finish() error {
 	*ma.m = schema.Maybe_Value
 	return nil
 }
-func (ma *_TreeEntry__ReprAssembler) KeyPrototype() ipld.NodePrototype {
+func (ma *_TreeEntry__ReprAssembler) KeyPrototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
-func (ma *_TreeEntry__ReprAssembler) ValuePrototype(k string) ipld.NodePrototype {
+func (ma *_TreeEntry__ReprAssembler) ValuePrototype(k string) datamodel.NodePrototype {
 	panic("todo structbuilder mapassembler repr valueprototype")
 }
 
 type _TreeEntry__ReprKeyAssembler _TreeEntry__ReprAssembler
 
-func (_TreeEntry__ReprKeyAssembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_TreeEntry__ReprKeyAssembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.Repr.KeyAssembler"}.BeginMap(0)
 }
-func (_TreeEntry__ReprKeyAssembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_TreeEntry__ReprKeyAssembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.Repr.KeyAssembler"}.BeginList(0)
 }
 func (na *_TreeEntry__ReprKeyAssembler) AssignNull() error {
@@ -8349,7 +12117,7 @@ func (ka *_TreeEntry__ReprKeyAssembler) AssignString(k string) error {
 	switch k {
 	case "mode":
 		if ka.s&fieldBit__TreeEntry_Mode != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Mode_serial}
 		}
 		ka.s += fieldBit__TreeEntry_Mode
 		ka.state = maState_expectValue
@@ -8357,36 +12125,36 @@ func (ka *_TreeEntry__ReprKeyAssembler) AssignString(k string) error {
 		return nil
 	case "hash":
 		if ka.s&fieldBit__TreeEntry_Hash != 0 {
-			return ipld.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash_serial}
+			return datamodel.ErrRepeatedMapKey{Key: &fieldName__TreeEntry_Hash_serial}
 		}
 		ka.s += fieldBit__TreeEntry_Hash
 		ka.state = maState_expectValue
 		ka.f = 1
 		return nil
 	}
-	return ipld.ErrInvalidKey{TypeName: "ipldgit.TreeEntry.Repr", Key: &_String{k}}
+	return schema.ErrInvalidKey{TypeName: "ipldgit.TreeEntry.Repr", Key: &_String{k}}
 }
 func (_TreeEntry__ReprKeyAssembler) AssignBytes([]byte) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.Repr.KeyAssembler"}.AssignBytes(nil)
 }
-func (_TreeEntry__ReprKeyAssembler) AssignLink(ipld.Link) error {
+func (_TreeEntry__ReprKeyAssembler) AssignLink(datamodel.Link) error {
 	return mixins.StringAssembler{TypeName: "ipldgit.TreeEntry.Repr.KeyAssembler"}.AssignLink(nil)
 }
-func (ka *_TreeEntry__ReprKeyAssembler) AssignNode(v ipld.Node) error {
+func (ka *_TreeEntry__ReprKeyAssembler) AssignNode(v datamodel.Node) error {
 	if v2, err := v.AsString(); err != nil {
 		return err
 	} else {
 		return ka.AssignString(v2)
 	}
 }
-func (_TreeEntry__ReprKeyAssembler) Prototype() ipld.NodePrototype {
+func (_TreeEntry__ReprKeyAssembler) Prototype() datamodel.NodePrototype {
 	return _String__Prototype{}
 }
 
-func (n Tree_Link) Link() ipld.Link {
+func (n Tree_Link) Link() datamodel.Link {
 	return n.x
 }
-func (_Tree_Link__Prototype) FromLink(v ipld.Link) (Tree_Link, error) {
+func (_Tree_Link__Prototype) FromLink(v datamodel.Link) (Tree_Link, error) {
 	n := _Tree_Link{v}
 	return &n, nil
 }
@@ -8406,12 +12174,12 @@ func (m MaybeTree_Link) IsAbsent() bool {
 func (m MaybeTree_Link) Exists() bool {
 	return m.m == schema.Maybe_Value
 }
-func (m MaybeTree_Link) AsNode() ipld.Node {
+func (m MaybeTree_Link) AsNode() datamodel.Node {
 	switch m.m {
 	case schema.Maybe_Absent:
-		return ipld.Absent
+		return datamodel.Absent
 	case schema.Maybe_Null:
-		return ipld.Null
+		return datamodel.Null
 	case schema.Maybe_Value:
 		return &m.v
 	default:
@@ -8425,28 +12193,28 @@ func (m MaybeTree_Link) Must() Tree_Link {
 	return &m.v
 }
 
-var _ ipld.Node = (Tree_Link)(&_Tree_Link{})
+var _ datamodel.Node = (Tree_Link)(&_Tree_Link{})
 var _ schema.TypedNode = (Tree_Link)(&_Tree_Link{})
 
-func (Tree_Link) Kind() ipld.Kind {
-	return ipld.Kind_Link
+func (Tree_Link) Kind() datamodel.Kind {
+	return datamodel.Kind_Link
 }
-func (Tree_Link) LookupByString(string) (ipld.Node, error) {
+func (Tree_Link) LookupByString(string) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Tree_Link"}.LookupByString("")
 }
-func (Tree_Link) LookupByNode(ipld.Node) (ipld.Node, error) {
+func (Tree_Link) LookupByNode(datamodel.Node) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Tree_Link"}.LookupByNode(nil)
 }
-func (Tree_Link) LookupByIndex(idx int64) (ipld.Node, error) {
+func (Tree_Link) LookupByIndex(idx int64) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Tree_Link"}.LookupByIndex(0)
 }
-func (Tree_Link) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+func (Tree_Link) LookupBySegment(seg datamodel.PathSegment) (datamodel.Node, error) {
 	return mixins.Link{TypeName: "ipldgit.Tree_Link"}.LookupBySegment(seg)
 }
-func (Tree_Link) MapIterator() ipld.MapIterator {
+func (Tree_Link) MapIterator() datamodel.MapIterator {
 	return nil
 }
-func (Tree_Link) ListIterator() ipld.ListIterator {
+func (Tree_Link) ListIterator() datamodel.ListIterator {
 	return nil
 }
 func (Tree_Link) Length() int64 {
@@ -8473,16 +12241,16 @@ func (Tree_Link) AsString() (string, error) {
 func (Tree_Link) AsBytes() ([]byte, error) {
 	return mixins.Link{TypeName: "ipldgit.Tree_Link"}.AsBytes()
 }
-func (n Tree_Link) AsLink() (ipld.Link, error) {
+func (n Tree_Link) AsLink() (datamodel.Link, error) {
 	return n.x, nil
 }
-func (Tree_Link) Prototype() ipld.NodePrototype {
+func (Tree_Link) Prototype() datamodel.NodePrototype {
 	return _Tree_Link__Prototype{}
 }
 
 type _Tree_Link__Prototype struct{}
 
-func (_Tree_Link__Prototype) NewBuilder() ipld.NodeBuilder {
+func (_Tree_Link__Prototype) NewBuilder() datamodel.NodeBuilder {
 	var nb _Tree_Link__Builder
 	nb.Reset()
 	return &nb
@@ -8492,7 +12260,7 @@ type _Tree_Link__Builder struct {
 	_Tree_Link__Assembler
 }
 
-func (nb *_Tree_Link__Builder) Build() ipld.Node {
+func (nb *_Tree_Link__Builder) Build() datamodel.Node {
 	if *nb.m != schema.Maybe_Value {
 		panic("invalid state: cannot call Build on an assembler that's not finished")
 	}
@@ -8510,10 +12278,10 @@ type _Tree_Link__Assembler struct {
 }
 
 func (na *_Tree_Link__Assembler) reset() {}
-func (_Tree_Link__Assembler) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+func (_Tree_Link__Assembler) BeginMap(sizeHint int64) (datamodel.MapAssembler, error) {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Tree_Link"}.BeginMap(0)
 }
-func (_Tree_Link__Assembler) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+func (_Tree_Link__Assembler) BeginList(sizeHint int64) (datamodel.ListAssembler, error) {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Tree_Link"}.BeginList(0)
 }
 func (na *_Tree_Link__Assembler) AssignNull() error {
@@ -8543,7 +12311,7 @@ func (_Tree_Link__Assembler) AssignString(string) error {
 func (_Tree_Link__Assembler) AssignBytes([]byte) error {
 	return mixins.LinkAssembler{TypeName: "ipldgit.Tree_Link"}.AssignBytes(nil)
 }
-func (na *_Tree_Link__Assembler) AssignLink(v ipld.Link) error {
+func (na *_Tree_Link__Assembler) AssignLink(v datamodel.Link) error {
 	switch *na.m {
 	case schema.Maybe_Value, schema.Maybe_Null:
 		panic("invalid state: cannot assign into assembler that's already finished")
@@ -8552,7 +12320,7 @@ func (na *_Tree_Link__Assembler) AssignLink(v ipld.Link) error {
 	*na.m = schema.Maybe_Value
 	return nil
 }
-func (na *_Tree_Link__Assembler) AssignNode(v ipld.Node) error {
+func (na *_Tree_Link__Assembler) AssignNode(v datamodel.Node) error {
 	if v.IsNull() {
 		return na.AssignNull()
 	}
@@ -8571,22 +12339,22 @@ func (na *_Tree_Link__Assembler) AssignNode(v ipld.Node) error {
 		return na.AssignLink(v2)
 	}
 }
-func (_Tree_Link__Assembler) Prototype() ipld.NodePrototype {
+func (_Tree_Link__Assembler) Prototype() datamodel.NodePrototype {
 	return _Tree_Link__Prototype{}
 }
 func (Tree_Link) Type() schema.Type {
 	return nil /*TODO:typelit*/
 }
-func (Tree_Link) LinkTargetNodePrototype() ipld.NodePrototype {
+func (Tree_Link) LinkTargetNodePrototype() datamodel.NodePrototype {
 	return Type.Tree__Repr
 }
-func (n Tree_Link) Representation() ipld.Node {
+func (n Tree_Link) Representation() datamodel.Node {
 	return (*_Tree_Link__Repr)(n)
 }
 
 type _Tree_Link__Repr = _Tree_Link
 
-var _ ipld.Node = &_Tree_Link__Repr{}
+var _ datamodel.Node = &_Tree_Link__Repr{}
 
 type _Tree_Link__ReprPrototype = _Tree_Link__Prototype
 type _Tree_Link__ReprAssembler = _Tree_Link__Assembler
@@ -3,11 +3,11 @@ package ipldgit
 // Code generated by go-ipld-prime gengo.  DO NOT EDIT.
 
 import (
-	ipld "github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/datamodel"
 )
 
-var _ ipld.Node = nil // suppress errors when this dependency is not referenced
-// Type is a struct embeding a NodePrototype/Type for every Node implementation in this package.
+var _ datamodel.Node = nil // suppress errors when this dependency is not referenced
+// Type is a struct embedding a NodePrototype/Type for every Node implementation in this package.
 // One of its major uses is to start the construction of a value.
 // You can use it like this:
 //
@@ -21,24 +21,34 @@ var Type typeSlab
 type typeSlab struct {
 	Blob                   _Blob__Prototype
 	Blob__Repr             _Blob__ReprPrototype
+	Blob_Link              _Blob_Link__Prototype
+	Blob_Link__Repr        _Blob_Link__ReprPrototype
 	Commit                 _Commit__Prototype
 	Commit__Repr           _Commit__ReprPrototype
 	Commit_Link            _Commit_Link__Prototype
 	Commit_Link__Repr      _Commit_Link__ReprPrototype
 	Commit_Link_List       _Commit_Link_List__Prototype
 	Commit_Link_List__Repr _Commit_Link_List__ReprPrototype
-	GpgSig                 _GpgSig__Prototype
-	GpgSig__Repr           _GpgSig__ReprPrototype
+	Header                 _Header__Prototype
+	Header__Repr           _Header__ReprPrototype
+	Header_List            _Header_List__Prototype
+	Header_List__Repr      _Header_List__ReprPrototype
 	Link                   _Link__Prototype
 	Link__Repr             _Link__ReprPrototype
 	PersonInfo             _PersonInfo__Prototype
 	PersonInfo__Repr       _PersonInfo__ReprPrototype
+	Signature              _Signature__Prototype
+	Signature__Repr        _Signature__ReprPrototype
+	Signature_List         _Signature_List__Prototype
+	Signature_List__Repr   _Signature_List__ReprPrototype
 	String                 _String__Prototype
 	String__Repr           _String__ReprPrototype
 	String_List            _String_List__Prototype
 	String_List__Repr      _String_List__ReprPrototype
 	Tag                    _Tag__Prototype
 	Tag__Repr              _Tag__ReprPrototype
+	Tag_Link               _Tag_Link__Prototype
+	Tag_Link__Repr         _Tag_Link__ReprPrototype
 	Tag_List               _Tag_List__Prototype
 	Tag_List__Repr         _Tag_List__ReprPrototype
 	Tree                   _Tree__Prototype
@@ -55,23 +65,28 @@ type typeSlab struct {
 type Blob = *_Blob
 type _Blob struct{ x []byte }
 
-// Commit matches the IPLD Schema type "Commit".  It has Struct type-kind, and may be interrogated like map kind.
+// Blob_Link matches the IPLD Schema type "Blob_Link".  It has link kind.
+type Blob_Link = *_Blob_Link
+type _Blob_Link struct{ x datamodel.Link }
+
+// Commit matches the IPLD Schema type "Commit".  It has struct type-kind, and may be interrogated like map kind.
 type Commit = *_Commit
 type _Commit struct {
-	tree      _Tree_Link
-	parents   _Commit_Link_List
-	message   _String
-	author    _PersonInfo__Maybe
-	committer _PersonInfo__Maybe
-	encoding  _String__Maybe
-	signature _GpgSig__Maybe
-	mergetag  _Tag_List
-	other     _String_List
+	tree        _Tree_Link
+	parents     _Commit_Link_List
+	message     _String
+	author      _PersonInfo__Maybe
+	committer   _PersonInfo__Maybe
+	encoding    _String__Maybe
+	signatures  _Signature_List
+	mergetag    _Tag_List
+	other       _Header_List
+	headerOrder _String_List__Maybe
 }
 
 // Commit_Link matches the IPLD Schema type "Commit_Link".  It has link kind.
 type Commit_Link = *_Commit_Link
-type _Commit_Link struct{ x ipld.Link }
+type _Commit_Link struct{ x datamodel.Link }
 
 // Commit_Link_List matches the IPLD Schema type "Commit_Link_List".  It has list kind.
 type Commit_Link_List = *_Commit_Link_List
@@ -79,15 +94,24 @@ type _Commit_Link_List struct {
 	x []_Commit_Link
 }
 
-// GpgSig matches the IPLD Schema type "GpgSig".  It has string kind.
-type GpgSig = *_GpgSig
-type _GpgSig struct{ x string }
+// Header matches the IPLD Schema type "Header".  It has struct type-kind, and may be interrogated like map kind.
+type Header = *_Header
+type _Header struct {
+	key   _String
+	value _String
+}
+
+// Header_List matches the IPLD Schema type "Header_List".  It has list kind.
+type Header_List = *_Header_List
+type _Header_List struct {
+	x []_Header
+}
 
 // Link matches the IPLD Schema type "Link".  It has link kind.
 type Link = *_Link
-type _Link struct{ x ipld.Link }
+type _Link struct{ x datamodel.Link }
 
-// PersonInfo matches the IPLD Schema type "PersonInfo".  It has Struct type-kind, and may be interrogated like map kind.
+// PersonInfo matches the IPLD Schema type "PersonInfo".  It has struct type-kind, and may be interrogated like map kind.
 type PersonInfo = *_PersonInfo
 type _PersonInfo struct {
 	date     _String
@@ -96,6 +120,19 @@ type _PersonInfo struct {
 	name     _String
 }
 
+// Signature matches the IPLD Schema type "Signature".  It has struct type-kind, and may be interrogated like map kind.
+type Signature = *_Signature
+type _Signature struct {
+	header _String
+	text   _String
+}
+
+// Signature_List matches the IPLD Schema type "Signature_List".  It has list kind.
+type Signature_List = *_Signature_List
+type _Signature_List struct {
+	x []_Signature
+}
+
 // String matches the IPLD Schema type "String".  It has string kind.
 type String = *_String
 type _String struct{ x string }
@@ -106,16 +143,24 @@ type _String_List struct {
 	x []_String
 }
 
-// Tag matches the IPLD Schema type "Tag".  It has Struct type-kind, and may be interrogated like map kind.
+// Tag matches the IPLD Schema type "Tag".  It has struct type-kind, and may be interrogated like map kind.
 type Tag = *_Tag
 type _Tag struct {
-	object  _Link
-	typ     _String
-	tag     _String
-	tagger  _PersonInfo
-	message _String
+	object      _Link
+	typ         _String
+	tag         _String
+	tagger      _PersonInfo__Maybe
+	message     _String
+	signature   _String__Maybe
+	signatures  _Signature_List__Maybe
+	other       _Header_List__Maybe
+	headerOrder _String_List__Maybe
 }
 
+// Tag_Link matches the IPLD Schema type "Tag_Link".  It has link kind.
+type Tag_Link = *_Tag_Link
+type _Tag_Link struct{ x datamodel.Link }
+
 // Tag_List matches the IPLD Schema type "Tag_List".  It has list kind.
 type Tag_List = *_Tag_List
 type _Tag_List struct {
@@ -133,7 +178,7 @@ type _Tree__entry struct {
 	v _TreeEntry
 }
 
-// TreeEntry matches the IPLD Schema type "TreeEntry".  It has Struct type-kind, and may be interrogated like map kind.
+// TreeEntry matches the IPLD Schema type "TreeEntry".  It has struct type-kind, and may be interrogated like map kind.
 type TreeEntry = *_TreeEntry
 type _TreeEntry struct {
 	mode _String
@@ -142,4 +187,4 @@ type _TreeEntry struct {
 
 // Tree_Link matches the IPLD Schema type "Tree_Link".  It has link kind.
 type Tree_Link = *_Tree_Link
-type _Tree_Link struct{ x ipld.Link }
+type _Tree_Link struct{ x datamodel.Link }
@@ -0,0 +1,47 @@
+package ipldgit
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+var _ func(ipld.Link) (ipld.Decoder, error) = DecoderChooser
+
+// DecoderChooser chooses the decoder of a link for a LinkSystem. Git-raw links
+// are decoded in the object format of their multihash, so that SHA-1 and
+// SHA-256 objects load alike, and other links with the decoder registered for
+// their codec. The multicodec registry only holds the SHA-1 Decode, so a
+// LinkSystem loading SHA-256 objects must use this, as LinkSystem does.
+func DecoderChooser(lnk ipld.Link) (ipld.Decoder, error) {
+	return DecodeOptions{}.DecoderChooser(lnk)
+}
+
+// DecoderChooser is like the package-level DecoderChooser, decoding git-raw
+// links with the options o. When o.ObjectFormat is set, links in another
+// format are refused; otherwise each link is decoded in its own format.
+func (o DecodeOptions) DecoderChooser(lnk ipld.Link) (ipld.Decoder, error) {
+	cl, ok := lnk.(cidlink.Link)
+	if !ok || cl.Cid.Prefix().Codec != cid.GitRaw {
+		return cidlink.DefaultLinkSystem().DecoderChooser(lnk)
+	}
+	f, err := ObjectFormatOf(cl.Cid)
+	if err != nil {
+		return nil, err
+	}
+	if o.ObjectFormat != ObjectFormatDefault && o.ObjectFormat != f {
+		return nil, fmt.Errorf("link %s is a %s object, not %s", lnk, f, o.ObjectFormat)
+	}
+	o.ObjectFormat = f
+	return o.Decode, nil
+}
+
+// LinkSystem returns cidlink.DefaultLinkSystem with DecoderChooser, ready to
+// have storage set to load and store git objects of either format.
+func LinkSystem() ipld.LinkSystem {
+	ls := cidlink.DefaultLinkSystem()
+	ls.DecoderChooser = DecoderChooser
+	return ls
+}
@@ -0,0 +1,99 @@
+package ipldgit
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+func TestLinkSystemSHA256(t *testing.T) {
+	blob, blobSum := sha256Object("blob", "hello world\n")
+	tree, treeSum := sha256Object("tree", "100644 hello.txt\x00"+string(blobSum[:]))
+	commit, commitSum := sha256Object("commit", "tree "+hex.EncodeToString(treeSum[:])+"\n"+
+		"author A <a@b> 1792206015 +0000\n"+
+		"committer A <a@b> 1792206015 +0000\n"+
+		"\nmsg\n")
+	tag, tagSum := sha256Object("tag", "object "+hex.EncodeToString(commitSum[:])+"\n"+
+		"type commit\n"+
+		"tag v1\n"+
+		"tagger A <a@b> 1792206015 +0000\n"+
+		"\ntagmsg\n")
+
+	store := &memstore.Store{}
+	for _, raw := range [][]byte{blob, tree, commit, tag} {
+		c, err := ObjectFormatSHA256.Prefix().Sum(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Put(t.Context(), cidlink.Link{Cid: c}.Binary(), raw); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tagCid, _ := ObjectFormatSHA256.Cid(tagSum[:])
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := selector.CompileSelector(ssb.ExploreRecursive(selector.RecursionLimitNone(),
+		ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.ExploreRecursiveEdge()))).Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	walk := func(ls ipld.LinkSystem) (map[string]string, error) {
+		ls.SetReadStorage(store)
+		start, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: tagCid}, basicnode.Prototype.Any)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[string]string)
+		err = traversal.Progress{
+			Cfg: &traversal.Config{
+				LinkSystem: ls,
+				LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) {
+					return basicnode.Prototype.Any, nil
+				},
+			},
+		}.WalkMatching(start, sel, func(p traversal.Progress, n ipld.Node) error {
+			switch n.Kind() {
+			case ipld.Kind_String:
+				found[p.Path.String()], _ = n.AsString()
+			case ipld.Kind_Bytes:
+				b, _ := n.AsBytes()
+				found[p.Path.String()] = string(b)
+			}
+			return nil
+		})
+		return found, err
+	}
+
+	found, err := walk(LinkSystem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"message":                    "tagmsg\n",
+		"object/message":             "msg\n",
+		"object/tree/hello.txt/mode": "100644",
+		"object/tree/hello.txt/hash": "blob 12\x00hello world\n",
+		"object/author/name":         "A",
+	} {
+		if got := found[path]; got != want {
+			t.Errorf("%s: got %q, want %q", path, got, want)
+		}
+	}
+
+	if _, err := walk(cidlink.DefaultLinkSystem()); err == nil {
+		t.Fatal("walked sha256 objects decoding them as sha1")
+	}
+
+	sha1Only := cidlink.DefaultLinkSystem()
+	sha1Only.DecoderChooser = DecodeOptions{ObjectFormat: ObjectFormatSHA1}.DecoderChooser
+	if _, err := walk(sha1Only); err == nil {
+		t.Fatal("loaded a sha256 object with a sha1 decoder chooser")
+	}
+}
@@ -7,24 +7,47 @@ import (
 	"github.com/ipld/go-ipld-prime"
 )
 
+// EncodeOptions configures how git objects are written.
+type EncodeOptions struct {
+	// ObjectFormat, when set, requires every link written to be a hash in that
+	// format. When left as ObjectFormatDefault, each link is written in the
+	// format of its own multihash.
+	ObjectFormat ObjectFormat
+	// StrictTreeOrder makes encoding a tree whose entries are not in the order
+	// git sorts them in fail. Otherwise entries are written in the order of
+	// the node, so that a decoded tree encodes back to its bytes; TreeBuilder
+	// builds trees in git order. Git sorts entries by name, comparing
+	// directories as if their names ended in a slash.
+	StrictTreeOrder bool
+}
+
 // Encode serializes a git node to a raw binary form.
 func Encode(n ipld.Node, w io.Writer) error {
+	return EncodeOptions{}.Encode(n, w)
+}
+
+// Encode serializes a git node to a raw binary form.
+func (o EncodeOptions) Encode(n ipld.Node, w io.Writer) error {
+	f := o.ObjectFormat
+	if !f.valid() {
+		return fmt.Errorf("unsupported object format: %s", f)
+	}
 	switch n.Prototype() {
 	case Type.Blob, Type.Blob__Repr:
 		return encodeBlob(n, w)
 	case Type.Commit, Type.Commit__Repr:
-		return encodeCommit(n, w)
+		return encodeCommit(n, w, f)
 	case Type.Tree, Type.Tree__Repr:
-		return encodeTree(n, w)
+		return encodeTree(n, w, o)
 	case Type.Tag, Type.Tag__Repr:
-		return encodeTag(n, w)
+		return encodeTag(n, w, f)
 	default:
 	}
 	switch n.Kind() {
 	case ipld.Kind_Bytes:
 		return encodeBlob(n, w)
 	case ipld.Kind_List:
-		return encodeTree(n, w)
+		return encodeTree(n, w, o)
 	case ipld.Kind_Map:
 		k, _, err := n.MapIterator().Next()
 		if err != nil {
@@ -41,9 +64,9 @@ func Encode(n ipld.Node, w io.Writer) error {
 			"tag",
 			"tagger",
 			"text":
-			return encodeTag(n, w)
+			return encodeTag(n, w, f)
 		default:
-			return encodeCommit(n, w)
+			return encodeCommit(n, w, f)
 		}
 	default:
 		return fmt.Errorf("unrecognized object type: %T", n.Prototype())
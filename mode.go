@@ -0,0 +1,93 @@
+package ipldgit
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EntryKind is the kind of object a tree entry names, as given by its mode.
+type EntryKind uint8
+
+// The kinds of tree entries git writes.
+const (
+	// EntryFile is a regular file, with mode 100644.
+	EntryFile EntryKind = iota + 1
+	// EntryExecutable is an executable file, with mode 100755.
+	EntryExecutable
+	// EntrySymlink is a symbolic link whose target is the blob, with mode
+	// 120000.
+	EntrySymlink
+	// EntryDirectory is a subtree, with mode 40000.
+	EntryDirectory
+	// EntryGitlink is a submodule, naming a commit of another repository, with
+	// mode 160000.
+	EntryGitlink
+)
+
+// String returns the name of the kind.
+func (k EntryKind) String() string {
+	switch k {
+	case EntryFile:
+		return "file"
+	case EntryExecutable:
+		return "executable"
+	case EntrySymlink:
+		return "symlink"
+	case EntryDirectory:
+		return "directory"
+	case EntryGitlink:
+		return "gitlink"
+	default:
+		return fmt.Sprintf("EntryKind(%d)", uint8(k))
+	}
+}
+
+// Mode returns the mode git writes for entries of the kind.
+func (k EntryKind) Mode() string {
+	switch k {
+	case EntryFile:
+		return "100644"
+	case EntryExecutable:
+		return "100755"
+	case EntrySymlink:
+		return "120000"
+	case EntryDirectory:
+		return "40000"
+	case EntryGitlink:
+		return "160000"
+	default:
+		return ""
+	}
+}
+
+// ParseEntryMode returns the kind of a tree entry with the given mode. Besides
+// the modes git writes today, it accepts the zero-padded directory mode
+// "040000" and the group-writable file mode "100664" found in trees written by
+// old versions of git.
+func ParseEntryMode(mode string) (EntryKind, error) {
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tree entry mode %q", mode)
+	}
+	switch m {
+	case 0100644, 0100664:
+		return EntryFile, nil
+	case 0100755:
+		return EntryExecutable, nil
+	case 0120000:
+		return EntrySymlink, nil
+	case 0040000:
+		return EntryDirectory, nil
+	case 0160000:
+		return EntryGitlink, nil
+	default:
+		return 0, fmt.Errorf("unknown tree entry mode %q", mode)
+	}
+}
+
+// EntryKind returns the kind of the entry, parsed from its mode. The mode
+// itself is kept as it was written, so that trees re-encode to the same bytes.
+// (Kind is taken by ipld.Node, where it returns the data model kind.)
+func (n TreeEntry) EntryKind() (EntryKind, error) {
+	return ParseEntryMode(n.mode.x)
+}
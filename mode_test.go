@@ -0,0 +1,55 @@
+package ipldgit
+
+import (
+	"testing"
+)
+
+func TestParseEntryMode(t *testing.T) {
+	for _, test := range []struct {
+		mode string
+		kind EntryKind
+	}{
+		{"100644", EntryFile},
+		{"100664", EntryFile},
+		{"100755", EntryExecutable},
+		{"120000", EntrySymlink},
+		{"40000", EntryDirectory},
+		{"040000", EntryDirectory},
+		{"160000", EntryGitlink},
+		{"100600", 0},
+		{"644", 0},
+		{"10064x", 0},
+		{"", 0},
+	} {
+		kind, err := ParseEntryMode(test.mode)
+		if test.kind == 0 {
+			if err == nil {
+				t.Errorf("expected an error parsing %q, got %s", test.mode, kind)
+			}
+			continue
+		}
+		if err != nil || kind != test.kind {
+			t.Errorf("parsing %q: got %s, %v, want %s", test.mode, kind, err, test.kind)
+		}
+		if canon, err := ParseEntryMode(kind.Mode()); err != nil || canon != kind {
+			t.Errorf("mode %q of %s does not parse back", kind.Mode(), kind)
+		}
+	}
+}
+
+func TestTreeEntryKind(t *testing.T) {
+	tree := buildTree(t, "040000", "legacy", "100755", "run.sh")
+	for name, want := range map[string]EntryKind{"legacy": EntryDirectory, "run.sh": EntryExecutable} {
+		te := tree.(Tree).Lookup(&_String{name})
+		kind, err := te.EntryKind()
+		if err != nil || kind != want {
+			t.Fatalf("%s: got %s, %v, want %s", name, kind, err, want)
+		}
+	}
+
+	// The legacy mode is kept, so the entry re-encodes as it was written.
+	te := tree.(Tree).Lookup(&_String{"legacy"})
+	if te.FieldMode().String() != "040000" {
+		t.Fatalf("mode rewritten to %q", te.FieldMode().String())
+	}
+}
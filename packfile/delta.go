@@ -0,0 +1,329 @@
+package packfile
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultCacheSize is the number of bytes of delta bases a pack keeps in memory
+// when Options.CacheSize is zero.
+const DefaultCacheSize = 32 << 20
+
+// maxDeltaChain bounds the length of a delta chain. git itself writes chains no
+// deeper than 4095, so anything longer is treated as corrupt.
+const maxDeltaChain = 10000
+
+var errDeltaCorrupt = errors.New("corrupt delta")
+
+// deltaSize reads one of the two size varints at the start of a delta.
+func deltaSize(delta []byte) (int, []byte, error) {
+	var size uint64
+	for shift := uint(0); ; shift += 7 {
+		if len(delta) == 0 || shift > 56 {
+			return 0, nil, errDeltaCorrupt
+		}
+		c := delta[0]
+		delta = delta[1:]
+		size |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			break
+		}
+	}
+	if size > uint64(maxInt) {
+		return 0, nil, errDeltaCorrupt
+	}
+	return int(size), delta, nil
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// applyDelta rebuilds an object from its base and a delta against it.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	srcSize, delta, err := deltaSize(delta)
+	if err != nil {
+		return nil, err
+	}
+	if srcSize != len(base) {
+		return nil, fmt.Errorf("delta expects a base of %d bytes, got %d", srcSize, len(base))
+	}
+	dstSize, delta, err := deltaSize(delta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the size of a packed object, the target size is unverified until
+	// the delta has been applied, so it only bounds the initial allocation.
+	out := make([]byte, 0, min(dstSize, len(base)+len(delta)))
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+
+		switch {
+		case op&0x80 != 0:
+			var offset, size int
+			for i := range 4 {
+				if op&(1<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errDeltaCorrupt
+					}
+					offset |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			for i := range 3 {
+				if op&(0x10<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errDeltaCorrupt
+					}
+					size |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if offset+size > len(base) || len(out)+size > dstSize {
+				return nil, errDeltaCorrupt
+			}
+			out = append(out, base[offset:offset+size]...)
+		case op != 0:
+			size := int(op)
+			if size > len(delta) || len(out)+size > dstSize {
+				return nil, errDeltaCorrupt
+			}
+			out = append(out, delta[:size]...)
+			delta = delta[size:]
+		default:
+			// Opcode zero is reserved.
+			return nil, errDeltaCorrupt
+		}
+	}
+
+	if len(out) != dstSize {
+		return nil, fmt.Errorf("delta produced %d bytes, expected %d", len(out), dstSize)
+	}
+	return out, nil
+}
+
+// readObject returns the type and body of the object at off, applying deltas
+// down to its base object as needed.
+func (p *Pack) readObject(off int64) (ObjectType, []byte, error) {
+	var (
+		chain   []entry
+		offsets []int64
+		typ     ObjectType
+		body    []byte
+	)
+	seen := make(map[int64]struct{})
+	for cur := off; ; {
+		if t, b, ok := p.cache.get(cur); ok {
+			typ, body = t, b
+			break
+		}
+		if _, ok := seen[cur]; ok {
+			return 0, nil, fmt.Errorf("delta chain of object at pack offset %d loops back to offset %d", off, cur)
+		}
+		seen[cur] = struct{}{}
+		if len(chain) == maxDeltaChain {
+			return 0, nil, fmt.Errorf("delta chain of object at pack offset %d is too long", off)
+		}
+
+		e, err := p.readEntry(cur)
+		if err != nil {
+			return 0, nil, err
+		}
+		if e.typ != ObjOfsDelta && e.typ != ObjRefDelta {
+			body, err = p.inflate(e)
+			if err != nil {
+				return 0, nil, err
+			}
+			typ = e.typ
+			if len(chain) > 0 {
+				p.cache.add(cur, typ, body)
+			}
+			break
+		}
+
+		chain = append(chain, e)
+		offsets = append(offsets, cur)
+		if e.typ == ObjOfsDelta {
+			cur = e.baseOffset
+			continue
+		}
+		i, ok := p.idx.Find(e.baseSha)
+		if !ok {
+			return 0, nil, fmt.Errorf("delta base %x of object at pack offset %d is not in the pack", e.baseSha, cur)
+		}
+		cur = p.idx.Offset(i)
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		delta, err := p.inflate(chain[i])
+		if err != nil {
+			return 0, nil, err
+		}
+		body, err = applyDelta(body, delta)
+		if err != nil {
+			return 0, nil, fmt.Errorf("object at pack offset %d: %w", offsets[i], err)
+		}
+		if i > 0 {
+			p.cache.add(offsets[i], typ, body)
+		}
+	}
+	return typ, body, nil
+}
+
+// baseCache is a least recently used cache of delta bases, bounded by the total
+// size of the objects it holds.
+type baseCache struct {
+	mu    sync.Mutex
+	limit int64
+	size  int64
+	lru   list.List
+	items map[int64]*list.Element
+}
+
+type cachedBase struct {
+	off  int64
+	typ  ObjectType
+	body []byte
+}
+
+func newBaseCache(limit int64) *baseCache {
+	return &baseCache{limit: limit, items: make(map[int64]*list.Element)}
+}
+
+func (c *baseCache) get(off int64) (ObjectType, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[off]
+	if !ok {
+		return 0, nil, false
+	}
+	c.lru.MoveToFront(el)
+	b := el.Value.(*cachedBase)
+	return b.typ, b.body, true
+}
+
+func (c *baseCache) add(off int64, typ ObjectType, body []byte) {
+	if int64(len(body)) > c.limit {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[off]; ok {
+		return
+	}
+	c.items[off] = c.lru.PushFront(&cachedBase{off: off, typ: typ, body: body})
+	c.size += int64(len(body))
+	for c.size > c.limit {
+		el := c.lru.Back()
+		b := el.Value.(*cachedBase)
+		c.lru.Remove(el)
+		delete(c.items, b.off)
+		c.size -= int64(len(b.body))
+	}
+}
+
+// deltaBlock is the length of the blocks of a base that a delta index matches.
+const deltaBlock = 16
+
+// deltaIndex finds the blocks of a base object in the objects deltified
+// against it.
+type deltaIndex struct {
+	base   []byte
+	blocks map[[deltaBlock]byte]int
+}
+
+func newDeltaIndex(base []byte) *deltaIndex {
+	idx := &deltaIndex{base: base, blocks: make(map[[deltaBlock]byte]int, len(base)/deltaBlock)}
+	for i := 0; i+deltaBlock <= len(base); i += deltaBlock {
+		k := [deltaBlock]byte(base[i : i+deltaBlock])
+		if _, ok := idx.blocks[k]; !ok {
+			idx.blocks[k] = i
+		}
+	}
+	return idx
+}
+
+// createDelta returns a delta that rebuilds target from the base of idx, or nil
+// if the delta would be longer than maxSize bytes.
+func (idx *deltaIndex) createDelta(target []byte, maxSize int) []byte {
+	delta := appendDeltaSize(nil, len(idx.base))
+	delta = appendDeltaSize(delta, len(target))
+
+	// Bytes from insert up to i have no match in the base yet.
+	insert := 0
+	flush := func(end int) {
+		for insert < end {
+			n := min(end-insert, 0x7f)
+			delta = append(delta, byte(n))
+			delta = append(delta, target[insert:insert+n]...)
+			insert += n
+		}
+	}
+	for i := 0; i+deltaBlock <= len(target); {
+		if len(delta) > maxSize {
+			return nil
+		}
+		off, ok := idx.blocks[[deltaBlock]byte(target[i:i+deltaBlock])]
+		if !ok {
+			i++
+			continue
+		}
+		// Grow the match backwards over pending inserts, then forwards.
+		for off > 0 && i > insert && idx.base[off-1] == target[i-1] {
+			off--
+			i--
+		}
+		n := 0
+		for off+n < len(idx.base) && i+n < len(target) && idx.base[off+n] == target[i+n] {
+			n++
+		}
+		flush(i)
+		delta = appendDeltaCopy(delta, off, n)
+		i += n
+		insert = i
+	}
+	flush(len(target))
+	if len(delta) > maxSize {
+		return nil
+	}
+	return delta
+}
+
+func appendDeltaSize(delta []byte, size int) []byte {
+	for size >= 0x80 {
+		delta = append(delta, byte(size)|0x80)
+		size >>= 7
+	}
+	return append(delta, byte(size))
+}
+
+// appendDeltaCopy appends the copy instructions for n bytes of the base at off.
+// Like git, it copies at most 0x10000 bytes per instruction.
+func appendDeltaCopy(delta []byte, off, n int) []byte {
+	for n > 0 {
+		size := min(n, 0x10000)
+		op := len(delta)
+		delta = append(delta, 0x80)
+		for i := range 4 {
+			if b := byte(off >> (8 * i)); b != 0 {
+				delta[op] |= 1 << i
+				delta = append(delta, b)
+			}
+		}
+		// A size of 0x10000 is written as no size bytes at all.
+		for i := range 2 {
+			if b := byte(size >> (8 * i)); b != 0 && size != 0x10000 {
+				delta[op] |= 0x10 << i
+				delta = append(delta, b)
+			}
+		}
+		off += size
+		n -= size
+	}
+	return delta
+}
@@ -0,0 +1,261 @@
+package packfile
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+)
+
+func TestDeltaPacks(t *testing.T) {
+	for _, name := range []string{"ofsdelta", "refdelta"} {
+		for _, cacheSize := range []int64{0, 512, -1} {
+			t.Run(fmt.Sprintf("%s/cache=%d", name, cacheSize), func(t *testing.T) {
+				testPackObjects(t, "testdata/"+name+".pack", Options{CacheSize: cacheSize}, 120)
+			})
+		}
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("0123456789")
+	for _, test := range []struct {
+		name  string
+		delta []byte
+		want  string
+		err   string
+	}{
+		{"CopyAndInsert", []byte{10, 7, 0x91, 2, 4, 3, 'a', 'b', 'c'}, "2345abc", ""},
+		{"CopyDefaultSize", []byte{10, 0, 0x80}, "", "corrupt delta"},
+		{"WrongBaseSize", []byte{9, 1, 1, 'a'}, "", "base of 9 bytes"},
+		{"CopyOutOfRange", []byte{10, 4, 0x91, 8, 4}, "", "corrupt delta"},
+		{"ReservedOpcode", []byte{10, 1, 0}, "", "corrupt delta"},
+		{"TruncatedInsert", []byte{10, 3, 3, 'a'}, "", "corrupt delta"},
+		{"TruncatedSize", []byte{0x8a}, "", "corrupt delta"},
+		{"ShortResult", []byte{10, 3, 1, 'a'}, "", "produced 1 bytes"},
+		{"LongResult", []byte{10, 1, 2, 'a', 'b'}, "", "corrupt delta"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := applyDelta(base, test.delta)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// testEntry is an object written whole, or a delta against the entry at index
+// base, written as a reference delta when ref is set.
+type testEntry struct {
+	typ   ObjectType
+	body  []byte
+	base  int
+	ref   bool
+	delta []byte
+	// sha overrides the hash recorded for the entry in the index.
+	sha []byte
+	// baseSha overrides the base of a reference delta.
+	baseSha []byte
+}
+
+// buildPack writes a SHA-1 pack and index holding entries, in order.
+func buildPack(t *testing.T, entries []testEntry, opts Options) *Pack {
+	shas := make([][]byte, len(entries))
+	for i, e := range entries {
+		switch {
+		case e.sha != nil:
+			shas[i] = e.sha
+		case e.delta == nil:
+			h := sha1.Sum(append(fmt.Appendf(nil, "%s %d\x00", e.typ, len(e.body)), e.body...))
+			shas[i] = h[:]
+		default:
+			t.Fatalf("delta entry %d needs a sha", i)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	buf.Write(packMagic)
+	binary.Write(buf, binary.BigEndian, uint32(2))
+	binary.Write(buf, binary.BigEndian, uint32(len(entries)))
+
+	offsets := make([]int64, len(entries))
+	for i, e := range entries {
+		offsets[i] = int64(buf.Len())
+		typ, data := e.typ, e.body
+		if e.delta != nil {
+			typ, data = ObjOfsDelta, e.delta
+			if e.ref {
+				typ = ObjRefDelta
+			}
+		}
+
+		size := len(data)
+		c := byte(typ)<<4 | byte(size&0x0f)
+		for size >>= 4; size > 0; size >>= 7 {
+			buf.WriteByte(c | 0x80)
+			c = byte(size & 0x7f)
+		}
+		buf.WriteByte(c)
+
+		switch typ {
+		case ObjOfsDelta:
+			rel := offsets[i] - offsets[e.base]
+			enc := []byte{byte(rel & 0x7f)}
+			for rel >>= 7; rel > 0; rel >>= 7 {
+				rel--
+				enc = append([]byte{byte(rel&0x7f) | 0x80}, enc...)
+			}
+			buf.Write(enc)
+		case ObjRefDelta:
+			if e.baseSha != nil {
+				buf.Write(e.baseSha)
+			} else {
+				buf.Write(shas[e.base])
+			}
+		}
+
+		zw := zlib.NewWriter(buf)
+		zw.Write(data)
+		zw.Close()
+	}
+	sum := sha1.Sum(buf.Bytes())
+	buf.Write(sum[:])
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return bytes.Compare(shas[order[a]], shas[order[b]]) < 0 })
+
+	idx := &Index{format: ipldgit.ObjectFormatSHA1, packSum: sum[:]}
+	for _, i := range order {
+		idx.names = append(idx.names, shas[i]...)
+		idx.crcs = append(idx.crcs, 0)
+		idx.offsets = append(idx.offsets, offsets[i])
+		for b := int(shas[i][0]); b < 256; b++ {
+			idx.fanout[b]++
+		}
+	}
+
+	p, err := New(bytes.NewReader(buf.Bytes()), int64(buf.Len()), idx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func blobSha(content string) []byte {
+	h := sha1.Sum(fmt.Appendf(nil, "blob %d\x00%s", len(content), content))
+	return h[:]
+}
+
+func TestDeltaChain(t *testing.T) {
+	// Each version appends a letter to the one before it, through a delta that
+	// copies the previous version and inserts the letter.
+	content := "base"
+	entries := []testEntry{{typ: ObjBlob, body: []byte(content)}}
+	for i := range 100 {
+		prev := len(content)
+		content += string(rune('a' + i%26))
+		entries = append(entries, testEntry{
+			base:  i,
+			ref:   i%2 == 1,
+			delta: []byte{byte(prev), byte(len(content)), 0x90, byte(prev), 1, content[len(content)-1]},
+			sha:   blobSha(content),
+		})
+	}
+
+	for _, test := range []struct {
+		name      string
+		cacheSize int64
+		limit     int64
+	}{
+		{"Default", 0, DefaultCacheSize},
+		{"Disabled", -1, -1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := buildPack(t, entries, Options{CacheSize: test.cacheSize})
+			if p.cache.limit != test.limit {
+				t.Fatalf("cache limit is %d, want %d", p.cache.limit, test.limit)
+			}
+			n, err := p.GetBySha(blobSha(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b, _ := n.AsBytes(); !bytes.HasSuffix(b, []byte(content)) {
+				t.Fatalf("unexpected blob %q", b)
+			}
+			if cached := p.cache.lru.Len() > 0; cached != (test.limit > 0) {
+				t.Fatalf("cache holds %d bases", p.cache.lru.Len())
+			}
+		})
+	}
+}
+
+func TestDeltaCycle(t *testing.T) {
+	a, b := blobSha("a"), blobSha("b")
+	p := buildPack(t, []testEntry{
+		{base: 1, ref: true, delta: []byte{1, 1, 1, 'a'}, sha: a},
+		{base: 0, ref: true, delta: []byte{1, 1, 1, 'b'}, sha: b},
+	}, Options{})
+	if _, err := p.ReadRaw(a); err == nil || !strings.Contains(err.Error(), "loops back") {
+		t.Fatalf("expected a delta cycle error, got %v", err)
+	}
+}
+
+func TestDeltaMissingBase(t *testing.T) {
+	a := blobSha("a")
+	p := buildPack(t, []testEntry{
+		{ref: true, baseSha: blobSha("b"), delta: []byte{1, 1, 1, 'a'}, sha: a},
+	}, Options{})
+	if _, err := p.ReadRaw(a); err == nil || !strings.Contains(err.Error(), "not in the pack") {
+		t.Fatalf("expected a missing base error, got %v", err)
+	}
+}
+
+func TestCreateDelta(t *testing.T) {
+	var base []byte
+	for i := range 2000 {
+		base = fmt.Appendf(base, "line %d\n", i)
+	}
+	for _, test := range []struct {
+		name   string
+		target []byte
+	}{
+		{"Same", base},
+		{"Empty", nil},
+		{"Prefix", base[:1000]},
+		{"Edited", bytes.Replace(base, []byte("line 1000\n"), []byte("changed\n"), 1)},
+		{"Reordered", append(append([]byte("new\n"), base[5000:]...), base[:5000]...)},
+		{"Unrelated", bytes.Repeat([]byte("unrelated "), 100)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			delta := newDeltaIndex(base).createDelta(test.target, maxInt)
+			got, err := applyDelta(base, delta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, test.target) {
+				t.Fatal("delta does not rebuild the target")
+			}
+			if newDeltaIndex(base).createDelta(test.target, len(delta)-1) != nil {
+				t.Fatal("delta exceeds its maximum size")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package packfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// searchObject is an object considered by the delta search.
+type searchObject struct {
+	sha      []byte
+	typ      ObjectType
+	size     int
+	nameHash uint32
+}
+
+// windowObject is a written object that later objects may be deltified against.
+type windowObject struct {
+	typ    ObjectType
+	body   []byte
+	idx    *deltaIndex
+	offset int64
+	depth  int
+}
+
+// writeDeltified writes the objects with the given hashes, deltifying each one
+// against the best of the window objects written before it.
+//
+// As in git pack-objects, the objects are first sorted by type, by a hash of
+// the name a tree gives them, and by decreasing size, so that the objects
+// compared are likely to be versions of the same file, and deltas are made by
+// removing data rather than adding it. Every object is loaded once for the
+// sort and once more when it is written, so that only the window is held in
+// memory.
+func (pw *packWriter) writeDeltified(ctx context.Context, ls *ipld.LinkSystem, shas [][]byte, window, maxDepth int) error {
+	f := pw.format
+	objs := make([]searchObject, len(shas))
+	pos := make(map[string]int, len(shas))
+	for i, sha := range shas {
+		objs[i].sha = sha
+		pos[string(sha)] = i
+	}
+	named := make([]bool, len(shas))
+	for i, sha := range shas {
+		typ, body, err := loadObject(ctx, ls, f, sha)
+		if err != nil {
+			return err
+		}
+		objs[i].typ, objs[i].size = typ, len(body)
+		if typ != ObjTree {
+			continue
+		}
+		err = walkTree(body, f.Size(), func(name string, sha []byte) {
+			if j, ok := pos[string(sha)]; ok && !named[j] {
+				objs[j].nameHash = nameHash(name)
+				named[j] = true
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	sort.SliceStable(objs, func(a, b int) bool {
+		oa, ob := &objs[a], &objs[b]
+		if oa.typ != ob.typ {
+			return oa.typ < ob.typ
+		}
+		if oa.nameHash != ob.nameHash {
+			return oa.nameHash < ob.nameHash
+		}
+		return oa.size > ob.size
+	})
+
+	win := make([]*windowObject, 0, window)
+	for _, o := range objs {
+		typ, body, err := loadObject(ctx, ls, f, o.sha)
+		if err != nil {
+			return err
+		}
+
+		var (
+			base  *windowObject
+			delta []byte
+		)
+		// Most recently written objects first, as they are the most similar.
+		for i := len(win) - 1; i >= 0; i-- {
+			w := win[i]
+			if w.typ != typ || w.depth >= maxDepth || int64(len(w.body)) > maxDeltaBase {
+				continue
+			}
+			// Like git, demand a smaller delta of bases deeper in their chain.
+			maxSize := (len(body)/2 - f.Size()) * (maxDepth - w.depth) / maxDepth
+			if delta != nil {
+				maxSize = min(maxSize, len(delta)-1)
+			}
+			if maxSize <= 0 || abs(len(w.body)-len(body)) >= maxSize {
+				continue
+			}
+			if w.idx == nil {
+				w.idx = newDeltaIndex(w.body)
+			}
+			if d := w.idx.createDelta(body, maxSize); d != nil {
+				base, delta = w, d
+			}
+		}
+
+		cur := &windowObject{typ: typ, body: body}
+		if base != nil {
+			err = pw.writeEntry(o.sha, ObjOfsDelta, ofsDeltaOffset(pw.offset()-base.offset), delta)
+			cur.depth = base.depth + 1
+		} else {
+			err = pw.writeObject(o.sha, typ, body)
+		}
+		if err != nil {
+			return err
+		}
+		cur.offset = pw.offsets[len(pw.offsets)-1]
+
+		if len(win) == window {
+			copy(win, win[1:])
+			win = win[:window-1]
+		}
+		win = append(win, cur)
+	}
+	return nil
+}
+
+// maxDeltaBase is the largest base a delta can copy from, as copy instructions
+// hold 32-bit offsets.
+const maxDeltaBase = 1<<32 - 1
+
+// ofsDeltaOffset encodes the distance back from an ObjOfsDelta to its base.
+func ofsDeltaOffset(rel int64) []byte {
+	enc := []byte{byte(rel & 0x7f)}
+	for rel >>= 7; rel > 0; rel >>= 7 {
+		rel--
+		enc = append([]byte{byte(rel&0x7f) | 0x80}, enc...)
+	}
+	return enc
+}
+
+// nameHash is the hash git pack-objects sorts objects with the same name by.
+// It is mostly made of the last characters of the name, so that files with the
+// same extension sort near each other.
+func nameHash(name string) uint32 {
+	var h uint32
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			continue
+		}
+		h = (h >> 2) + uint32(c)<<24
+	}
+	return h
+}
+
+// walkTree calls fn with the name and hash of every entry of a tree body.
+func walkTree(body []byte, hashSize int, fn func(name string, sha []byte)) error {
+	for len(body) > 0 {
+		sp := bytes.IndexByte(body, ' ')
+		nul := bytes.IndexByte(body, 0)
+		if sp < 0 || nul < sp || len(body) < nul+1+hashSize {
+			return errors.New("malformed tree")
+		}
+		fn(string(body[sp+1:nul]), body[nul+1:nul+1+hashSize])
+		body = body[nul+1+hashSize:]
+	}
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,229 @@
+package packfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+)
+
+var idxMagic = []byte{0xff, 't', 'O', 'c'}
+
+const (
+	idxVersion     = 2
+	idxFanoutSize  = 256 * 4
+	idxLargeOffset = 1 << 31
+)
+
+// Index is the contents of a version 2 pack index (.idx) file: the hash, CRC-32
+// and pack offset of every object in a pack, sorted by hash.
+type Index struct {
+	format  ipldgit.ObjectFormat
+	fanout  [256]uint32
+	names   []byte
+	crcs    []uint32
+	offsets []int64
+	packSum []byte
+}
+
+// ReadIndex reads a version 2 pack index for a repository in the given object
+// format, verifying its trailing checksum.
+func ReadIndex(r io.Reader, f ipldgit.ObjectFormat) (*Index, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	hs := f.Size()
+	if len(data) < 8+idxFanoutSize+2*hs {
+		return nil, errors.New("pack index too short")
+	}
+	if !bytes.Equal(data[:4], idxMagic) {
+		return nil, errors.New("not a version 2 pack index")
+	}
+	if v := binary.BigEndian.Uint32(data[4:8]); v != idxVersion {
+		return nil, fmt.Errorf("unsupported pack index version %d", v)
+	}
+
+	body, sum := data[:len(data)-hs], data[len(data)-hs:]
+	h := f.NewHash()
+	h.Write(body)
+	if !bytes.Equal(h.Sum(nil), sum) {
+		return nil, errors.New("pack index checksum mismatch")
+	}
+	body, packSum := body[:len(body)-hs], body[len(body)-hs:]
+
+	idx := &Index{format: f, packSum: append([]byte(nil), packSum...)}
+	var prev uint32
+	for i := range idx.fanout {
+		n := binary.BigEndian.Uint32(data[8+4*i:])
+		if n < prev {
+			return nil, errors.New("pack index fanout is not sorted")
+		}
+		idx.fanout[i], prev = n, n
+	}
+
+	count := int(idx.fanout[255])
+	rest := body[8+idxFanoutSize:]
+	if len(rest) < count*(hs+8) {
+		return nil, errors.New("pack index too short")
+	}
+	idx.names, rest = rest[:count*hs], rest[count*hs:]
+
+	idx.crcs = make([]uint32, count)
+	for i := range idx.crcs {
+		idx.crcs[i] = binary.BigEndian.Uint32(rest[4*i:])
+	}
+	rest = rest[4*count:]
+
+	idx.offsets = make([]int64, count)
+	large := rest[4*count:]
+	for i := range idx.offsets {
+		off := binary.BigEndian.Uint32(rest[4*i:])
+		if off&idxLargeOffset == 0 {
+			idx.offsets[i] = int64(off)
+			continue
+		}
+		li := int(off &^ idxLargeOffset)
+		if len(large) < 8*(li+1) {
+			return nil, fmt.Errorf("pack index large offset %d out of range", li)
+		}
+		idx.offsets[i] = int64(binary.BigEndian.Uint64(large[8*li:]))
+	}
+
+	for i := 1; i < count; i++ {
+		if bytes.Compare(idx.Hash(i-1), idx.Hash(i)) >= 0 {
+			return nil, errors.New("pack index names are not sorted")
+		}
+	}
+
+	return idx, nil
+}
+
+// ObjectFormat returns the object format the index was read or built in.
+func (idx *Index) ObjectFormat() ipldgit.ObjectFormat {
+	return idx.format
+}
+
+// Count returns the number of objects in the index.
+func (idx *Index) Count() int {
+	return len(idx.offsets)
+}
+
+// Hash returns the hash of the i'th object, in hash order.
+func (idx *Index) Hash(i int) []byte {
+	hs := idx.format.Size()
+	return idx.names[i*hs : (i+1)*hs]
+}
+
+// Offset returns the pack offset of the i'th object, in hash order.
+func (idx *Index) Offset(i int) int64 {
+	return idx.offsets[i]
+}
+
+// CRC32 returns the CRC-32 of the i'th object's packed entry, in hash order.
+func (idx *Index) CRC32(i int) uint32 {
+	return idx.crcs[i]
+}
+
+// PackChecksum returns the trailing checksum of the pack the index describes.
+func (idx *Index) PackChecksum() []byte {
+	return idx.packSum
+}
+
+// Find returns the position of the object with the given hash, if present.
+func (idx *Index) Find(sha []byte) (int, bool) {
+	if len(sha) != idx.format.Size() {
+		return 0, false
+	}
+	lo := 0
+	if sha[0] > 0 {
+		lo = int(idx.fanout[sha[0]-1])
+	}
+	hi := int(idx.fanout[sha[0]])
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(idx.Hash(lo+i), sha) >= 0
+	})
+	if i < hi && bytes.Equal(idx.Hash(i), sha) {
+		return i, true
+	}
+	return 0, false
+}
+
+// newIndex builds the index of a pack from the hashes, CRC-32s and offsets of
+// its objects, in any order.
+func newIndex(f ipldgit.ObjectFormat, shas [][]byte, crcs []uint32, offsets []int64, packSum []byte) *Index {
+	order := make([]int, len(shas))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return bytes.Compare(shas[order[a]], shas[order[b]]) < 0
+	})
+
+	idx := &Index{
+		format:  f,
+		names:   make([]byte, 0, len(shas)*f.Size()),
+		crcs:    make([]uint32, 0, len(shas)),
+		offsets: make([]int64, 0, len(shas)),
+		packSum: packSum,
+	}
+	for _, i := range order {
+		idx.names = append(idx.names, shas[i]...)
+		idx.crcs = append(idx.crcs, crcs[i])
+		idx.offsets = append(idx.offsets, offsets[i])
+		idx.fanout[shas[i][0]]++
+	}
+	for i := 1; i < len(idx.fanout); i++ {
+		idx.fanout[i] += idx.fanout[i-1]
+	}
+	return idx
+}
+
+// WriteTo writes the index in the version 2 .idx format, followed by its
+// checksum.
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	h := idx.format.NewHash()
+	cw := &countingWriter{w: io.MultiWriter(w, h)}
+	bw := bufio.NewWriter(cw)
+
+	bw.Write(idxMagic)
+	binary.Write(bw, binary.BigEndian, uint32(idxVersion))
+	binary.Write(bw, binary.BigEndian, idx.fanout[:])
+	bw.Write(idx.names)
+	binary.Write(bw, binary.BigEndian, idx.crcs)
+
+	var large []int64
+	for _, off := range idx.offsets {
+		if off < idxLargeOffset {
+			binary.Write(bw, binary.BigEndian, uint32(off))
+			continue
+		}
+		binary.Write(bw, binary.BigEndian, uint32(len(large))|idxLargeOffset)
+		large = append(large, off)
+	}
+	binary.Write(bw, binary.BigEndian, large)
+	bw.Write(idx.packSum)
+
+	if err := bw.Flush(); err != nil {
+		return cw.n, err
+	}
+	_, err := w.Write(h.Sum(nil))
+	return cw.n + int64(idx.format.Size()), err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,358 @@
+// Package packfile reads and writes git packfiles and their version 2 indexes,
+// producing the same nodes as ipldgit.ParseObject does for loose objects.
+package packfile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+)
+
+// ErrNotFound is returned when an object is not in the pack.
+var ErrNotFound = errors.New("object not found in pack")
+
+// ObjectType is the type of an entry in a pack.
+type ObjectType uint8
+
+// The entry types a pack can hold. Types 0 and 5 are reserved.
+const (
+	ObjCommit   ObjectType = 1
+	ObjTree     ObjectType = 2
+	ObjBlob     ObjectType = 3
+	ObjTag      ObjectType = 4
+	ObjOfsDelta ObjectType = 6
+	ObjRefDelta ObjectType = 7
+)
+
+// String returns the name git gives the type in object headers.
+func (t ObjectType) String() string {
+	switch t {
+	case ObjCommit:
+		return "commit"
+	case ObjTree:
+		return "tree"
+	case ObjBlob:
+		return "blob"
+	case ObjTag:
+		return "tag"
+	case ObjOfsDelta:
+		return "ofs-delta"
+	case ObjRefDelta:
+		return "ref-delta"
+	default:
+		return fmt.Sprintf("ObjectType(%d)", uint8(t))
+	}
+}
+
+var packMagic = []byte("PACK")
+
+const packHeaderSize = 12
+
+// Options configures how a pack is opened.
+type Options struct {
+	// ObjectFormat is the hash function of the repository the pack belongs to.
+	ObjectFormat ipldgit.ObjectFormat
+	// CacheSize bounds the bytes of delta bases kept in memory to speed up
+	// reading objects that share them. Zero means DefaultCacheSize, and a
+	// negative value disables the cache.
+	CacheSize int64
+}
+
+// Pack is a packfile opened for reading. It is safe for concurrent use.
+type Pack struct {
+	r      io.ReaderAt
+	size   int64
+	idx    *Index
+	cache  *baseCache
+	closer io.Closer
+}
+
+// Open opens the pack at path, along with the index next to it that shares its
+// name but ends in ".idx".
+func Open(path string, opts Options) (*Pack, error) {
+	idxf, err := os.Open(strings.TrimSuffix(path, ".pack") + ".idx")
+	if err != nil {
+		return nil, err
+	}
+	defer idxf.Close()
+
+	idx, err := ReadIndex(bufio.NewReader(idxf), opts.ObjectFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	p, err := New(f, fi.Size(), idx, opts)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	p.closer = f
+	return p, nil
+}
+
+// New reads a pack of the given size from r, using idx to find its objects.
+// The header and trailing checksum of the pack are checked against the index,
+// whose object format takes precedence over the one in opts.
+func New(r io.ReaderAt, size int64, idx *Index, opts Options) (*Pack, error) {
+	hs := int64(idx.format.Size())
+	if size < packHeaderSize+hs {
+		return nil, errors.New("pack too short")
+	}
+
+	hdr := make([]byte, packHeaderSize)
+	if _, err := r.ReadAt(hdr, 0); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hdr[:4], packMagic) {
+		return nil, errors.New("not a pack")
+	}
+	if v := binary.BigEndian.Uint32(hdr[4:8]); v != 2 && v != 3 {
+		return nil, fmt.Errorf("unsupported pack version %d", v)
+	}
+	if n := binary.BigEndian.Uint32(hdr[8:12]); int(n) != idx.Count() {
+		return nil, fmt.Errorf("pack holds %d objects but its index lists %d", n, idx.Count())
+	}
+
+	sum := make([]byte, hs)
+	if _, err := r.ReadAt(sum, size-hs); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(sum, idx.PackChecksum()) {
+		return nil, errors.New("pack checksum does not match its index")
+	}
+
+	cacheSize := opts.CacheSize
+	if cacheSize == 0 {
+		cacheSize = DefaultCacheSize
+	}
+	return &Pack{r: r, size: size, idx: idx, cache: newBaseCache(cacheSize)}, nil
+}
+
+// Close releases the file opened by Open. It does nothing for a pack made by New.
+func (p *Pack) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
+
+// Index returns the index of the pack.
+func (p *Pack) Index() *Index {
+	return p.idx
+}
+
+// Has reports whether the object named by c is in the pack.
+func (p *Pack) Has(c cid.Cid) bool {
+	sha, err := p.idx.format.Sha(c)
+	if err != nil {
+		return false
+	}
+	_, ok := p.idx.Find(sha)
+	return ok
+}
+
+// Get returns the node for the object named by c.
+func (p *Pack) Get(c cid.Cid) (ipld.Node, error) {
+	sha, err := p.idx.format.Sha(c)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetBySha(sha)
+}
+
+// GetBySha returns the node for the object with the given hash.
+func (p *Pack) GetBySha(sha []byte) (ipld.Node, error) {
+	raw, err := p.ReadRaw(sha)
+	if err != nil {
+		return nil, err
+	}
+	return ipldgit.DecodeOptions{ObjectFormat: p.idx.format}.ParseObjectFromBuffer(raw)
+}
+
+// ReadRaw returns the object with the given hash in its loose form, a
+// "<type> <size>\x00" header followed by the object body.
+func (p *Pack) ReadRaw(sha []byte) ([]byte, error) {
+	i, ok := p.idx.Find(sha)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return p.readRaw(i)
+}
+
+func (p *Pack) readRaw(i int) ([]byte, error) {
+	typ, body, err := p.readObject(p.idx.Offset(i))
+	if err != nil {
+		return nil, err
+	}
+
+	raw := fmt.Appendf(nil, "%s %d\x00", typ, len(body))
+	raw = append(raw, body...)
+
+	h := p.idx.format.NewHash()
+	h.Write(raw)
+	if sum := h.Sum(nil); !bytes.Equal(sum, p.idx.Hash(i)) {
+		return nil, fmt.Errorf("object %s in pack hashes to %s", hex.EncodeToString(p.idx.Hash(i)), hex.EncodeToString(sum))
+	}
+	return raw, nil
+}
+
+// ForEach calls fn with every object in the pack, in pack order. It stops at
+// the first error fn returns.
+func (p *Pack) ForEach(fn func(cid.Cid, ipld.Node) error) error {
+	order := make([]int, p.idx.Count())
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return p.idx.Offset(order[a]) < p.idx.Offset(order[b])
+	})
+
+	dec := ipldgit.DecodeOptions{ObjectFormat: p.idx.format}
+	for _, i := range order {
+		raw, err := p.readRaw(i)
+		if err != nil {
+			return err
+		}
+		n, err := dec.ParseObjectFromBuffer(raw)
+		if err != nil {
+			return err
+		}
+		c, err := p.idx.format.Cid(p.idx.Hash(i))
+		if err != nil {
+			return err
+		}
+		if err := fn(c, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entry is the header of a packed object.
+type entry struct {
+	typ  ObjectType
+	size int64
+	// data is the offset of the compressed data.
+	data int64
+	// baseOffset is the offset of the base of an ObjOfsDelta.
+	baseOffset int64
+	// baseSha is the hash of the base of an ObjRefDelta.
+	baseSha []byte
+}
+
+func (p *Pack) readEntry(off int64) (entry, error) {
+	if off < packHeaderSize || off >= p.size {
+		return entry{}, fmt.Errorf("pack offset %d out of range", off)
+	}
+	br := &countingReader{r: bufio.NewReader(io.NewSectionReader(p.r, off, p.size-off))}
+
+	c, err := br.ReadByte()
+	if err != nil {
+		return entry{}, err
+	}
+	e := entry{typ: ObjectType(c>>4) & 7, size: int64(c & 0x0f)}
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if shift > 56 {
+			return entry{}, fmt.Errorf("object size at pack offset %d overflows", off)
+		}
+		if c, err = br.ReadByte(); err != nil {
+			return entry{}, err
+		}
+		e.size |= int64(c&0x7f) << shift
+	}
+
+	switch e.typ {
+	case ObjCommit, ObjTree, ObjBlob, ObjTag:
+	case ObjOfsDelta:
+		c, err := br.ReadByte()
+		if err != nil {
+			return entry{}, err
+		}
+		rel := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if rel >= 1<<56 {
+				return entry{}, fmt.Errorf("delta base offset at pack offset %d overflows", off)
+			}
+			if c, err = br.ReadByte(); err != nil {
+				return entry{}, err
+			}
+			rel = ((rel + 1) << 7) | int64(c&0x7f)
+		}
+		if rel <= 0 || rel > off {
+			return entry{}, fmt.Errorf("invalid delta base offset %d at pack offset %d", rel, off)
+		}
+		e.baseOffset = off - rel
+	case ObjRefDelta:
+		e.baseSha = make([]byte, p.idx.format.Size())
+		if _, err := io.ReadFull(br, e.baseSha); err != nil {
+			return entry{}, err
+		}
+	default:
+		return entry{}, fmt.Errorf("invalid object type %d at pack offset %d", e.typ, off)
+	}
+
+	e.data = off + br.n
+	return e, nil
+}
+
+// inflate decompresses the data of e, which must inflate to exactly e.size bytes.
+func (p *Pack) inflate(e entry) ([]byte, error) {
+	zr, err := zlib.NewReader(bufio.NewReader(io.NewSectionReader(p.r, e.data, p.size-e.data)))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	// The declared size is unverified until the data is inflated, so grow the
+	// buffer as it is read rather than reserving it up front.
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(zr, e.size+1))
+	if err != nil {
+		return nil, err
+	}
+	if n != e.size {
+		return nil, fmt.Errorf("object at pack offset %d inflates to %d bytes, expected %d", e.data, n, e.size)
+	}
+	return buf.Bytes(), nil
+}
+
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
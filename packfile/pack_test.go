@@ -0,0 +1,66 @@
+package packfile
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+func testPackObjects(t *testing.T, path string, opts Options, count int) *Pack {
+	p, err := Open(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { p.Close() })
+
+	if p.Index().Count() != count {
+		t.Fatalf("expected %d objects, got %d", count, p.Index().Count())
+	}
+
+	lp := cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA1.Prefix()}
+	ls := cidlink.DefaultLinkSystem()
+	seen := 0
+	err = p.ForEach(func(c cid.Cid, n ipld.Node) error {
+		if lnk := ls.MustComputeLink(lp, n); lnk.(cidlink.Link).Cid != c {
+			t.Fatalf("object %s encodes to %s", c, lnk)
+		}
+		if !p.Has(c) {
+			t.Fatalf("pack does not have %s", c)
+		}
+		if _, err := p.Get(c); err != nil {
+			t.Fatal(err)
+		}
+		seen++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != p.Index().Count() {
+		t.Fatalf("iterated %d objects of %d", seen, p.Index().Count())
+	}
+	return p
+}
+
+func TestPack(t *testing.T) {
+	p := testPackObjects(t, "testdata/nodelta.pack", Options{}, 24)
+
+	// The "Hello world" blob of the first commit in the test repository.
+	sha, _ := hex.DecodeString("802992c4220de19a90767f3000a79a31b98d0df7")
+	n, err := p.GetBySha(sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := n.AsBytes(); string(b) != "blob 12\x00Hello world\n" {
+		t.Fatalf("unexpected blob %q", b)
+	}
+
+	if _, err := p.GetBySha(make([]byte, 20)); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+#!/usr/bin/env bash
+
+# Packs the objects of the repository in ../../testdata.tar.gz, and of a
+# history of edits to a single file, for the packfile tests. Run from this
+# directory.
+
+set -ex
+CUR_DIR=$(pwd)
+TEST_DIR=$(mktemp -d)
+tar xzf ../../testdata.tar.gz -C ${TEST_DIR}
+export GIT_DIR=${TEST_DIR}/.git
+
+pack() {
+	NAME=$1
+	shift
+	SUM=$(git -c safe.directory='*' cat-file --batch-all-objects --batch-check='%(objectname)' |
+		git -c safe.directory='*' pack-objects -q "$@" ${TEST_DIR}/pack)
+	mv ${TEST_DIR}/pack-${SUM}.pack ${CUR_DIR}/${NAME}.pack
+	mv ${TEST_DIR}/pack-${SUM}.idx ${CUR_DIR}/${NAME}.idx
+}
+
+packrevs() {
+	NAME=$1
+	shift
+	SUM=$(git rev-list --objects --all | git pack-objects -q "$@" ${TEST_DIR}/pack)
+	mv ${TEST_DIR}/pack-${SUM}.pack ${CUR_DIR}/${NAME}.pack
+	mv ${TEST_DIR}/pack-${SUM}.idx ${CUR_DIR}/${NAME}.idx
+}
+
+# Every object stored whole
+pack nodelta --window=0 --depth=0
+
+# Versions of a file whose lines slide along, which git deltifies in a long
+# chain, once with offset deltas and once with reference deltas
+export GIT_DIR=${TEST_DIR}/edits/.git
+git init -q ${TEST_DIR}/edits
+export GIT_WORK_TREE=${TEST_DIR}/edits
+export GIT_AUTHOR_NAME="John Doe" GIT_AUTHOR_EMAIL=johndoe@example.com
+export GIT_COMMITTER_NAME="John Doe" GIT_COMMITTER_EMAIL=johndoe@example.com
+for i in $(seq 1 40); do
+	export GIT_AUTHOR_DATE="$((1500000000 + i)) +0000" GIT_COMMITTER_DATE="$((1500000000 + i)) +0000"
+	for n in $(seq $((i * 5)) $((i * 5 + 100))); do echo $n | sha1sum; done > ${TEST_DIR}/edits/file
+	git add file
+	git commit -q -m "Edit $i"
+done
+
+packrevs ofsdelta --window=10 --depth=50 --delta-base-offset
+packrevs refdelta --window=10 --depth=50
+
+rm -rf ${TEST_DIR}
@@ -0,0 +1,255 @@
+package packfile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strconv"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/linking"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// WriteOptions configures how a pack is written.
+type WriteOptions struct {
+	// ObjectFormat is the hash function of the objects written. When left as
+	// ObjectFormatDefault it is taken from the first link, and every other link
+	// must be in the same format.
+	ObjectFormat ipldgit.ObjectFormat
+	// DeltaWindow is the number of objects each object is compared with when
+	// searching for a delta base, like the --window of git pack-objects. Zero
+	// writes every object whole.
+	DeltaWindow int
+	// MaxDeltaDepth bounds the length of the delta chains written. Zero means
+	// DefaultMaxDeltaDepth.
+	MaxDeltaDepth int
+}
+
+// The delta search settings git pack-objects uses by default.
+const (
+	DefaultDeltaWindow   = 10
+	DefaultMaxDeltaDepth = 50
+)
+
+// Write writes the objects named by links to w as a version 2 pack, and returns
+// its index. Each object is loaded through ls and serialized with
+// ipldgit.Encode, and links named more than once are written once. Objects are
+// written in the order of links, unless a delta search is enabled.
+func Write(ctx context.Context, w io.Writer, ls *ipld.LinkSystem, links []ipld.Link, opts WriteOptions) (*Index, error) {
+	f := opts.ObjectFormat
+	if f == ipldgit.ObjectFormatDefault && len(links) > 0 {
+		c, err := linkCid(links[0])
+		if err != nil {
+			return nil, err
+		}
+		if f, err = ipldgit.ObjectFormatOf(c); err != nil {
+			return nil, err
+		}
+	}
+
+	var shas [][]byte
+	seen := make(map[string]struct{}, len(links))
+	for _, lnk := range links {
+		c, err := linkCid(lnk)
+		if err != nil {
+			return nil, err
+		}
+		sha, err := f.Sha(c)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[string(sha)]; ok {
+			continue
+		}
+		seen[string(sha)] = struct{}{}
+		shas = append(shas, sha)
+	}
+
+	pw := newPackWriter(w, f, len(shas))
+	if opts.DeltaWindow > 0 {
+		maxDepth := opts.MaxDeltaDepth
+		if maxDepth <= 0 {
+			maxDepth = DefaultMaxDeltaDepth
+		}
+		if err := pw.writeDeltified(ctx, ls, shas, opts.DeltaWindow, maxDepth); err != nil {
+			return nil, err
+		}
+		return pw.finish()
+	}
+	for _, sha := range shas {
+		typ, body, err := loadObject(ctx, ls, f, sha)
+		if err != nil {
+			return nil, err
+		}
+		if err := pw.writeObject(sha, typ, body); err != nil {
+			return nil, err
+		}
+	}
+	return pw.finish()
+}
+
+func linkCid(lnk ipld.Link) (cid.Cid, error) {
+	cl, ok := lnk.(cidlink.Link)
+	if !ok {
+		return cid.Undef, fmt.Errorf("unsupported link type %T", lnk)
+	}
+	return cl.Cid, nil
+}
+
+// loadObject loads the object with the given hash through ls, and returns its
+// type and its body as serialized by ipldgit.Encode.
+func loadObject(ctx context.Context, ls *ipld.LinkSystem, f ipldgit.ObjectFormat, sha []byte) (ObjectType, []byte, error) {
+	c, err := f.Cid(sha)
+	if err != nil {
+		return 0, nil, err
+	}
+	lnk := cidlink.Link{Cid: c}
+	raw, err := ls.LoadRaw(linking.LinkContext{Ctx: ctx}, lnk)
+	if err != nil {
+		return 0, nil, err
+	}
+	n, err := ipldgit.DecodeOptions{ObjectFormat: f}.ParseObjectFromBuffer(raw)
+	if err != nil {
+		return 0, nil, fmt.Errorf("decoding %s: %w", lnk, err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := (ipldgit.EncodeOptions{ObjectFormat: f}).Encode(n, buf); err != nil {
+		return 0, nil, fmt.Errorf("encoding %s: %w", lnk, err)
+	}
+	h := f.NewHash()
+	h.Write(buf.Bytes())
+	if !bytes.Equal(h.Sum(nil), sha) {
+		return 0, nil, fmt.Errorf("%s does not re-encode to the same object", lnk)
+	}
+	return splitObject(buf.Bytes())
+}
+
+// splitObject splits a loose object into its type and body.
+func splitObject(raw []byte) (ObjectType, []byte, error) {
+	hdr, body, ok := bytes.Cut(raw, []byte{0})
+	if !ok {
+		return 0, nil, fmt.Errorf("object has no header")
+	}
+	name, size, _ := bytes.Cut(hdr, []byte{' '})
+	if n, err := strconv.Atoi(string(size)); err != nil || n != len(body) {
+		return 0, nil, fmt.Errorf("object header %q does not match its %d byte body", hdr, len(body))
+	}
+	for _, typ := range []ObjectType{ObjCommit, ObjTree, ObjBlob, ObjTag} {
+		if string(name) == typ.String() {
+			return typ, body, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("unknown object type %q", name)
+}
+
+// packWriter writes the entries of a pack, keeping what is needed to index it.
+type packWriter struct {
+	w     *bufio.Writer
+	cw    *countingWriter
+	sum   hash.Hash
+	crc   hash.Hash32
+	zw    *zlib.Writer
+	count int
+	err   error
+
+	format  ipldgit.ObjectFormat
+	shas    [][]byte
+	crcs    []uint32
+	offsets []int64
+}
+
+func newPackWriter(w io.Writer, f ipldgit.ObjectFormat, count int) *packWriter {
+	pw := &packWriter{
+		format: f,
+		sum:    f.NewHash(),
+		crc:    crc32.NewIEEE(),
+		count:  count,
+	}
+	pw.cw = &countingWriter{w: w}
+	pw.w = bufio.NewWriter(io.MultiWriter(pw.cw, pw.sum, pw.crc))
+	pw.zw = zlib.NewWriter(pw.w)
+
+	pw.w.Write(packMagic)
+	binary.Write(pw.w, binary.BigEndian, uint32(2))
+	binary.Write(pw.w, binary.BigEndian, uint32(count))
+	return pw
+}
+
+// offset returns the offset the next entry will be written at.
+func (pw *packWriter) offset() int64 {
+	return pw.cw.n + int64(pw.w.Buffered())
+}
+
+// writeEntry writes an entry header, the extra header of a delta, and the
+// compressed data of the entry.
+func (pw *packWriter) writeEntry(sha []byte, typ ObjectType, extra []byte, data []byte) error {
+	if pw.err != nil {
+		return pw.err
+	}
+	if len(pw.shas) == pw.count {
+		return fmt.Errorf("pack already holds the %d objects it was started with", pw.count)
+	}
+	if err := pw.w.Flush(); err != nil {
+		pw.err = err
+		return err
+	}
+	off := pw.offset()
+	pw.crc.Reset()
+
+	size := uint64(len(data))
+	hdr := []byte{byte(typ)<<4 | byte(size&0x0f)}
+	for size >>= 4; size > 0; size >>= 7 {
+		hdr[len(hdr)-1] |= 0x80
+		hdr = append(hdr, byte(size&0x7f))
+	}
+	pw.w.Write(hdr)
+	pw.w.Write(extra)
+
+	pw.zw.Reset(pw.w)
+	pw.zw.Write(data)
+	if err := pw.zw.Close(); err != nil {
+		pw.err = err
+		return err
+	}
+	if err := pw.w.Flush(); err != nil {
+		pw.err = err
+		return err
+	}
+
+	pw.shas = append(pw.shas, sha)
+	pw.crcs = append(pw.crcs, pw.crc.Sum32())
+	pw.offsets = append(pw.offsets, off)
+	return nil
+}
+
+func (pw *packWriter) writeObject(sha []byte, typ ObjectType, body []byte) error {
+	return pw.writeEntry(sha, typ, nil, body)
+}
+
+// finish writes the trailing checksum of the pack and returns its index.
+func (pw *packWriter) finish() (*Index, error) {
+	if pw.err != nil {
+		return nil, pw.err
+	}
+	if len(pw.shas) != pw.count {
+		return nil, fmt.Errorf("pack was started with %d objects but %d were written", pw.count, len(pw.shas))
+	}
+	if err := pw.w.Flush(); err != nil {
+		return nil, err
+	}
+	packSum := pw.sum.Sum(nil)
+	if _, err := pw.cw.Write(packSum); err != nil {
+		return nil, err
+	}
+	return newIndex(pw.format, pw.shas, pw.crcs, pw.offsets, packSum), nil
+}
@@ -0,0 +1,187 @@
+package packfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+)
+
+// packLinkSystem returns a link system holding every object of the pack at
+// path, along with links to them in pack order.
+func packLinkSystem(t *testing.T, path string) (*ipld.LinkSystem, []ipld.Link) {
+	src, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	ls := cidlink.DefaultLinkSystem()
+	store := &memstore.Store{}
+	ls.SetReadStorage(store)
+	ls.SetWriteStorage(store)
+
+	var links []ipld.Link
+	err = src.ForEach(func(c cid.Cid, n ipld.Node) error {
+		lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: c.Prefix()}, n)
+		if err != nil {
+			return err
+		}
+		links = append(links, lnk)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ls, links
+}
+
+func TestWrite(t *testing.T) {
+	ls, links := packLinkSystem(t, "testdata/ofsdelta.pack")
+
+	dir := t.TempDir()
+	pack := new(bytes.Buffer)
+	// Naming every object twice must not duplicate them in the pack.
+	idx, err := Write(context.Background(), pack, ls, append(links, links...), WriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Count() != len(links) {
+		t.Fatalf("expected %d objects, got %d", len(links), idx.Count())
+	}
+	idxBuf := new(bytes.Buffer)
+	if _, err := idx.WriteTo(idxBuf); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "out.pack"), pack.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "out.idx"), idxBuf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testPackObjects(t, filepath.Join(dir, "out.pack"), Options{}, len(links))
+
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not found, not checking the pack with git")
+	}
+	// git index-pack must build the very same index from the pack.
+	cmd := exec.Command(git, "index-pack", "-o", filepath.Join(dir, "git.idx"), filepath.Join(dir, "out.pack"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git index-pack: %v\n%s", err, out)
+	}
+	gitIdx, err := os.ReadFile(filepath.Join(dir, "git.idx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gitIdx, idxBuf.Bytes()) {
+		t.Fatal("index written differs from the one git builds")
+	}
+	cmd = exec.Command(git, "verify-pack", filepath.Join(dir, "out.idx"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git verify-pack: %v\n%s", err, out)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	ls, links := packLinkSystem(t, "testdata/nodelta.pack")
+
+	missing, _ := ipldgit.ObjectFormatSHA1.Cid(make([]byte, 20))
+	_, err := Write(context.Background(), new(bytes.Buffer), ls, append(links, cidlink.Link{Cid: missing}), WriteOptions{})
+	if err == nil {
+		t.Fatal("expected an error writing a missing object")
+	}
+
+	_, err = Write(context.Background(), new(bytes.Buffer), ls, links, WriteOptions{ObjectFormat: ipldgit.ObjectFormatSHA256})
+	if err == nil || !strings.Contains(err.Error(), "sha1") {
+		t.Fatalf("expected an object format error, got %v", err)
+	}
+}
+
+func TestWriteDeltas(t *testing.T) {
+	ls, links := packLinkSystem(t, "testdata/ofsdelta.pack")
+
+	whole := new(bytes.Buffer)
+	if _, err := Write(context.Background(), whole, ls, links, WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maxDepth := range []int{1, 3, 0} {
+		t.Run(fmt.Sprintf("depth=%d", maxDepth), func(t *testing.T) {
+			dir := t.TempDir()
+			pack := new(bytes.Buffer)
+			idx, err := Write(context.Background(), pack, ls, links, WriteOptions{
+				DeltaWindow:   DefaultDeltaWindow,
+				MaxDeltaDepth: maxDepth,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pack.Len() >= whole.Len()/2 {
+				t.Fatalf("pack with deltas is %d bytes, against %d without", pack.Len(), whole.Len())
+			}
+
+			p, err := New(bytes.NewReader(pack.Bytes()), int64(pack.Len()), idx, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if maxDepth == 0 {
+				maxDepth = DefaultMaxDeltaDepth
+			}
+			deltas := 0
+			for i := range idx.Count() {
+				depth := 0
+				for off := idx.Offset(i); ; depth++ {
+					e, err := p.readEntry(off)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if e.typ != ObjOfsDelta {
+						break
+					}
+					off = e.baseOffset
+				}
+				if depth > maxDepth {
+					t.Fatalf("object %d has a delta chain %d deep", i, depth)
+				}
+				if depth > 0 {
+					deltas++
+				}
+			}
+			if deltas == 0 {
+				t.Fatal("no object was deltified")
+			}
+
+			if err := os.WriteFile(filepath.Join(dir, "out.pack"), pack.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Create(filepath.Join(dir, "out.idx"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := idx.WriteTo(f); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+			testPackObjects(t, filepath.Join(dir, "out.pack"), Options{}, len(links))
+
+			if git, err := exec.LookPath("git"); err == nil {
+				cmd := exec.Command(git, "verify-pack", filepath.Join(dir, "out.idx"))
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git verify-pack: %v\n%s", err, out)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,18 @@ package ipldgit
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// Errors of the date and timezone of a PersonInfo that cannot be read as a
+// time, wrapped with the offending value.
+var (
+	ErrInvalidDate     = errors.New("invalid person date")
+	ErrInvalidTimezone = errors.New("invalid person timezone")
 )
 
 func parsePersonInfo(line []byte) (PersonInfo, error) {
@@ -87,3 +97,76 @@ func (p _PersonInfo) GitString() string {
 	}
 	return fmt.Sprintf(f, arg...)
 }
+
+// NewPersonInfo returns the person info of someone acting at time t, with the
+// date in seconds since the epoch and the timezone of the offset of t, to the
+// minute. The name and email may not contain newlines or angle brackets, and
+// t may not be before the epoch, which git does not support.
+func NewPersonInfo(name, email string, t time.Time) (PersonInfo, error) {
+	if err := checkNameEmail(name, email); err != nil {
+		return nil, err
+	}
+	if t.Unix() < 0 {
+		return nil, fmt.Errorf("%w: %s is before the epoch", ErrInvalidDate, t)
+	}
+
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	offset /= 60
+	return &_PersonInfo{
+		name:     _String{name},
+		email:    _String{email},
+		date:     _String{strconv.FormatInt(t.Unix(), 10)},
+		timezone: _String{fmt.Sprintf("%c%02d%02d", sign, offset/60, offset%60)},
+	}, nil
+}
+
+// checkNameEmail checks that a name and email can be written in a person
+// header and read back as they are.
+func checkNameEmail(name, email string) error {
+	if strings.ContainsAny(name, "<>\n") || strings.TrimSpace(name) != name {
+		return fmt.Errorf("invalid person name %q", name)
+	}
+	if strings.ContainsAny(email, "<>\n") {
+		return fmt.Errorf("invalid person email %q", email)
+	}
+	return nil
+}
+
+// Time returns the time of the person info, in a fixed zone of its timezone.
+func (p _PersonInfo) Time() (time.Time, error) {
+	if p.date.x == "" || strings.Trim(p.date.x, "0123456789") != "" {
+		return time.Time{}, fmt.Errorf("%w %q: not a number of seconds", ErrInvalidDate, p.date.x)
+	}
+	secs, err := strconv.ParseInt(p.date.x, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w %q: out of range", ErrInvalidDate, p.date.x)
+	}
+	loc, err := p.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(secs, 0).In(loc), nil
+}
+
+// Location returns the fixed zone of the timezone of the person info, such as
+// "+0130", named after it.
+func (p _PersonInfo) Location() (*time.Location, error) {
+	tz := p.timezone.x
+	if len(tz) != 5 || (tz[0] != '+' && tz[0] != '-') || strings.Trim(tz[1:], "0123456789") != "" {
+		return nil, fmt.Errorf("%w %q: not in the form +hhmm", ErrInvalidTimezone, tz)
+	}
+	hours, _ := strconv.Atoi(tz[1:3])
+	minutes, _ := strconv.Atoi(tz[3:])
+	if minutes >= 60 {
+		return nil, fmt.Errorf("%w %q: minutes out of range", ErrInvalidTimezone, tz)
+	}
+	offset := (hours*60 + minutes) * 60
+	if tz[0] == '-' {
+		offset = -offset
+	}
+	return time.FixedZone(tz, offset), nil
+}
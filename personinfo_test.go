@@ -0,0 +1,79 @@
+package ipldgit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPersonInfoTime(t *testing.T) {
+	for _, test := range []struct {
+		date, timezone string
+		want           time.Time
+		err            error
+	}{
+		{"1700000000", "+0000", time.Unix(1700000000, 0), nil},
+		{"1700000000", "+0530", time.Unix(1700000000, 0), nil},
+		{"1700000000", "-0800", time.Unix(1700000000, 0), nil},
+		{"0", "+0000", time.Unix(0, 0), nil},
+		{"1700000000", "+05", time.Time{}, ErrInvalidTimezone},
+		{"1700000000", "+0575", time.Time{}, ErrInvalidTimezone},
+		{"1700000000", "0530", time.Time{}, ErrInvalidTimezone},
+		{"1700000000", "", time.Time{}, ErrInvalidTimezone},
+		{"99999999999999999999", "+0000", time.Time{}, ErrInvalidDate},
+		{"-1", "+0000", time.Time{}, ErrInvalidDate},
+		{"17e8", "+0000", time.Time{}, ErrInvalidDate},
+		{"", "", time.Time{}, ErrInvalidDate},
+	} {
+		pi, err := parsePersonInfo([]byte("author A U Thor <author@example.com> " + test.date + " " + test.timezone))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := pi.Time()
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s %s: expected %v, got %v", test.date, test.timezone, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: %v", test.date, test.timezone, err)
+			continue
+		}
+		if !got.Equal(test.want) || got.Format("-0700") != test.timezone {
+			t.Errorf("%s %s: got %s", test.date, test.timezone, got)
+		}
+	}
+}
+
+func TestNewPersonInfo(t *testing.T) {
+	at := time.Date(2023, 11, 14, 22, 13, 20, 0, time.FixedZone("", -(3*60+30)*60))
+	pi, err := NewPersonInfo("A U Thor", "author@example.com", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pi.GitString(); got != "A U Thor <author@example.com> 1700012600 -0330" {
+		t.Fatalf("got %q", got)
+	}
+	if got, err := pi.Time(); err != nil || !got.Equal(at) || got.Format("-0700") != "-0330" {
+		t.Fatalf("got %s, %v", got, err)
+	}
+	if back, err := parsePersonInfo([]byte("author " + pi.GitString())); err != nil || back.GitString() != pi.GitString() {
+		t.Fatalf("person info does not parse back: %v", err)
+	}
+
+	for _, test := range []struct {
+		name, email string
+		at          time.Time
+	}{
+		{"A <U> Thor", "author@example.com", at},
+		{"A U\nThor", "author@example.com", at},
+		{" A U Thor", "author@example.com", at},
+		{"A U Thor", "author>@example.com", at},
+		{"A U Thor", "author@example.com", time.Unix(-1, 0)},
+	} {
+		if _, err := NewPersonInfo(test.name, test.email, test.at); err == nil {
+			t.Errorf("expected an error for %q <%q> %s", test.name, test.email, test.at)
+		}
+	}
+}
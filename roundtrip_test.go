@@ -0,0 +1,330 @@
+package ipldgit
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// testRoundTrip checks that the loose object raw encodes back to itself.
+func testRoundTrip(t *testing.T, raw []byte) {
+	t.Helper()
+	n, err := ParseObjectFromBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := Encode(n, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), raw) {
+		t.Fatalf("object does not round-trip:\n%q\nencodes to\n%q", raw, buf.Bytes())
+	}
+}
+
+func TestRoundTripArchive(t *testing.T) {
+	archive, err := os.Open("testdata.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(gz)
+	count := 0
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts := strings.Split(hdr.Name, "/")
+		if hdr.Typeflag != tar.TypeReg || !strings.HasPrefix(hdr.Name, ".git/objects/") || len(parts[2]) != 2 {
+			continue
+		}
+
+		zr, err := zlib.NewReader(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(parts[2]+parts[3], func(t *testing.T) {
+			testRoundTrip(t, raw)
+		})
+		count++
+	}
+	if count == 0 {
+		t.Fatal("no objects in the archive")
+	}
+}
+
+const (
+	rtTree   = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"
+	rtParent = "parent 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\n"
+	rtAuthor = "author A U Thor <author@example.com> 1700000000 +0100\n"
+	rtComm   = "committer C O Mitter <committer@example.com> 1700000001 -0500\n"
+	rtSig    = "gpgsig -----BEGIN PGP SIGNATURE-----\n \n iQEzBAABCAAdFiEE\n =abcd\n -----END PGP SIGNATURE-----\n"
+	rtTag    = "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntype commit\ntag v1.0\n"
+	rtTagger = "tagger T Agger <tagger@example.com> 1700000002 +0000\n"
+)
+
+func object(typ, body string) []byte {
+	return fmt.Appendf(nil, "%s %d\x00%s", typ, len(body), body)
+}
+
+func TestRoundTripCommits(t *testing.T) {
+	mergetag := "mergetag object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\n type commit\n tag v1.0\n tagger T Agger <tagger@example.com> 1700000002 +0000\n \n Release 1.0\n -----BEGIN PGP SIGNATURE-----\n \n iQEzBAABCAAdFiEE\n -----END PGP SIGNATURE-----\n"
+	for _, test := range []struct {
+		name, body string
+	}{
+		{"Plain", rtTree + rtParent + rtAuthor + rtComm + "\nmessage\n"},
+		{"NoParent", rtTree + rtAuthor + rtComm + "\nmessage\n"},
+		{"EmptyMessage", rtTree + rtAuthor + rtComm + "\n"},
+		{"NoMessage", rtTree + rtAuthor + rtComm + "\nno newline"},
+		{"NoAuthor", rtTree + rtComm + "\nmessage\n"},
+		{"NoCommitter", rtTree + rtAuthor + "\nmessage\n"},
+		{"NoPeople", rtTree + "\nmessage\n"},
+		{"Encoding", rtTree + rtAuthor + rtComm + "encoding ISO-8859-1\n\nmessage\n"},
+		{"EncodingWithoutValue", rtTree + rtAuthor + rtComm + "encoding\n\nmessage\n"},
+		{"EncodingWithEmptyValue", rtTree + rtAuthor + rtComm + "encoding \n\nmessage\n"},
+		{"Signed", rtTree + rtParent + rtAuthor + rtComm + rtSig + "\nmessage\n"},
+		{"SHA256SignatureFirst", rtTree + rtAuthor + rtComm + strings.Replace(rtSig, "gpgsig", "gpgsig-sha256", 1) + rtSig + "\nmessage\n"},
+		{"OtherBeforeSignature", rtTree + rtAuthor + rtComm + "extra value\n" + rtSig + "\nmessage\n"},
+		{"OtherBeforeTree", "extra value\n" + rtTree + rtAuthor + rtComm + "\nmessage\n"},
+		{"MultilineOther", rtTree + rtAuthor + rtComm + "extra first\n second\n \n third\n\nmessage\n"},
+		{"OtherWithoutValue", rtTree + rtAuthor + rtComm + "extra\nextra \nextra  two\n\nmessage\n"},
+		{"X509Signature", rtTree + rtAuthor + rtComm + "gpgsig -----BEGIN SIGNED MESSAGE-----\n MIAGCSqGSIb3DQEHAqCAMIACAQEx\n -----END SIGNED MESSAGE-----\n\nmessage\n"},
+		{"EmptySignature", rtTree + rtAuthor + rtComm + "gpgsig\n\nmessage\n"},
+		{"SSHSignature", rtTree + rtAuthor + rtComm + "gpgsig -----BEGIN SSH SIGNATURE-----\n U1NIU0lHAAAAAQ==\n -----END SSH SIGNATURE-----\n\nmessage\n"},
+		{"SHA256Signature", rtTree + rtAuthor + rtComm + rtSig + strings.Replace(rtSig, "gpgsig", "gpgsig-sha256", 1) + "\nmessage\n"},
+		{"CommitterFirst", rtTree + rtComm + rtAuthor + "\nmessage\n"},
+		{"ParentFirst", rtParent + rtTree + rtAuthor + rtComm + "\nmessage\n"},
+		{"DuplicateAuthor", rtTree + rtAuthor + rtAuthor + rtComm + "\nmessage\n"},
+		{"DuplicateTree", rtTree + rtTree + rtAuthor + rtComm + "\nmessage\n"},
+		{"OddPersonSpacing", rtTree + "author A U Thor <author@example.com>  1700000000 +0100\n" + rtComm + "\nmessage\n"},
+		{"Mergetag", rtTree + rtParent + rtParent + rtAuthor + rtComm + mergetag + "\nMerge tag 'v1.0'\n"},
+		{"MergetagAfterSignature", rtTree + rtAuthor + rtComm + rtSig + mergetag + "\nmessage\n"},
+		{"MergetagWithoutTagger", rtTree + rtAuthor + rtComm + "mergetag object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\n type commit\n tag v1.0\n \n Release\n\nmessage\n"},
+		{"MergetagWithoutObject", rtTree + rtAuthor + rtComm + "mergetag type commit\n tag v1.0\n\nmessage\n"},
+		{"MergetagWithoutMessage", rtTree + rtAuthor + rtComm + "mergetag object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\n type commit\n tag v1.0\n\nmessage\n"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testRoundTrip(t, object("commit", test.body))
+		})
+	}
+}
+
+func TestRoundTripTags(t *testing.T) {
+	for _, test := range []struct {
+		name, body string
+	}{
+		{"Plain", rtTag + rtTagger + "\nRelease\n"},
+		{"EmptyMessage", rtTag + rtTagger + "\n"},
+		{"NoTagger", rtTag + "\nRelease\n"},
+		{"Signed", rtTag + rtTagger + "\nRelease\n-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"},
+		{"SignedTwice", rtTag + rtTagger + "\nRelease\n-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----\n-----BEGIN SSH SIGNATURE-----\n-----END SSH SIGNATURE-----\n"},
+		{"SignatureWithoutMessage", rtTag + rtTagger + "\n-----BEGIN SSH SIGNATURE-----\nU1NIU0lHAAAAAQ==\n-----END SSH SIGNATURE-----"},
+		{"ArmorInLine", rtTag + rtTagger + "\nRelease -----BEGIN PGP SIGNATURE-----\n"},
+		{"SHA256Signature", rtTag + rtTagger + strings.Replace(rtSig, "gpgsig", "gpgsig-sha256", 1) + "\nRelease\n"},
+		{"Other", rtTag + rtTagger + "extra value\n\nRelease\n"},
+		{"MultilineOther", rtTag + "extra first\n second\n" + rtTagger + "\nRelease\n"},
+		{"TypeWithoutValue", "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntype\ntype commit\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"TagWithoutValue", "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntype commit\ntag\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"TypeFirst", "type commit\nobject 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"OddPersonSpacing", rtTag + "tagger T Agger  <tagger@example.com> 1700000002 +0000\n\nRelease\n"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testRoundTrip(t, object("tag", test.body))
+		})
+	}
+}
+
+// Objects missing headers, or the blank line after them, that encoding always
+// writes cannot round-trip, so they fail to decode.
+func TestRoundTripMissingHeaders(t *testing.T) {
+	for _, test := range []struct {
+		name, typ, body string
+	}{
+		{"CommitWithoutTree", "commit", rtParent + rtAuthor + rtComm + "\nmessage\n"},
+		{"CommitWithoutHeaders", "commit", "\nmessage\n"},
+		{"TagWithoutObject", "tag", "type commit\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"TagWithoutType", "tag", "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"TagWithoutName", "tag", "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntype commit\n" + rtTagger + "\nRelease\n"},
+		{"TagWithEmptyType", "tag", "object 2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51\ntype\ntag v1.0\n" + rtTagger + "\nRelease\n"},
+		{"CommitWithoutBlankLine", "commit", rtTree + rtAuthor + rtComm},
+		{"CommitEndingInHeader", "commit", rtTree + rtAuthor + "committer C O Mitter <committer@example.com> 1700000001 -0500"},
+		{"TagWithoutBlankLine", "tag", rtTag + rtTagger},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseObjectFromBuffer(object(test.typ, test.body)); err == nil || !strings.Contains(err.Error(), " header") {
+				t.Fatalf("expected a missing header error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHeaderOrderMismatch(t *testing.T) {
+	n, err := ParseObjectFromBuffer(object("commit", rtTree+rtComm+rtAuthor+"\nmessage\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := n.(Commit)
+	c.headerOrder.v.x = c.headerOrder.v.x[:2]
+	if err := Encode(c, io.Discard); err == nil || !strings.Contains(err.Error(), "headerOrder") {
+		t.Fatalf("expected a headerOrder error, got %v", err)
+	}
+}
+
+func TestRoundTripFields(t *testing.T) {
+	n, err := ParseObjectFromBuffer(object("commit", rtTree+rtAuthor+rtComm+"extra value\n"+rtSig+
+		"mergetag "+strings.ReplaceAll(strings.TrimSuffix(rtTag+"\nRelease\n", "\n"), "\n", "\n ")+"\n\nmessage\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := n.(Commit)
+	if len(c.signatures.x) != 1 || len(c.mergetag.x) != 1 || c.headerOrder.m != schema.Maybe_Value {
+		t.Fatalf("unexpected commit %#v", c)
+	}
+	if got := c.mergetag.x[0]; got.tagger.m != schema.Maybe_Absent || got.message.x != "Release\n" {
+		t.Fatalf("unexpected mergetag %#v", got)
+	}
+	if len(c.other.x) != 1 || c.other.x[0].key.x != "extra" || c.other.x[0].value.x != "value" {
+		t.Fatalf("unexpected other headers %#v", c.other.x)
+	}
+
+	n, err = ParseObjectFromBuffer(object("tag", rtTag+"tagger T Agger <tagger@example.com>  1 +0000\n\nRelease\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag := n.(Tag)
+	if tag.tagger.m != schema.Maybe_Absent || tag.other.m != schema.Maybe_Value || tag.headerOrder.m != schema.Maybe_Absent {
+		t.Fatalf("unexpected tag %#v", tag)
+	}
+}
+
+func TestOtherHeaders(t *testing.T) {
+	n, err := ParseObjectFromBuffer(object("commit", rtTree+rtAuthor+"x-first one\n"+rtComm+
+		"x-note -----BEGIN NOTE-----\n \n iQEz\n -----END NOTE-----\n"+
+		"x-bare\n\nmessage\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := n.(Commit)
+	want := []_Header{
+		{_String{"x-first"}, _String{"one"}},
+		{_String{"x-note"}, _String{"-----BEGIN NOTE-----\n\niQEz\n-----END NOTE-----"}},
+		{_String{"x-bare"}, _String{""}},
+	}
+	if len(c.other.x) != len(want) {
+		t.Fatalf("got %d other headers, want %d", len(c.other.x), len(want))
+	}
+	for i, h := range c.other.x {
+		if h != want[i] {
+			t.Errorf("header %d is %q: %q, want %q: %q", i, h.key.x, h.value.x, want[i].key.x, want[i].value.x)
+		}
+	}
+
+	// Headers added to other are written in place of the "other" entries of
+	// headerOrder.
+	c.other.x[0].value.x = "changed\non two lines"
+	buf := new(bytes.Buffer)
+	if err := Encode(c, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), rtAuthor+"x-first changed\n on two lines\n"+rtComm) {
+		t.Fatalf("header not written in place:\n%s", buf)
+	}
+}
+
+func TestSignatures(t *testing.T) {
+	ssh := "-----BEGIN SSH SIGNATURE-----\nU1NIU0lHAAAAAQ==\n-----END SSH SIGNATURE-----"
+	x509 := "-----BEGIN SIGNED MESSAGE-----\nMIAGCSqGSIb3DQEHAqCAMIACAQEx\n-----END SIGNED MESSAGE-----"
+	body := rtTree + rtAuthor + rtComm +
+		"gpgsig " + foldHeader(ssh) + "\n" +
+		"gpgsig-sha256 " + foldHeader(x509) + "\n" +
+		strings.Replace(rtSig, "gpgsig", "gpgsig-sha256", 1) +
+		"gpgsig -----BEGIN UNKNOWN-----\n -----END UNKNOWN-----\n" +
+		"\nmessage\n"
+	n, err := ParseObjectFromBuffer(object("commit", body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := n.(Commit)
+	want := []struct {
+		header string
+		format SignatureFormat
+		text   string
+	}{
+		{"gpgsig", SignatureSSH, ssh},
+		{"gpgsig-sha256", SignatureX509, x509},
+		{"gpgsig-sha256", SignatureOpenPGP, "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n=abcd\n-----END PGP SIGNATURE-----"},
+		{"gpgsig", SignatureUnknown, "-----BEGIN UNKNOWN-----\n-----END UNKNOWN-----"},
+	}
+	if len(c.signatures.x) != len(want) {
+		t.Fatalf("got %d signatures, want %d", len(c.signatures.x), len(want))
+	}
+	for i, sig := range c.signatures.x {
+		if sig.header.x != want[i].header || sig.Format() != want[i].format || sig.text.x != want[i].text {
+			t.Errorf("signature %d is %s %s %q, want %s %s %q", i, sig.header.x, sig.Format(), sig.text.x, want[i].header, want[i].format, want[i].text)
+		}
+	}
+
+	c.signatures.x[0].header.x = "x-sig"
+	if err := Encode(c, io.Discard); err == nil || !strings.Contains(err.Error(), "signature header") {
+		t.Fatalf("expected a signature header error, got %v", err)
+	}
+}
+
+func TestTagSignature(t *testing.T) {
+	sig := "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"
+	n, err := ParseObjectFromBuffer(object("tag", rtTag+rtTagger+"\nRelease\n"+sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag := n.(Tag)
+	if tag.message.x != "Release\n" || tag.signature.m != schema.Maybe_Value || tag.signature.v.x != sig {
+		t.Fatalf("got message %q and signature %q", tag.message.x, tag.signature.v.x)
+	}
+
+	mergetag := "mergetag " + foldHeader(strings.TrimSuffix(rtTag+rtTagger+"\nRelease\n"+sig, "\n")) + "\n"
+	n, err = ParseObjectFromBuffer(object("commit", rtTree+rtAuthor+rtComm+mergetag+"\nmessage\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := n.(Commit).mergetag.x[0]
+	if merged.message.x != "Release\n" || merged.signature.v.x != sig {
+		t.Fatalf("got mergetag message %q and signature %q", merged.message.x, merged.signature.v.x)
+	}
+
+	for _, test := range []struct {
+		name, message, signature string
+	}{
+		{"Empty", "Release\n", ""},
+		{"NotArmored", "Release\n", "signature\n"},
+		{"MidLine", "Release", sig},
+		{"ArmorAfter", "Release\n", sig + sig},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tag.message.x, tag.signature.v.x = test.message, test.signature
+			if err := Encode(tag, io.Discard); err == nil || !strings.Contains(err.Error(), "tag signature") {
+				t.Fatalf("expected a tag signature error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ipldgit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignatureFormat is the kind of signature a Signature holds, named like the
+// gpg.format settings of git.
+type SignatureFormat uint8
+
+const (
+	// SignatureUnknown is a signature whose armor is not recognised.
+	SignatureUnknown SignatureFormat = iota
+	// SignatureOpenPGP is a PGP signature, as made by gpg.
+	SignatureOpenPGP
+	// SignatureX509 is a CMS signature, as made by gpgsm.
+	SignatureX509
+	// SignatureSSH is an SSHSIG signature, as made by ssh-keygen -Y sign.
+	SignatureSSH
+)
+
+func (f SignatureFormat) String() string {
+	switch f {
+	case SignatureOpenPGP:
+		return "openpgp"
+	case SignatureX509:
+		return "x509"
+	case SignatureSSH:
+		return "ssh"
+	default:
+		return "unknown"
+	}
+}
+
+// The headers holding signatures: gpgsig signs the SHA-1 form of an object, and
+// gpgsig-sha256 its SHA-256 form, so the commits of a SHA-256 repository are
+// signed in gpgsig-sha256.
+const (
+	SignatureHeader       = "gpgsig"
+	SignatureHeaderSHA256 = "gpgsig-sha256"
+)
+
+// signatureArmors are the beginnings of the signatures of each format, as git
+// recognises them.
+var signatureArmors = []struct {
+	begin  string
+	format SignatureFormat
+}{
+	{"-----BEGIN PGP SIGNATURE-----", SignatureOpenPGP},
+	{"-----BEGIN PGP MESSAGE-----", SignatureOpenPGP},
+	{"-----BEGIN SIGNED MESSAGE-----", SignatureX509},
+	{"-----BEGIN SSH SIGNATURE-----", SignatureSSH},
+}
+
+// Format returns the format of the signature, told by the first line of its
+// armor.
+func (s Signature) Format() SignatureFormat {
+	return SignatureFormatOf(s.text.x)
+}
+
+// SignatureFormatOf returns the format of an armored signature, which git tells
+// from the way it begins.
+func SignatureFormatOf(armor string) SignatureFormat {
+	for _, a := range signatureArmors {
+		if strings.HasPrefix(armor, a.begin) {
+			return a.format
+		}
+	}
+	return SignatureUnknown
+}
+
+// decodeSignatureHeader parses a gpgsig or gpgsig-sha256 header, whose value
+// is the armored signature folded over continuation lines.
+func decodeSignatureHeader(h string) (_Signature, bool) {
+	key, value, _ := strings.Cut(h, " ")
+	if (key != SignatureHeader && key != SignatureHeaderSHA256) || value == "" {
+		return _Signature{}, false
+	}
+	return _Signature{header: _String{key}, text: _String{unfoldHeader(value)}}, true
+}
+
+func (s _Signature) gitString() (string, error) {
+	if s.header.x != SignatureHeader && s.header.x != SignatureHeaderSHA256 {
+		return "", fmt.Errorf("invalid signature header %q", s.header.x)
+	}
+	if s.text.x == "" {
+		return "", fmt.Errorf("empty %s signature", s.header.x)
+	}
+	return s.header.x + " " + foldHeader(s.text.x), nil
+}
+
+// splitTagMessage separates the signature that ends the message of a tag, which
+// git takes to start at the last line beginning with the armor of a known
+// format.
+func splitTagMessage(message string) (string, string) {
+	match := -1
+	for i := 0; i < len(message); {
+		if SignatureFormatOf(message[i:]) != SignatureUnknown {
+			match = i
+		}
+		end := strings.IndexByte(message[i:], '\n')
+		if end < 0 {
+			break
+		}
+		i += end + 1
+	}
+	if match < 0 {
+		return message, ""
+	}
+	return message[:match], message[match:]
+}
+
+// joinTagMessage appends the signature of a tag to its message, checking that
+// the signature would be split off the message again.
+func joinTagMessage(message, signature string) (string, error) {
+	if signature == "" {
+		return "", fmt.Errorf("empty tag signature")
+	}
+	joined := message + signature
+	if _, sig := splitTagMessage(joined); sig != signature {
+		return "", fmt.Errorf("tag signature %q does not begin the last armored block of the message", signature)
+	}
+	return joined, nil
+}
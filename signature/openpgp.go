@@ -0,0 +1,104 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	ipldgit "github.com/ipfs/go-ipld-git"
+)
+
+// OpenPGPVerifier checks OpenPGP signatures against a keyring. Like gpg, it
+// checks that the signing key is not expired or revoked at the current time,
+// rather than at the time the object was signed.
+type OpenPGPVerifier struct {
+	keyring openpgp.EntityList
+	now     func() time.Time
+}
+
+var _ Verifier = (*OpenPGPVerifier)(nil)
+
+// NewOpenPGPVerifier reads a keyring of public keys, armored or not, such as
+// the output of gpg --export.
+func NewOpenPGPVerifier(keyring io.Reader) (*OpenPGPVerifier, error) {
+	data, err := io.ReadAll(keyring)
+	if err != nil {
+		return nil, err
+	}
+	var el openpgp.EntityList
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN ")) {
+		el, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	} else {
+		el, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenPGP keyring: %w", err)
+	}
+	return &OpenPGPVerifier{keyring: el, now: time.Now}, nil
+}
+
+func (*OpenPGPVerifier) Format() ipldgit.SignatureFormat {
+	return ipldgit.SignatureOpenPGP
+}
+
+func (v *OpenPGPVerifier) Verify(payload, signature []byte, _ time.Time) *Result {
+	res := &Result{Format: ipldgit.SignatureOpenPGP}
+
+	block, err := armor.Decode(bytes.NewReader(signature))
+	if err != nil {
+		res.Err = fmt.Errorf("%w: %v", ErrBadSignature, err)
+		return res
+	}
+	sigData, err := io.ReadAll(block.Body)
+	if err != nil {
+		res.Err = fmt.Errorf("%w: %v", ErrBadSignature, err)
+		return res
+	}
+
+	// Name the key from the signature itself, so that unknown keys are
+	// reported too.
+	if p, err := packet.NewReader(bytes.NewReader(sigData)).Next(); err == nil {
+		if sig, ok := p.(*packet.Signature); ok {
+			switch {
+			case sig.IssuerFingerprint != nil:
+				res.Fingerprint = fmt.Sprintf("%X", sig.IssuerFingerprint)
+			case sig.IssuerKeyId != nil:
+				res.Fingerprint = fmt.Sprintf("%016X", *sig.IssuerKeyId)
+			}
+		}
+	}
+
+	config := &packet.Config{Time: v.now}
+	sig, signer, err := openpgp.VerifyDetachedSignature(v.keyring, bytes.NewReader(payload), bytes.NewReader(sigData), config)
+	if signer != nil {
+		if id := signer.PrimaryIdentity(); id != nil {
+			res.Signer = id.Name
+		}
+		if sig != nil && sig.IssuerKeyId != nil {
+			for _, k := range v.keyring.KeysById(*sig.IssuerKeyId) {
+				if k.Entity == signer {
+					res.Fingerprint = fmt.Sprintf("%X", k.PublicKey.Fingerprint)
+				}
+			}
+		}
+	}
+	switch {
+	case err == nil:
+		res.Valid = true
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		res.Err = ErrUnknownKey
+	case signer != nil:
+		// The signature is good, but the key or the signature has expired or
+		// been revoked.
+		res.Err = fmt.Errorf("%w: %v", ErrKeyNotValid, err)
+	default:
+		res.Err = fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+// Package signature checks the signatures of git commits and tags, separating
+// them from the bytes they sign the way git does.
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// ErrUnsigned is returned for commits and tags without a signature over the
+// object format they are in.
+var ErrUnsigned = errors.New("object is not signed")
+
+// Payload returns the bytes the signature of a commit or tag signs, and the
+// armored signature. Commit signatures are taken from the header for the
+// object format of the commit, gpgsig or gpgsig-sha256, and the payload is the
+// commit without its signature headers. Tag signatures are the signature split
+// off the end of the message, and the payload is the tag up to it.
+func Payload(n ipld.Node) (payload, signature []byte, err error) {
+	typ, body, err := encode(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch typ {
+	case "commit":
+		f, err := linkFormat(n, "tree")
+		if err != nil {
+			return nil, nil, err
+		}
+		header := ipldgit.SignatureHeader
+		if f == ipldgit.ObjectFormatSHA256 {
+			header = ipldgit.SignatureHeaderSHA256
+		}
+		payload, signature = splitCommit(body, header)
+	case "tag":
+		sig, err := tagSignature(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if sig != "" {
+			payload, signature = dropSignatureHeaders(body[:len(body)-len(sig)]), []byte(sig)
+		}
+	default:
+		return nil, nil, fmt.Errorf("a %s cannot be signed", typ)
+	}
+	if signature == nil {
+		return nil, nil, ErrUnsigned
+	}
+	return payload, signature, nil
+}
+
+// encode returns the type of the object n and its body.
+func encode(n ipld.Node) (string, []byte, error) {
+	var buf bytes.Buffer
+	if err := ipldgit.Encode(n, &buf); err != nil {
+		return "", nil, err
+	}
+	hdr, body, ok := bytes.Cut(buf.Bytes(), []byte{0})
+	if !ok {
+		return "", nil, errors.New("object has no header")
+	}
+	typ, _, _ := strings.Cut(string(hdr), " ")
+	return typ, body, nil
+}
+
+func linkFormat(n ipld.Node, field string) (ipldgit.ObjectFormat, error) {
+	ln, err := n.LookupByString(field)
+	if err != nil {
+		return 0, err
+	}
+	lnk, err := ln.AsLink()
+	if err != nil {
+		return 0, err
+	}
+	cl, ok := lnk.(cidlink.Link)
+	if !ok {
+		return 0, fmt.Errorf("unsupported link type %T", lnk)
+	}
+	return ipldgit.ObjectFormatOf(cl.Cid)
+}
+
+// splitCommit separates the signature in the given header from the rest of a
+// commit, dropping the other signature headers as git does.
+func splitCommit(body []byte, header string) (payload, signature []byte) {
+	var inSignature, otherSignature bool
+	for rest := body; len(rest) > 0; {
+		line := nextLine(&rest)
+		switch {
+		case inSignature && line[0] == ' ':
+			signature = append(signature, line[1:]...)
+		case bytes.HasPrefix(line, []byte(header+" ")):
+			signature = append(signature, line[len(header)+1:]...)
+			inSignature, otherSignature = true, false
+		case bytes.HasPrefix(line, []byte("gpgsig")):
+			inSignature, otherSignature = false, true
+		case otherSignature && line[0] == ' ':
+		case line[0] == '\n':
+			// The message follows, and is signed as it is.
+			payload = append(payload, line...)
+			return append(payload, rest...), signature
+		default:
+			inSignature, otherSignature = false, false
+			payload = append(payload, line...)
+		}
+	}
+	return payload, signature
+}
+
+// tagSignature returns the signature ending the message of a tag, or "" if it
+// has none.
+func tagSignature(n ipld.Node) (string, error) {
+	sn, err := n.LookupByString("signature")
+	if err != nil {
+		return "", err
+	}
+	if sn.IsAbsent() {
+		return "", nil
+	}
+	return sn.AsString()
+}
+
+// dropSignatureHeaders removes the gpgsig and gpgsig-sha256 headers of a tag,
+// which git leaves out of the payload of the signature ending its message.
+func dropSignatureHeaders(body []byte) []byte {
+	var payload []byte
+	var inSignature bool
+	for rest := body; len(rest) > 0; {
+		line := nextLine(&rest)
+		switch {
+		case inSignature && line[0] == ' ':
+		case bytes.HasPrefix(line, []byte(ipldgit.SignatureHeader+" ")),
+			bytes.HasPrefix(line, []byte(ipldgit.SignatureHeaderSHA256+" ")):
+			inSignature = true
+		case line[0] == '\n':
+			payload = append(payload, line...)
+			return append(payload, rest...)
+		default:
+			inSignature = false
+			payload = append(payload, line...)
+		}
+	}
+	return payload
+}
+
+// nextLine returns the first line of rest, with its newline, and advances rest
+// past it.
+func nextLine(rest *[]byte) []byte {
+	end := bytes.IndexByte(*rest, '\n') + 1
+	if end == 0 {
+		end = len(*rest)
+	}
+	line := (*rest)[:end]
+	*rest = (*rest)[end:]
+	return line
+}
+
+// signedAt returns the time of the committer or tagger of n, or the current
+// time if it has none.
+func signedAt(n ipld.Node) time.Time {
+	for _, field := range []string{"committer", "tagger"} {
+		pn, err := n.LookupByString(field)
+		if err != nil || pn.IsAbsent() || pn.IsNull() {
+			continue
+		}
+		dn, err := pn.LookupByString("date")
+		if err != nil {
+			continue
+		}
+		date, err := dn.AsString()
+		if err != nil {
+			continue
+		}
+		if secs, err := strconv.ParseInt(date, 10, 64); err == nil {
+			return time.Unix(secs, 0)
+		}
+	}
+	return time.Now()
+}
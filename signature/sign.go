@@ -0,0 +1,159 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrSigned is returned when signing an object that already has a signature
+// where the new one would go.
+var ErrSigned = errors.New("object is already signed")
+
+// Signer makes signatures of one format.
+type Signer interface {
+	// Format returns the format of the signatures made.
+	Format() ipldgit.SignatureFormat
+	// Sign returns an armored signature of payload.
+	Sign(payload []byte) ([]byte, error)
+}
+
+// Sign signs a commit or tag with signer, and returns the signed object. The
+// signature is added as git adds it: to a commit, in a gpgsig header after its
+// other headers, or gpgsig-sha256 for a SHA-256 commit; to a tag, after its
+// message, which must end with a newline. The object returned is decoded from
+// the signed bytes, so it has the CID git gives the same signed object.
+func Sign(n ipld.Node, signer Signer) (ipld.Node, error) {
+	typ, body, err := encode(n)
+	if err != nil {
+		return nil, err
+	}
+
+	var f ipldgit.ObjectFormat
+	var signed []byte
+	switch typ {
+	case "commit":
+		if f, err = linkFormat(n, "tree"); err != nil {
+			return nil, err
+		}
+		header := ipldgit.SignatureHeader
+		if f == ipldgit.ObjectFormatSHA256 {
+			header = ipldgit.SignatureHeaderSHA256
+		}
+		payload, sig := splitCommit(body, header)
+		if sig != nil {
+			return nil, ErrSigned
+		}
+		if sig, err = signer.Sign(payload); err != nil {
+			return nil, err
+		}
+
+		end := bytes.Index(body, []byte("\n\n")) + 1
+		signed = append(signed, body[:end]...)
+		signed = fmt.Appendf(signed, "%s %s\n", header, strings.ReplaceAll(strings.TrimSuffix(string(sig), "\n"), "\n", "\n "))
+		signed = append(signed, body[end:]...)
+	case "tag":
+		if f, err = linkFormat(n, "object"); err != nil {
+			return nil, err
+		}
+		sig, err := tagSignature(n)
+		if err != nil {
+			return nil, err
+		}
+		if sig != "" {
+			return nil, ErrSigned
+		}
+		if !bytes.HasSuffix(body, []byte("\n")) {
+			return nil, errors.New("tag message must end with a newline to be signed")
+		}
+		signature, err := signer.Sign(body)
+		if err != nil {
+			return nil, err
+		}
+		signed = append(body, signature...)
+	default:
+		return nil, fmt.Errorf("a %s cannot be signed", typ)
+	}
+
+	raw := fmt.Appendf(nil, "%s %d\x00", typ, len(signed))
+	return ipldgit.DecodeOptions{ObjectFormat: f}.ParseObjectFromBuffer(append(raw, signed...))
+}
+
+// OpenPGPSigner signs with an OpenPGP key.
+type OpenPGPSigner struct {
+	entity *openpgp.Entity
+	config *packet.Config
+}
+
+var _ Signer = (*OpenPGPSigner)(nil)
+
+// NewOpenPGPSigner returns a signer using the signing key of entity, whose
+// private key must be decrypted. The config may be nil.
+func NewOpenPGPSigner(entity *openpgp.Entity, config *packet.Config) *OpenPGPSigner {
+	return &OpenPGPSigner{entity: entity, config: config}
+}
+
+func (*OpenPGPSigner) Format() ipldgit.SignatureFormat {
+	return ipldgit.SignatureOpenPGP
+}
+
+func (s *OpenPGPSigner) Sign(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, bytes.NewReader(payload), s.config); err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// SSHSigner makes SSHSIG signatures in the git namespace, like ssh-keygen -Y
+// sign does for git.
+type SSHSigner struct {
+	signer ssh.Signer
+}
+
+var _ Signer = (*SSHSigner)(nil)
+
+// NewSSHSigner returns a signer using an SSH key.
+func NewSSHSigner(signer ssh.Signer) *SSHSigner {
+	return &SSHSigner{signer: signer}
+}
+
+func (*SSHSigner) Format() ipldgit.SignatureFormat {
+	return ipldgit.SignatureSSH
+}
+
+func (s *SSHSigner) Sign(payload []byte) ([]byte, error) {
+	sig := &sshSignature{
+		key:       s.signer.PublicKey(),
+		namespace: sshNamespace,
+		hashAlg:   "sha512",
+	}
+	data, err := sig.signedData(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// RSA keys sign with SHA-512, as ssh-keygen does, rather than the SHA-1
+	// of their legacy algorithm.
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && sig.key.Type() == ssh.KeyAlgoRSA {
+		sig.sig, err = as.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig.sig, err = s.signer.Sign(rand.Reader, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.armor(), nil
+}
@@ -0,0 +1,126 @@
+package signature
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+func testSSHSigner(t *testing.T) *SSHSigner {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/ssh_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.ParsePrivateKey(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSSHSigner(key)
+}
+
+// TestSignLikeGit signs the unsigned form of the objects git signed with the
+// same ed25519 key, whose signatures are deterministic, and expects the same
+// objects.
+func TestSignLikeGit(t *testing.T) {
+	signer := testSSHSigner(t)
+	for _, name := range []string{"sha1-ssh-commit", "sha1-ssh-tag", "sha256-ssh-commit", "sha256-ssh-tag"} {
+		t.Run(name, func(t *testing.T) {
+			want, err := os.ReadFile("testdata/" + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sha256 := strings.HasPrefix(name, "sha256")
+			typ := name[strings.LastIndexByte(name, '-')+1:]
+			payload, _, err := Payload(parseObject(t, want, sha256))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, err := Sign(parseObject(t, testRaw(typ, string(payload)), sha256), signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			typ, body, err := encode(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := testRaw(typ, string(body)); string(got) != string(want) {
+				t.Fatalf("signed object differs from git's:\n%s\nwant:\n%s", got, want)
+			}
+
+			if _, err := Sign(signed, signer); !errors.Is(err, ErrSigned) {
+				t.Fatalf("expected %v signing again, got %v", ErrSigned, err)
+			}
+		})
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	entity, err := openpgp.NewEntity("Jane Doe", "", "janedoe@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pgp := &OpenPGPVerifier{keyring: openpgp.EntityList{entity}, now: time.Now}
+	_, sshv := testVerifiers(t)
+
+	for _, test := range []struct {
+		name     string
+		signer   Signer
+		verifier Verifier
+	}{
+		{"OpenPGP", NewOpenPGPSigner(entity, nil), pgp},
+		{"SSH", testSSHSigner(t), sshv},
+	} {
+		for _, object := range []struct {
+			name, typ, body string
+			sha256          bool
+		}{
+			{"Commit", "commit", "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor A <a@example.com> 1700000000 +0000\ncommitter A <a@example.com> 1700000000 +0000\nx-other a\n\nmessage\n", false},
+			{"SHA256Commit", "commit", "tree 6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321\n\nmessage\n", true},
+			{"Tag", "tag", "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\n\nmessage\n", false},
+		} {
+			t.Run(test.name+"/"+object.name, func(t *testing.T) {
+				signed, err := Sign(parseObject(t, testRaw(object.typ, object.body), object.sha256), test.signer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res, err := Verify(signed, test.verifier)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !res.Valid || res.Format != test.signer.Format() {
+					t.Fatalf("signature not valid: %+v", res)
+				}
+				payload, _, err := Payload(signed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(payload) != object.body {
+					t.Fatalf("payload %q is not the unsigned object", payload)
+				}
+			})
+		}
+	}
+}
+
+func TestSignFailures(t *testing.T) {
+	signer := testSSHSigner(t)
+	for _, test := range []struct {
+		name, typ, body string
+	}{
+		{"Blob", "blob", "data\n"},
+		{"TagWithoutNewline", "tag", "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\n\nmessage"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Sign(parseObject(t, testRaw(test.typ, test.body), false), signer); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
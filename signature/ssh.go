@@ -0,0 +1,322 @@
+package signature
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshNamespace is the namespace git signs objects in.
+const sshNamespace = "git"
+
+// SSHVerifier checks SSH signatures against the keys of an allowed_signers
+// file, as git does with gpg.ssh.allowedSignersFile. The valid-after and
+// valid-before options of a key are checked against the time the object was
+// signed at. Keys marked cert-authority are not supported, and are ignored.
+type SSHVerifier struct {
+	signers []allowedSigner
+}
+
+var _ Verifier = (*SSHVerifier)(nil)
+
+type allowedSigner struct {
+	principals  string
+	key         ssh.PublicKey
+	namespaces  string
+	validAfter  time.Time
+	validBefore time.Time
+}
+
+// NewSSHVerifier reads an allowed_signers file, whose format is described in
+// ssh-keygen(1).
+func NewSSHVerifier(allowedSigners io.Reader) (*SSHVerifier, error) {
+	v := &SSHVerifier{}
+	sc := bufio.NewScanner(allowedSigners)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		s, ok, err := parseAllowedSigner(line)
+		if err != nil {
+			return nil, fmt.Errorf("allowed signers line %d: %w", lineNo, err)
+		}
+		if ok {
+			v.signers = append(v.signers, s)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// parseAllowedSigner parses a line of principals, options and a public key,
+// reporting false for keys that are not supported.
+func parseAllowedSigner(line string) (allowedSigner, bool, error) {
+	var s allowedSigner
+	if line[0] == '"' {
+		end := strings.IndexByte(line[1:], '"')
+		if end < 0 {
+			return s, false, errors.New("unterminated quoted principals")
+		}
+		s.principals, line = line[1:end+1], line[end+2:]
+	} else {
+		s.principals, line, _ = strings.Cut(line, " ")
+	}
+
+	key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(line)))
+	if err != nil {
+		return s, false, err
+	}
+	s.key = key
+	for _, opt := range options {
+		name, value, _ := strings.Cut(opt, "=")
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(name) {
+		case "cert-authority":
+			return s, false, nil
+		case "namespaces":
+			s.namespaces = value
+		case "valid-after":
+			if s.validAfter, err = parseSignerTime(value); err != nil {
+				return s, false, err
+			}
+		case "valid-before":
+			if s.validBefore, err = parseSignerTime(value); err != nil {
+				return s, false, err
+			}
+		}
+	}
+	return s, true, nil
+}
+
+// parseSignerTime parses a time of the valid-after and valid-before options,
+// which is in the local time zone unless it ends in Z.
+func parseSignerTime(value string) (time.Time, error) {
+	loc := time.Local
+	if v, ok := strings.CutSuffix(value, "Z"); ok {
+		value, loc = v, time.UTC
+	}
+	for _, layout := range []string{"20060102", "200601021504", "20060102150405"} {
+		if len(value) == len(layout) {
+			return time.ParseInLocation(layout, value, loc)
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+func (*SSHVerifier) Format() ipldgit.SignatureFormat {
+	return ipldgit.SignatureSSH
+}
+
+func (v *SSHVerifier) Verify(payload, signature []byte, signedAt time.Time) *Result {
+	res := &Result{Format: ipldgit.SignatureSSH}
+
+	sig, err := parseSSHSignature(signature)
+	if err != nil {
+		res.Err = fmt.Errorf("%w: %v", ErrBadSignature, err)
+		return res
+	}
+	res.Fingerprint = ssh.FingerprintSHA256(sig.key)
+
+	if sig.namespace != sshNamespace {
+		res.Err = fmt.Errorf("%w: made in namespace %q, not %q", ErrBadSignature, sig.namespace, sshNamespace)
+		return res
+	}
+	if err := sig.verify(payload); err != nil {
+		res.Err = fmt.Errorf("%w: %v", ErrBadSignature, err)
+		return res
+	}
+
+	var notValid error
+	for _, s := range v.signers {
+		if !bytes.Equal(s.key.Marshal(), sig.key.Marshal()) {
+			continue
+		}
+		switch {
+		case s.namespaces != "" && !matchPatternList(s.namespaces, sshNamespace):
+			notValid = fmt.Errorf("%w: not allowed to sign in namespace %q", ErrKeyNotValid, sshNamespace)
+		case !s.validAfter.IsZero() && signedAt.Before(s.validAfter):
+			notValid = fmt.Errorf("%w: not valid before %s", ErrKeyNotValid, s.validAfter)
+		case !s.validBefore.IsZero() && signedAt.After(s.validBefore):
+			notValid = fmt.Errorf("%w: not valid after %s", ErrKeyNotValid, s.validBefore)
+		default:
+			res.Signer, res.Valid = s.principals, true
+			return res
+		}
+		res.Signer = s.principals
+	}
+	if notValid != nil {
+		res.Err = notValid
+	} else {
+		res.Err = ErrUnknownKey
+	}
+	return res
+}
+
+// sshSignature is a signature in the SSHSIG format of ssh-keygen -Y sign,
+// described in PROTOCOL.sshsig of OpenSSH.
+type sshSignature struct {
+	key       ssh.PublicKey
+	namespace string
+	reserved  string
+	hashAlg   string
+	sig       *ssh.Signature
+}
+
+const sshsigMagic = "SSHSIG"
+
+// sshsigBlob is the wire format of an SSHSIG signature, after its magic.
+type sshsigBlob struct {
+	Version   uint32
+	PublicKey []byte
+	Namespace string
+	Reserved  string
+	HashAlg   string
+	Signature []byte
+}
+
+func parseSSHSignature(armored []byte) (*sshSignature, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return nil, errors.New("not an armored SSH signature")
+	}
+	data, ok := bytes.CutPrefix(block.Bytes, []byte(sshsigMagic))
+	if !ok {
+		return nil, errors.New("not an SSHSIG signature")
+	}
+
+	var blob sshsigBlob
+	if err := ssh.Unmarshal(data, &blob); err != nil {
+		return nil, err
+	}
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("unsupported SSHSIG version %d", blob.Version)
+	}
+	key, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := key.(*ssh.Certificate); ok {
+		return nil, errors.New("signatures made with certificates are not supported")
+	}
+	sig := new(ssh.Signature)
+	if err := ssh.Unmarshal(blob.Signature, sig); err != nil {
+		return nil, err
+	}
+	return &sshSignature{
+		key:       key,
+		namespace: blob.Namespace,
+		reserved:  blob.Reserved,
+		hashAlg:   blob.HashAlg,
+		sig:       sig,
+	}, nil
+}
+
+// armor encodes the signature as ssh-keygen does, with lines of 70 characters
+// of base64.
+func (s *sshSignature) armor() []byte {
+	data := append([]byte(sshsigMagic), ssh.Marshal(sshsigBlob{
+		Version:   1,
+		PublicKey: s.key.Marshal(),
+		Namespace: s.namespace,
+		Reserved:  s.reserved,
+		HashAlg:   s.hashAlg,
+		Signature: ssh.Marshal(s.sig),
+	})...)
+	enc := base64.StdEncoding.EncodeToString(data)
+
+	var buf bytes.Buffer
+	buf.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(enc) > 70 {
+		buf.WriteString(enc[:70] + "\n")
+		enc = enc[70:]
+	}
+	buf.WriteString(enc + "\n")
+	buf.WriteString("-----END SSH SIGNATURE-----\n")
+	return buf.Bytes()
+}
+
+// signedData returns the data an SSHSIG signature of message signs.
+func (s *sshSignature) signedData(message []byte) ([]byte, error) {
+	var h hash.Hash
+	switch s.hashAlg {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm %q", s.hashAlg)
+	}
+	h.Write(message)
+	return append([]byte(sshsigMagic), ssh.Marshal(struct {
+		Namespace string
+		Reserved  string
+		HashAlg   string
+		Hash      []byte
+	}{s.namespace, s.reserved, s.hashAlg, h.Sum(nil)})...), nil
+}
+
+func (s *sshSignature) verify(message []byte) error {
+	data, err := s.signedData(message)
+	if err != nil {
+		return err
+	}
+	return s.key.Verify(data, s.sig)
+}
+
+// matchPatternList reports whether s matches a comma-separated list of
+// patterns, as described in the PATTERNS section of ssh_config(5): it must
+// match one of them and none of those negated with "!".
+func matchPatternList(list, s string) bool {
+	matched := false
+	for _, p := range strings.Split(list, ",") {
+		if neg, ok := strings.CutPrefix(p, "!"); ok {
+			if matchPattern(neg, s) {
+				return false
+			}
+		} else if matchPattern(p, s) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// matchPattern matches s against a pattern where "*" matches any run of
+// characters and "?" any one character.
+func matchPattern(p, s string) bool {
+	for len(p) > 0 {
+		switch p[0] {
+		case '*':
+			for i := len(s); i >= 0; i-- {
+				if matchPattern(p[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		default:
+			if len(s) == 0 || s[0] != p[0] {
+				return false
+			}
+		}
+		p, s = p[1:], s[1:]
+	}
+	return len(s) == 0
+}
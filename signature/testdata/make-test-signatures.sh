@@ -0,0 +1,60 @@
+#!/usr/bin/env bash
+
+# Makes commits and tags signed by git with a fresh OpenPGP key and SSH key, for
+# the signature tests. The objects are written in their loose form, along with
+# the public OpenPGP key, and the SSH key with an allowed_signers file for it.
+# Run from this directory.
+
+set -ex
+CUR_DIR=$(pwd)
+TEST_DIR=$(mktemp -d)
+export GNUPGHOME=${TEST_DIR}/gnupg
+mkdir -m 700 ${GNUPGHOME}
+
+gpg --batch --passphrase '' --quick-gen-key "John Doe <johndoe@example.com>" ed25519 sign never
+gpg --armor --export johndoe@example.com > ${CUR_DIR}/pgp.asc
+rm -f ${CUR_DIR}/ssh_key ${CUR_DIR}/ssh_key.pub
+ssh-keygen -q -t ed25519 -N '' -C johndoe -f ${CUR_DIR}/ssh_key
+echo "johndoe@example.com namespaces=\"git\" $(cat ${CUR_DIR}/ssh_key.pub)" > ${CUR_DIR}/allowed_signers
+rm ${CUR_DIR}/ssh_key.pub
+
+export GIT_AUTHOR_NAME="John Doe" GIT_AUTHOR_EMAIL=johndoe@example.com
+export GIT_COMMITTER_NAME="John Doe" GIT_COMMITTER_EMAIL=johndoe@example.com
+export GIT_AUTHOR_DATE="1500000000 +0000" GIT_COMMITTER_DATE="1500000000 +0000"
+
+# Writes the object named by $2 in the repository $1 to the file $3.
+object() {
+	TYPE=$(git -C $1 cat-file -t $2)
+	SIZE=$(git -C $1 cat-file -s $2)
+	{
+		printf '%s %s\0' ${TYPE} ${SIZE}
+		git -C $1 cat-file ${TYPE} $2
+	} > ${CUR_DIR}/$3
+}
+
+signed() {
+	NAME=$1
+	shift
+	REPO=${TEST_DIR}/${NAME}
+	git init -q "$@" ${REPO}
+	echo "Hello world" > ${REPO}/file
+	git -C ${REPO} add file
+	git -C ${REPO} commit -q -m "Init"
+	git -C ${REPO} config user.signingkey johndoe@example.com
+	git -C ${REPO} commit -q -S --allow-empty -m "Signed with OpenPGP"
+	object ${REPO} HEAD ${NAME}-pgp-commit
+	git -C ${REPO} tag -s -m "Signed with OpenPGP" pgp
+	object ${REPO} pgp ${NAME}-pgp-tag
+
+	git -C ${REPO} config gpg.format ssh
+	git -C ${REPO} config user.signingkey ${CUR_DIR}/ssh_key
+	git -C ${REPO} commit -q -S --allow-empty -m "Signed with SSH"
+	object ${REPO} HEAD ${NAME}-ssh-commit
+	git -C ${REPO} tag -s -m "Signed with SSH" ssh
+	object ${REPO} ssh ${NAME}-ssh-tag
+}
+
+signed sha1
+signed sha256 --object-format=sha256
+
+rm -rf ${TEST_DIR}
@@ -0,0 +1,74 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+)
+
+// Errors a Result can fail with, possibly wrapped with more detail.
+var (
+	// ErrUnsupportedFormat is the error of signatures in a format no verifier
+	// was given for.
+	ErrUnsupportedFormat = errors.New("no verifier for signature format")
+	// ErrUnknownKey is the error of signatures made by a key the verifier does
+	// not trust.
+	ErrUnknownKey = errors.New("signed by an unknown key")
+	// ErrBadSignature is the error of signatures that do not match what they
+	// sign, or are malformed.
+	ErrBadSignature = errors.New("bad signature")
+	// ErrKeyNotValid is the error of signatures made by a known key that is
+	// expired, revoked or not allowed to sign git objects.
+	ErrKeyNotValid = errors.New("signing key not valid")
+)
+
+// Result is the outcome of checking a signature.
+type Result struct {
+	// Format is the format of the signature.
+	Format ipldgit.SignatureFormat
+	// Signer names who made the signature: the primary user ID of an OpenPGP
+	// key, or the principals an SSH key is allowed to sign as.
+	Signer string
+	// Fingerprint identifies the key the signature was made with, as gpg or
+	// ssh-keygen print it. It is set whenever the signature names its key,
+	// even if the key is unknown.
+	Fingerprint string
+	// Valid reports whether the signature is good and made by a trusted key.
+	Valid bool
+	// Err is why the signature is not valid.
+	Err error
+}
+
+// Verifier checks signatures of one format.
+type Verifier interface {
+	// Format returns the format of the signatures the verifier checks.
+	Format() ipldgit.SignatureFormat
+	// Verify checks an armored signature of payload, which the signed object
+	// dates at signedAt.
+	Verify(payload, signature []byte, signedAt time.Time) *Result
+}
+
+// Verify checks the signature of a commit or tag with the verifier for its
+// format, among verifiers. Objects that are not signed, or whose signature
+// cannot be checked, give a Result that is not valid, whose Err says why. An
+// error is only returned if n is not a commit or tag that can be encoded.
+func Verify(n ipld.Node, verifiers ...Verifier) (*Result, error) {
+	payload, signature, err := Payload(n)
+	if errors.Is(err, ErrUnsigned) {
+		return &Result{Err: err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	format := ipldgit.SignatureFormatOf(string(signature))
+	for _, v := range verifiers {
+		if v.Format() == format {
+			return v.Verify(payload, signature, signedAt(n)), nil
+		}
+	}
+	return &Result{Format: format, Err: fmt.Errorf("%w %s", ErrUnsupportedFormat, format)}, nil
+}
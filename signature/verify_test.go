@@ -0,0 +1,259 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	"golang.org/x/crypto/ssh"
+)
+
+// testObject reads an object made by testdata/make-test-signatures.sh.
+func testObject(t *testing.T, name string) ipld.Node {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseObject(t, raw, strings.HasPrefix(name, "sha256"))
+}
+
+func parseObject(t *testing.T, raw []byte, sha256 bool) ipld.Node {
+	t.Helper()
+	o := ipldgit.DecodeOptions{}
+	if sha256 {
+		o.ObjectFormat = ipldgit.ObjectFormatSHA256
+	}
+	n, err := o.ParseObjectFromBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func testRaw(typ, body string) []byte {
+	return fmt.Appendf(nil, "%s %d\x00%s", typ, len(body), body)
+}
+
+func testVerifiers(t *testing.T) (*OpenPGPVerifier, *SSHVerifier) {
+	t.Helper()
+	keyring, err := os.Open("testdata/pgp.asc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keyring.Close()
+	pgp, err := NewOpenPGPVerifier(keyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers, err := os.Open("testdata/allowed_signers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signers.Close()
+	sshv, err := NewSSHVerifier(signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pgp, sshv
+}
+
+func TestVerify(t *testing.T) {
+	pgp, sshv := testVerifiers(t)
+	pgpFingerprint := fmt.Sprintf("%X", pgp.keyring[0].PrimaryKey.Fingerprint)
+	sshFingerprint := ssh.FingerprintSHA256(sshv.signers[0].key)
+
+	for _, format := range []string{"sha1", "sha256"} {
+		for _, typ := range []string{"commit", "tag"} {
+			for _, test := range []struct {
+				signer      string
+				format      ipldgit.SignatureFormat
+				who         string
+				fingerprint string
+			}{
+				{"pgp", ipldgit.SignatureOpenPGP, "John Doe <johndoe@example.com>", pgpFingerprint},
+				{"ssh", ipldgit.SignatureSSH, "johndoe@example.com", sshFingerprint},
+			} {
+				name := format + "-" + test.signer + "-" + typ
+				t.Run(name, func(t *testing.T) {
+					res, err := Verify(testObject(t, name), pgp, sshv)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !res.Valid || res.Err != nil {
+						t.Fatalf("signature not valid: %v", res.Err)
+					}
+					if res.Format != test.format || res.Signer != test.who || res.Fingerprint != test.fingerprint {
+						t.Fatalf("unexpected result %+v", res)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	pgp, sshv := testVerifiers(t)
+	for _, name := range []string{"sha1-pgp-commit", "sha1-ssh-commit", "sha256-pgp-tag", "sha256-ssh-tag"} {
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile("testdata/" + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw = bytes.Replace(raw, []byte("Signed with"), []byte("Signed With"), 1)
+			res, err := Verify(parseObject(t, raw, strings.HasPrefix(name, "sha256")), pgp, sshv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Valid || !errors.Is(res.Err, ErrBadSignature) {
+				t.Fatalf("expected a bad signature, got %+v", res)
+			}
+			if res.Fingerprint == "" {
+				t.Fatal("bad signature has no fingerprint")
+			}
+		})
+	}
+}
+
+func TestVerifyFailures(t *testing.T) {
+	pgp, sshv := testVerifiers(t)
+	otherSSH, err := NewSSHVerifier(strings.NewReader("# nobody\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPGP := &OpenPGPVerifier{now: time.Now}
+
+	line := func(options string) *SSHVerifier {
+		raw, err := os.ReadFile("testdata/allowed_signers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := strings.Replace(string(raw), `namespaces="git"`, options, 1)
+		v, err := NewSSHVerifier(strings.NewReader(l))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	for _, test := range []struct {
+		name      string
+		object    string
+		verifiers []Verifier
+		err       error
+	}{
+		{"Unsigned", "sha1-unsigned", []Verifier{pgp, sshv}, ErrUnsigned},
+		{"NoVerifier", "sha1-ssh-commit", []Verifier{pgp}, ErrUnsupportedFormat},
+		{"UnknownSSHKey", "sha1-ssh-commit", []Verifier{otherSSH}, ErrUnknownKey},
+		{"UnknownPGPKey", "sha1-pgp-commit", []Verifier{otherPGP}, ErrUnknownKey},
+		{"OtherNamespace", "sha1-ssh-commit", []Verifier{line(`namespaces="file,!git"`)}, ErrKeyNotValid},
+		{"ValidAfter", "sha1-ssh-tag", []Verifier{line(`valid-after="20200101Z"`)}, ErrKeyNotValid},
+		{"ValidBefore", "sha1-ssh-tag", []Verifier{line(`valid-before="201701010000Z"`)}, ErrKeyNotValid},
+		{"ValidPeriod", "sha1-ssh-tag", []Verifier{line(`valid-after="20170101",valid-before="20170801000000Z"`)}, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var n ipld.Node
+			if test.object == "sha1-unsigned" {
+				n = parseObject(t, testRaw("commit", "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nunsigned\n"), false)
+			} else {
+				n = testObject(t, test.object)
+			}
+			res, err := Verify(n, test.verifiers...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.err == nil {
+				if !res.Valid {
+					t.Fatalf("signature not valid: %v", res.Err)
+				}
+				return
+			}
+			if res.Valid || !errors.Is(res.Err, test.err) {
+				t.Fatalf("expected %v, got %+v", test.err, res)
+			}
+		})
+	}
+}
+
+func TestPayload(t *testing.T) {
+	sig := "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n"
+	folded := strings.ReplaceAll(strings.TrimSuffix(sig, "\n"), "\n", "\n ") + "\n"
+	for _, test := range []struct {
+		name, typ, object, payload, signature string
+		sha256                                bool
+	}{
+		{
+			name:      "Commit",
+			typ:       "commit",
+			object:    "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ngpgsig " + folded + "x-other a\n b\n\nmessage\n",
+			payload:   "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nx-other a\n b\n\nmessage\n",
+			signature: sig,
+		},
+		{
+			name:      "OtherSignature",
+			typ:       "commit",
+			object:    "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ngpgsig-sha256 " + folded + "gpgsig " + folded + "\nmessage\n",
+			payload:   "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nmessage\n",
+			signature: sig,
+		},
+		{
+			name:      "SHA256",
+			typ:       "commit",
+			object:    "tree 6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321\ngpgsig " + folded + "gpgsig-sha256 " + folded + "\nmessage\n",
+			payload:   "tree 6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321\n\nmessage\n",
+			signature: sig,
+			sha256:    true,
+		},
+		{
+			name:      "Tag",
+			typ:       "tag",
+			object:    "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\ngpgsig-sha256 " + folded + "\nmessage\n" + sig,
+			payload:   "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\n\nmessage\n",
+			signature: sig,
+		},
+		{
+			name:      "TagLastArmor",
+			typ:       "tag",
+			object:    "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\n\n" + sig + sig,
+			payload:   "object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype tree\ntag v1\n\n" + sig,
+			signature: sig,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			n := parseObject(t, testRaw(test.typ, test.object), test.sha256)
+			payload, signature, err := Payload(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(payload) != test.payload || string(signature) != test.signature {
+				t.Fatalf("got payload %q and signature %q", payload, signature)
+			}
+		})
+	}
+}
+
+func TestMatchPatternList(t *testing.T) {
+	for _, test := range []struct {
+		list string
+		want bool
+	}{
+		{"git", true},
+		{"file,git", true},
+		{"g*", true},
+		{"g?t", true},
+		{"*,!git", false},
+		{"file", false},
+		{"gi", false},
+	} {
+		if got := matchPatternList(test.list, "git"); got != test.want {
+			t.Errorf("matchPatternList(%q) = %v, want %v", test.list, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package ipldgit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/node/mixins"
+)
+
+var _ datamodel.LargeBytesNode = (*StreamingBlob)(nil)
+
+// StreamingBlob is a blob node that reads its body from its source whenever it
+// is asked for, rather than holding it in memory like the nodes DecodeBlob
+// builds. Its bytes are the same as those of a Blob: the "blob <size>\x00"
+// header followed by the body.
+//
+// The declared size is only checked as the body is read: reading a body that
+// is shorter or longer than its header says fails once the difference is
+// found. AsBytes reads the whole object into memory, while AsLargeBytes and
+// Reader stream it.
+type StreamingBlob struct {
+	open func() (io.ReadCloser, error)
+	size int64
+	hdr  int64
+}
+
+// OpenBlob returns a StreamingBlob reading a blob from open, which must return
+// a new reader of the blob in its loose form every time it is called. The
+// header is read once to check the object is a blob and learn its size.
+func OpenBlob(open func() (io.ReadCloser, error)) (*StreamingBlob, error) {
+	rc, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	rd := bufio.NewReader(rc)
+	typ, err := rd.ReadString(' ')
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	if typ != "blob " {
+		return nil, fmt.Errorf("object is a %q, not a blob", typ[:len(typ)-1])
+	}
+	size, err := readNullTerminatedNumber(rd)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid blob size: %d", size)
+	}
+	return &StreamingBlob{
+		open: open,
+		size: int64(size),
+		hdr:  int64(len(fmt.Sprintf("blob %d\x00", size))),
+	}, nil
+}
+
+// StreamingBlobPrototype returns a prototype of StreamingBlob nodes reading
+// their blob from open. Decoding a blob into it reads the blob through once,
+// without keeping it, so that a LinkSystem loading the blob with it checks its
+// hash; the node then reads the blob from open again whenever it is read.
+func StreamingBlobPrototype(open func() (io.ReadCloser, error)) ipld.NodePrototype {
+	return streamingBlobPrototype{open}
+}
+
+// LoadStreamingBlob loads the blob lnk through ls as a StreamingBlob, which
+// reads the blob from the storage of ls again whenever it is read. The blob is
+// never held in memory, even as its hash is checked.
+func LoadStreamingBlob(lctx ipld.LinkContext, ls ipld.LinkSystem, lnk ipld.Link) (*StreamingBlob, error) {
+	if ls.StorageReadOpener == nil {
+		return nil, errors.New("no storage configured for reading")
+	}
+	open := func() (io.ReadCloser, error) {
+		r, err := ls.StorageReadOpener(lctx, lnk)
+		if err != nil {
+			return nil, err
+		}
+		if rc, ok := r.(io.ReadCloser); ok {
+			return rc, nil
+		}
+		return io.NopCloser(r), nil
+	}
+	n, err := ls.Load(lctx, lnk, StreamingBlobPrototype(open))
+	if err != nil {
+		return nil, err
+	}
+	b, ok := n.(*StreamingBlob)
+	if !ok {
+		return nil, fmt.Errorf("loaded a %T, not a StreamingBlob", n)
+	}
+	return b, nil
+}
+
+// Size returns the size of the blob body, as declared by its header.
+func (b *StreamingBlob) Size() int64 {
+	return b.size
+}
+
+// Reader returns a reader of the blob body, without its header, whose offsets
+// are in the body. Seeking backwards reopens the object. The reader must be
+// closed.
+func (b *StreamingBlob) Reader() (io.ReadSeekCloser, error) {
+	return &blobReader{b: b, base: b.hdr, want: b.hdr}, nil
+}
+
+// AsLargeBytes returns a reader of the bytes of the node. Seeking backwards
+// reopens the object and reads it again up to the new position.
+func (b *StreamingBlob) AsLargeBytes() (io.ReadSeeker, error) {
+	return b.readSeeker(), nil
+}
+
+func (b *StreamingBlob) readSeeker() *blobReader {
+	return &blobReader{b: b}
+}
+
+// AsBytes reads the whole object into memory.
+func (b *StreamingBlob) AsBytes() ([]byte, error) {
+	rs := b.readSeeker()
+	defer rs.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, rs); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (*StreamingBlob) Kind() ipld.Kind {
+	return ipld.Kind_Bytes
+}
+func (*StreamingBlob) LookupByString(string) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.LookupByString("")
+}
+func (*StreamingBlob) LookupByNode(ipld.Node) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.LookupByNode(nil)
+}
+func (*StreamingBlob) LookupByIndex(idx int64) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.LookupByIndex(0)
+}
+func (*StreamingBlob) LookupBySegment(seg ipld.PathSegment) (ipld.Node, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.LookupBySegment(seg)
+}
+func (*StreamingBlob) MapIterator() ipld.MapIterator {
+	return nil
+}
+func (*StreamingBlob) ListIterator() ipld.ListIterator {
+	return nil
+}
+func (*StreamingBlob) Length() int64 {
+	return -1
+}
+func (*StreamingBlob) IsAbsent() bool {
+	return false
+}
+func (*StreamingBlob) IsNull() bool {
+	return false
+}
+func (*StreamingBlob) AsBool() (bool, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.AsBool()
+}
+func (*StreamingBlob) AsInt() (int64, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.AsInt()
+}
+func (*StreamingBlob) AsFloat() (float64, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.AsFloat()
+}
+func (*StreamingBlob) AsString() (string, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.AsString()
+}
+func (*StreamingBlob) AsLink() (ipld.Link, error) {
+	return mixins.Bytes{TypeName: "ipldgit.StreamingBlob"}.AsLink()
+}
+
+// Prototype returns the prototype of Blob, which builds blobs in memory.
+func (*StreamingBlob) Prototype() ipld.NodePrototype {
+	return Type.Blob
+}
+
+type streamingBlobPrototype struct {
+	open func() (io.ReadCloser, error)
+}
+
+func (p streamingBlobPrototype) NewBuilder() ipld.NodeBuilder {
+	return &streamingBlobBuilder{open: p.open}
+}
+
+// streamingBlobBuilder builds a StreamingBlob. DecodeBlob reads the blob
+// through when given one, and sets its size.
+type streamingBlobBuilder struct {
+	open func() (io.ReadCloser, error)
+	b    *StreamingBlob
+}
+
+// decoded sets the blob decoded, whose body of size bytes has been read.
+func (nb *streamingBlobBuilder) decoded(size int64) {
+	nb.b = &StreamingBlob{
+		open: nb.open,
+		size: size,
+		hdr:  int64(len(fmt.Sprintf("blob %d\x00", size))),
+	}
+}
+
+func (nb *streamingBlobBuilder) Build() ipld.Node {
+	return nb.b
+}
+func (nb *streamingBlobBuilder) Reset() {
+	nb.b = nil
+}
+func (streamingBlobBuilder) BeginMap(sizeHint int64) (ipld.MapAssembler, error) {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.BeginMap(0)
+}
+func (streamingBlobBuilder) BeginList(sizeHint int64) (ipld.ListAssembler, error) {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.BeginList(0)
+}
+func (streamingBlobBuilder) AssignNull() error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignNull()
+}
+func (streamingBlobBuilder) AssignBool(bool) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignBool(false)
+}
+func (streamingBlobBuilder) AssignInt(int64) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignInt(0)
+}
+func (streamingBlobBuilder) AssignFloat(float64) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignFloat(0)
+}
+func (streamingBlobBuilder) AssignString(string) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignString("")
+}
+
+// AssignBytes refuses bytes: a StreamingBlob reads its blob from its opener,
+// so it is only built by decoding.
+func (streamingBlobBuilder) AssignBytes([]byte) error {
+	return errors.New("a StreamingBlob is built by decoding a blob, not from bytes")
+}
+func (streamingBlobBuilder) AssignLink(ipld.Link) error {
+	return mixins.BytesAssembler{TypeName: "ipldgit.StreamingBlob"}.AssignLink(nil)
+}
+
+// AssignNode assigns another StreamingBlob.
+func (nb *streamingBlobBuilder) AssignNode(v ipld.Node) error {
+	b, ok := v.(*StreamingBlob)
+	if !ok {
+		return fmt.Errorf("cannot assign a %T to a StreamingBlob", v)
+	}
+	nb.b = b
+	return nil
+}
+func (nb *streamingBlobBuilder) Prototype() ipld.NodePrototype {
+	return streamingBlobPrototype{nb.open}
+}
+
+var errBlobTooLong = errors.New("blob is longer than its declared size")
+
+// blobReader reads a streaming blob from a reader opened on demand, checking
+// the object is as long as its header says.
+type blobReader struct {
+	b   *StreamingBlob
+	rc  io.ReadCloser
+	rd  *bufio.Reader
+	pos int64
+	// want is the position the next read starts at, which may be past pos
+	// after a seek.
+	want int64
+	// base is the position seek offsets count from: the start of the body
+	// for Reader, or of the object for AsLargeBytes.
+	base int64
+}
+
+func (r *blobReader) total() int64 {
+	return r.b.hdr + r.b.size
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	if r.want < r.pos && r.rc != nil {
+		r.rc.Close()
+		r.rc = nil
+	}
+	if r.rc == nil {
+		rc, err := r.b.open()
+		if err != nil {
+			return 0, err
+		}
+		r.rc, r.rd, r.pos = rc, bufio.NewReader(rc), 0
+	}
+	if skip := min(r.want, r.total()) - r.pos; skip > 0 {
+		n, err := io.CopyN(io.Discard, r.rd, skip)
+		r.pos += n
+		if err != nil {
+			return 0, r.shortErr(err)
+		}
+	}
+
+	if r.want > r.total() {
+		return 0, io.EOF
+	}
+	if r.pos == r.total() {
+		// Make sure the object ends where its header says it does.
+		if _, err := r.rd.ReadByte(); err != io.EOF {
+			if err == nil {
+				err = errBlobTooLong
+			}
+			return 0, err
+		}
+		return 0, io.EOF
+	}
+	if rem := r.total() - r.pos; int64(len(p)) > rem {
+		p = p[:rem]
+	}
+	n, err := r.rd.Read(p)
+	r.pos += int64(n)
+	r.want = r.pos
+	if err == io.EOF {
+		err = r.shortErr(err)
+	}
+	return n, err
+}
+
+func (r *blobReader) shortErr(err error) error {
+	if err == io.EOF && r.pos < r.total() {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		offset += r.base
+	case io.SeekCurrent:
+		offset += r.want
+	case io.SeekEnd:
+		offset += r.total()
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < r.base {
+		return 0, errors.New("seek to a negative position")
+	}
+	r.want = offset
+	return offset - r.base, nil
+}
+
+func (r *blobReader) Close() error {
+	if r.rc == nil {
+		return nil
+	}
+	err := r.rc.Close()
+	r.rc = nil
+	return err
+}
@@ -0,0 +1,183 @@
+package ipldgit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+)
+
+func opener(raw string) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(raw)), nil
+	}
+}
+
+func TestStreamingBlob(t *testing.T) {
+	body := strings.Repeat("streamed content\n", 1000)
+	raw := fmt.Sprintf("blob %d\x00%s", len(body), body)
+
+	b, err := OpenBlob(opener(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Size() != int64(len(body)) {
+		t.Fatalf("expected size %d, got %d", len(body), b.Size())
+	}
+
+	rc, err := b.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Fatal("reader does not return the blob body")
+	}
+	if pos, err := rc.Seek(4, io.SeekStart); err != nil || pos != 4 {
+		t.Fatalf("seeked to %d, %v", pos, err)
+	}
+	if got, _ := io.ReadAll(rc); string(got) != body[4:] {
+		t.Fatal("reader seeks outside the blob body")
+	}
+	if _, err := rc.Seek(-1, io.SeekStart); err == nil {
+		t.Fatal("expected an error seeking before the body")
+	}
+	rc.Close()
+
+	rs, err := b.AsLargeBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.Seek(-5, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(rs); string(got) != "tent\n" {
+		t.Fatalf("unexpected end of blob %q", got)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(rs); string(got) != raw {
+		t.Fatal("reading again after seeking back returns different bytes")
+	}
+
+	// The node hashes and encodes like the blob decoded in memory.
+	mem, err := ParseObjectFromBuffer([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls := cidlink.DefaultLinkSystem()
+	lp := cidlink.LinkPrototype{Prefix: ObjectFormatSHA1.Prefix()}
+	if ls.MustComputeLink(lp, b) != ls.MustComputeLink(lp, mem) {
+		t.Fatal("streaming blob and blob have different links")
+	}
+	if got, err := b.AsBytes(); err != nil || string(got) != raw {
+		t.Fatalf("unexpected bytes: %v", err)
+	}
+}
+
+func TestStreamingBlobSize(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		raw  string
+		err  error
+	}{
+		{"Short", "blob 10\x00short", io.ErrUnexpectedEOF},
+		{"Long", "blob 2\x00long", errBlobTooLong},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// Opening only reads the header, so the size is not checked yet.
+			b, err := OpenBlob(opener(test.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rc, err := b.Reader()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rc.Close()
+			if _, err := io.ReadAll(rc); err != test.err {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+			if err := Encode(b, new(bytes.Buffer)); err != test.err {
+				t.Fatalf("expected %v encoding, got %v", test.err, err)
+			}
+		})
+	}
+
+	if _, err := OpenBlob(opener("tree 0\x00")); err == nil {
+		t.Fatal("expected an error opening a tree as a blob")
+	}
+}
+
+func TestLoadStreamingBlob(t *testing.T) {
+	body := strings.Repeat("loaded content\n", 1000)
+	raw := fmt.Sprintf("blob %d\x00%s", len(body), body)
+	ls := memLinkSystem()
+	lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ObjectFormatSHA1.Prefix()}, NewBlob([]byte(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the bytes read from storage, to see the blob is read once to
+	// load it, and again only when the node is read.
+	var read int
+	open := ls.StorageReadOpener
+	ls.StorageReadOpener = func(lctx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		r, err := open(lctx, lnk)
+		if err != nil {
+			return nil, err
+		}
+		return readCounter{r, &read}, nil
+	}
+
+	b, err := LoadStreamingBlob(ipld.LinkContext{}, ls, lnk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != len(raw) || b.Size() != int64(len(body)) {
+		t.Fatalf("loading read %d bytes of a blob of %d bytes", read, b.Size())
+	}
+	rc, err := b.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(got) != body {
+		t.Fatalf("unexpected blob body: %v", err)
+	}
+	if ls.MustComputeLink(lnk.Prototype(), b) != lnk {
+		t.Fatal("loaded streaming blob has a different link")
+	}
+
+	// Loading with the prototype checks the hash of the blob.
+	bad := cidlink.Link{Cid: hashCid(t, ObjectFormatSHA1, "ce013625030ba8dba906f756967f9e9ca394464a")}
+	store := &memstore.Store{}
+	if err := store.Put(t.Context(), bad.Binary(), []byte(raw)); err != nil {
+		t.Fatal(err)
+	}
+	ls.SetReadStorage(store)
+	if _, err := ls.Load(ipld.LinkContext{}, bad, StreamingBlobPrototype(opener(raw))); err == nil {
+		t.Fatal("loaded a blob whose hash does not match its link")
+	}
+}
+
+type readCounter struct {
+	r io.Reader
+	n *int
+}
+
+func (r readCounter) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	*r.n += n
+	return n, err
+}
@@ -6,186 +6,192 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/ipld/go-ipld-prime"
-	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/schema"
 )
 
-const tagObjectPrefixLen = 7 // "prefix 'object '"
-const tagTagPrefixLen = 4    // "prefix 'tag '"
-const tagTypePrefixLen = 5   // "prefix 'type '"
-
 // DecodeTag fills a NodeAssembler (from `Type.Tag__Repr.NewBuilder()`) from a stream of bytes
 func DecodeTag(na ipld.NodeAssembler, rd *bufio.Reader) error {
+	return DecodeOptions{}.DecodeTag(na, rd)
+}
+
+// DecodeTag fills a NodeAssembler (from `Type.Tag__Repr.NewBuilder()`) from a stream of bytes
+//
+// The signature git appends to the message of a signed tag is kept apart from
+// the message, in signature. Like DecodeCommit, it keeps the headers it has no
+// field for in other, and their order in headerOrder when needed to encode the
+// tag back unchanged. Tags without an object, type or tag header, which git
+// fsck rejects, fail to decode, as do tags with no blank line after their
+// headers, which could not be written back.
+func (o DecodeOptions) DecodeTag(na ipld.NodeAssembler, rd *bufio.Reader) error {
 	_, err := rd.ReadString(0)
 	if err != nil {
 		return err
 	}
 
-	out := _Tag{}
-
-	for {
-		line, _, err := rd.ReadLine()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return err
-		}
-
-		switch {
-		case bytes.HasPrefix(line, []byte("object ")):
-			sha, err := hex.DecodeString(string(line[tagObjectPrefixLen:]))
-			if err != nil {
-				return err
-			}
-
-			c, err := shaToCid(sha)
-			if err != nil {
-				return err
-			}
-			out.object = _Link{cidlink.Link{Cid: c}}
-		case bytes.HasPrefix(line, []byte("tag ")):
-			out.tag = _String{string(line[tagTagPrefixLen:])}
-		case bytes.HasPrefix(line, []byte("tagger ")):
-			c, err := parsePersonInfo(line)
-			if err != nil {
-				return err
-			}
-
-			out.tagger = *c
-		case bytes.HasPrefix(line, []byte("type ")):
-			out.typ = _String{string(line[tagTypePrefixLen:])}
-		case len(line) == 0:
-			rest, err := io.ReadAll(rd)
-			if err != nil {
-				return err
-			}
-
-			out.message = _String{string(rest)}
-		default:
-			fmt.Println("unhandled line: ", string(line))
-		}
+	out, err := readTag(rd, o.ObjectFormat)
+	if err != nil {
+		return err
 	}
-
-	return na.AssignNode(&out)
+	return na.AssignNode(out)
 }
 
-// readMergeTag works for tags within commits like DecodeTag
-func readMergeTag(hash []byte, rd *bufio.Reader) (Tag, []byte, error) {
-	out := _Tag{}
+// decodeTagBody decodes a tag from its body, without the object header.
+func decodeTagBody(body string, f ObjectFormat) (*_Tag, error) {
+	return readTag(bufio.NewReader(strings.NewReader(body)), f)
+}
 
-	objCid, err := shaToCid(hash)
+func readTag(rd *bufio.Reader, f ObjectFormat) (*_Tag, error) {
+	headers, message, err := readHeaders(rd)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	out.object = _Link{cidlink.Link{Cid: objCid}}
-	for {
-		line, _, err := rd.ReadLine()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return nil, nil, err
-		}
 
+	message, signature := splitTagMessage(message)
+	out := _Tag{message: _String{message}}
+	if signature != "" {
+		out.signature = _String__Maybe{m: schema.Maybe_Value, v: _String{signature}}
+	}
+	order := make([]string, 0, len(headers))
+	var signatures []_Signature
+	var other []_Header
+	for _, h := range headers {
+		// Headers without a space, such as "type", would be written back
+		// with one, so they are kept as they are in other.
+		key, value, hasValue := strings.Cut(h, " ")
 		switch {
-		case bytes.HasPrefix(line, []byte(" type ")):
-			out.typ = _String{string(line[1+tagTypePrefixLen:])}
-		case bytes.HasPrefix(line, []byte(" tag ")):
-			out.tag = _String{string(line[1+tagTagPrefixLen:])}
-		case bytes.HasPrefix(line, []byte(" tagger ")):
-			tagger, err := parsePersonInfo(line[1:])
+		case key == "object" && out.object.x == nil:
+			lnk, err := decodeLinkHeader(value, f)
 			if err != nil {
-				return nil, nil, err
+				return nil, err
 			}
-			out.tagger = *tagger
-		case string(line) == " ":
-			// Accumulate in a builder: the message has no fixed size, and
-			// repeated string concatenation would copy it on every line.
-			var msg strings.Builder
-			for {
-				line, _, err := rd.ReadLine()
-				if err != nil {
-					out.message.x = msg.String()
-					return nil, nil, err
-				}
-
-				if !bytes.HasPrefix(line, []byte(" ")) {
-					out.message.x = msg.String()
-					return &out, line, nil
-				}
-
-				msg.WriteString(string(line) + "\n")
+			out.object = _Link{lnk}
+		case key == "type" && hasValue && !slices.Contains(order, key):
+			out.typ = _String{value}
+		case key == "tag" && hasValue && !slices.Contains(order, key):
+			out.tag = _String{value}
+		case key == SignatureHeader || key == SignatureHeaderSHA256:
+			sig, ok := decodeSignatureHeader(h)
+			if !ok {
+				key = "other"
+				other = append(other, parseHeader(h))
+				break
+			}
+			key = "signature"
+			signatures = append(signatures, sig)
+		case key == "tagger" && out.tagger.m != schema.Maybe_Value:
+			pi, ok := decodePersonHeader(key, h)
+			if !ok {
+				key = "other"
+				other = append(other, parseHeader(h))
+				break
 			}
+			out.tagger = _PersonInfo__Maybe{m: schema.Maybe_Value, v: pi}
+		default:
+			key = "other"
+			other = append(other, parseHeader(h))
 		}
+		order = append(order, key)
 	}
-	return &out, nil, nil
-}
-
-func encodeTag(n ipld.Node, w io.Writer) error {
-	obj, err := n.LookupByString("object")
-	if err != nil {
-		return err
+	// Every tag is written with these headers, so one without them would not
+	// encode back to its bytes.
+	for _, key := range []string{"object", "type", "tag"} {
+		if !slices.Contains(order, key) {
+			return nil, fmt.Errorf("tag has no %s header", key)
+		}
 	}
-	objLnk, err := obj.AsLink()
-	if err != nil {
-		return err
+	if signatures != nil {
+		out.signatures = _Signature_List__Maybe{m: schema.Maybe_Value, v: _Signature_List{signatures}}
+	}
+	if other != nil {
+		out.other = _Header_List__Maybe{m: schema.Maybe_Value, v: _Header_List{other}}
 	}
+	out.headerOrder = headerOrderOf(order, tagHeaders(&out))
+	return &out, nil
+}
 
-	tt, err := n.LookupByString("type")
-	if err != nil {
-		return err
+// tagHeaders returns the headerOrder keys of the headers of t, in the order
+// they are written in when it has no headerOrder.
+func tagHeaders(t *_Tag) []string {
+	keys := []string{"object", "type", "tag"}
+	if t.tagger.m == schema.Maybe_Value {
+		keys = append(keys, "tagger")
 	}
-	ttStr, err := tt.AsString()
-	if err != nil {
-		return err
+	if t.signatures.m == schema.Maybe_Value {
+		for range t.signatures.v.x {
+			keys = append(keys, "signature")
+		}
+	}
+	if t.other.m == schema.Maybe_Value {
+		for range t.other.v.x {
+			keys = append(keys, "other")
+		}
 	}
+	return keys
+}
 
-	tag, err := n.LookupByString("tag")
-	if err != nil {
-		return err
+func encodeTag(n ipld.Node, w io.Writer, f ObjectFormat) error {
+	ti := Type.Tag__Repr.NewBuilder()
+	if err := ti.AssignNode(n); err != nil {
+		return fmt.Errorf("not a Tag: %T %w", n, err)
 	}
-	tagStr, err := tag.AsString()
+	body, err := encodeTagBody(ti.Build().(Tag), f)
 	if err != nil {
 		return err
 	}
 
-	message, err := n.LookupByString("message")
-	if err != nil {
+	if _, err := fmt.Fprintf(w, "tag %d\x00", len(body)); err != nil {
 		return err
 	}
-	messageStr, err := message.AsString()
+	_, err = w.Write(body)
+	return err
+}
+
+// encodeTagBody encodes a tag without its object header, as it is embedded in
+// the mergetag headers of commits.
+func encodeTagBody(t Tag, f ObjectFormat) ([]byte, error) {
+	order, err := writeOrder(t.headerOrder, tagHeaders(t))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	tagger, taggerErr := n.LookupByString("tagger")
-
 	buf := new(bytes.Buffer)
-	fmt.Fprintf(buf, "object %s\n", hex.EncodeToString(sha(objLnk)))
-	fmt.Fprintf(buf, "type %s\n", ttStr)
-	fmt.Fprintf(buf, "tag %s\n", tagStr)
-	if taggerErr == nil && !tagger.IsNull() {
-		pi := Type.PersonInfo__Repr.NewBuilder()
-		if err := pi.AssignNode(tagger); err != nil {
-			return err
-		}
-		piN := pi.Build()
-		parsed, ok := piN.(*_PersonInfo)
-		if !ok {
-			return fmt.Errorf("could not parse tagger person info %v", tagger)
+	var signatures, others int
+	for _, key := range order {
+		switch key {
+		case "object":
+			object, err := t.object.sha(f)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(buf, "object %s\n", hex.EncodeToString(object))
+		case "type":
+			fmt.Fprintf(buf, "type %s\n", t.typ.x)
+		case "tag":
+			fmt.Fprintf(buf, "tag %s\n", t.tag.x)
+		case "tagger":
+			fmt.Fprintf(buf, "tagger %s\n", t.tagger.v.GitString())
+		case "signature":
+			sig, err := t.signatures.v.x[signatures].gitString()
+			if err != nil {
+				return nil, err
+			}
+			signatures++
+			fmt.Fprintln(buf, sig)
+		case "other":
+			fmt.Fprintln(buf, t.other.v.x[others].gitString())
+			others++
 		}
-		fmt.Fprintf(buf, "tagger %s\n", parsed.GitString())
-	}
-	if messageStr != "" {
-		fmt.Fprintf(buf, "\n%s", messageStr)
 	}
-
-	if _, err := fmt.Fprintf(w, "tag %d\x00", buf.Len()); err != nil {
-		return err
+	message := t.message.x
+	if t.signature.m == schema.Maybe_Value {
+		if message, err = joinTagMessage(message, t.signature.v.x); err != nil {
+			return nil, err
+		}
 	}
-	_, err = buf.WriteTo(w)
-	return err
+	fmt.Fprintf(buf, "\n%s", message)
+	return buf.Bytes(), nil
 }
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 
 	"github.com/ipld/go-ipld-prime"
 	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
@@ -12,6 +14,11 @@ import (
 
 // DecodeTree fills a NodeAssembler (from `Type.Tree__Repr.NewBuilder()`) from a stream of bytes
 func DecodeTree(na ipld.NodeAssembler, rd *bufio.Reader) error {
+	return DecodeOptions{}.DecodeTree(na, rd)
+}
+
+// DecodeTree fills a NodeAssembler (from `Type.Tree__Repr.NewBuilder()`) from a stream of bytes
+func (o DecodeOptions) DecodeTree(na ipld.NodeAssembler, rd *bufio.Reader) error {
 	if _, err := readNullTerminatedNumber(rd); err != nil {
 		return err
 	}
@@ -22,7 +29,7 @@ func DecodeTree(na ipld.NodeAssembler, rd *bufio.Reader) error {
 		return err
 	}
 	for {
-		name, node, err := DecodeTreeEntry(rd)
+		name, node, err := o.DecodeTreeEntry(rd)
 		if err != nil {
 			if err == io.EOF {
 				break
@@ -45,25 +52,36 @@ func DecodeTree(na ipld.NodeAssembler, rd *bufio.Reader) error {
 
 // DecodeTreeEntry fills a NodeAssembler (from `Type.TreeEntry__Repr.NewBuilder()`) from a stream of bytes
 func DecodeTreeEntry(rd *bufio.Reader) (string, ipld.Node, error) {
+	return DecodeOptions{}.DecodeTreeEntry(rd)
+}
+
+// DecodeTreeEntry fills a NodeAssembler (from `Type.TreeEntry__Repr.NewBuilder()`) from a stream of bytes
+//
+// It returns io.EOF only at the end of the stream, before any byte of an
+// entry, and io.ErrUnexpectedEOF when the stream ends within an entry.
+func (o DecodeOptions) DecodeTreeEntry(rd *bufio.Reader) (string, ipld.Node, error) {
 	data, err := rd.ReadString(' ')
 	if err != nil {
+		if err == io.EOF && data != "" {
+			err = io.ErrUnexpectedEOF
+		}
 		return "", nil, err
 	}
 	data = data[:len(data)-1]
 
 	name, err := rd.ReadString(0)
 	if err != nil {
-		return "", nil, err
+		return "", nil, unexpectedEOF(err)
 	}
 	name = name[:len(name)-1]
 
-	sha := make([]byte, 20)
+	sha := make([]byte, o.ObjectFormat.Size())
 	_, err = io.ReadFull(rd, sha)
 	if err != nil {
-		return "", nil, err
+		return "", nil, unexpectedEOF(err)
 	}
 
-	c, err := shaToCid(sha)
+	c, err := shaToCid(sha, o.ObjectFormat)
 	if err != nil {
 		return "", nil, err
 	}
@@ -75,8 +93,22 @@ func DecodeTreeEntry(rd *bufio.Reader) (string, ipld.Node, error) {
 	return name, &te, nil
 }
 
-func encodeTree(n ipld.Node, w io.Writer) error {
-	buf := new(bytes.Buffer)
+// unexpectedEOF returns io.ErrUnexpectedEOF for io.EOF, as the stream ending
+// within an entry is not the end of a tree.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func encodeTree(n ipld.Node, w io.Writer, o EncodeOptions) error {
+	type entry struct {
+		name string
+		key  string
+		node ipld.Node
+	}
+	var entries []entry
 
 	mi := n.MapIterator()
 	for !mi.Done() {
@@ -88,7 +120,23 @@ func encodeTree(n ipld.Node, w io.Writer) error {
 		if err != nil {
 			return err
 		}
-		if err := encodeTreeEntry(name, te, buf); err != nil {
+		sortKey, err := treeSortKey(name, te)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{name, sortKey, te})
+	}
+
+	// Entries are written in the order of the node, which for a decoded tree
+	// is the order it was read in, so that it encodes back to its bytes.
+	less := func(i, j int) bool { return entries[i].key < entries[j].key }
+	if o.StrictTreeOrder && !sort.SliceIsSorted(entries, less) {
+		return fmt.Errorf("tree entries are not in git order")
+	}
+
+	buf := new(bytes.Buffer)
+	for _, e := range entries {
+		if err := encodeTreeEntry(e.name, e.node, buf, o.ObjectFormat); err != nil {
 			return err
 		}
 	}
@@ -101,7 +149,28 @@ func encodeTree(n ipld.Node, w io.Writer) error {
 	return err
 }
 
-func encodeTreeEntry(name string, n ipld.Node, w io.Writer) error {
+// treeSortKey returns the string git sorts a tree entry by: its name, followed
+// by a slash if it is a directory.
+func treeSortKey(name string, te ipld.Node) (string, error) {
+	m, err := te.LookupByString("mode")
+	if err != nil {
+		return "", err
+	}
+	ms, err := m.AsString()
+	if err != nil {
+		return "", err
+	}
+	mode, err := strconv.ParseUint(ms, 8, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid mode %q of tree entry %q", ms, name)
+	}
+	if mode&0170000 == 0040000 {
+		return name + "/", nil
+	}
+	return name, nil
+}
+
+func encodeTreeEntry(name string, n ipld.Node, w io.Writer, f ObjectFormat) error {
 	m, err := n.LookupByString("mode")
 	if err != nil {
 		return err
@@ -114,19 +183,19 @@ func encodeTreeEntry(name string, n ipld.Node, w io.Writer) error {
 	if err != nil {
 		return err
 	}
-	_, err = fmt.Fprintf(w, "%s %s\x00", ms, name)
+	hal, err := ha.AsLink()
 	if err != nil {
 		return err
 	}
-
-	hal, err := ha.AsLink()
+	hash, err := sha(hal, f)
 	if err != nil {
 		return err
 	}
-	_, err = w.Write(cidToSha(hal.(cidlink.Link).Cid))
+
+	_, err = fmt.Fprintf(w, "%s %s\x00", ms, name)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	_, err = w.Write(hash)
+	return err
 }
@@ -0,0 +1,140 @@
+package ipldgit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// buildTree builds a tree with entries in the order given, each a mode and
+// name pair.
+func buildTree(t *testing.T, entries ...string) ipld.Node {
+	c, err := ObjectFormatSHA1.Cid(make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := qp.BuildMap(Type.Tree, -1, func(ma ipld.MapAssembler) {
+		for i := 0; i < len(entries); i += 2 {
+			qp.MapEntry(ma, entries[i+1], qp.Map(2, func(ma ipld.MapAssembler) {
+				qp.MapEntry(ma, "mode", qp.String(entries[i]))
+				qp.MapEntry(ma, "hash", qp.Link(cidlink.Link{Cid: c}))
+			}))
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func treeNames(t *testing.T, raw []byte) []string {
+	n, err := ParseObjectFromBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for mi := n.MapIterator(); !mi.Done(); {
+		k, _, err := mi.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, _ := k.AsString()
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestTreeOrder(t *testing.T) {
+	// Directories sort as if their names ended in a slash, which sorts after
+	// '-' and '.', while files and submodules sort by their names alone.
+	unsorted := buildTree(t,
+		"40000", "a",
+		"100644", "a.txt",
+		"160000", "b",
+		"100644", "a-b",
+		"100755", "a0",
+		"040000", "b.d",
+	)
+	given := "a a.txt b a-b a0 b.d"
+	want := "a-b a.txt a a0 b b.d"
+
+	// Trees are written in the order of their entries, so that a tree decoded
+	// out of order encodes back to its bytes.
+	buf := new(bytes.Buffer)
+	if err := Encode(unsorted, buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(treeNames(t, buf.Bytes()), " "); got != given {
+		t.Fatalf("tree encoded in order %q, want %q", got, given)
+	}
+	testRoundTrip(t, buf.Bytes())
+
+	err := EncodeOptions{StrictTreeOrder: true}.Encode(unsorted, new(bytes.Buffer))
+	if err == nil || !strings.Contains(err.Error(), "not in git order") {
+		t.Fatalf("expected an order error, got %v", err)
+	}
+
+	// TreeBuilder sorts the entries, and a sorted tree encodes the same in
+	// strict mode.
+	c, err := ObjectFormatSHA1.Cid(make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted, _, err := NewTreeBuilder(ObjectFormatSHA1).AddDir("a", c).AddFile("a.txt", c, false).
+		AddSubmodule("b", c).AddFile("a-b", c, false).AddFile("a0", c, true).AddDir("b.d", c).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := Encode(sorted, buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(treeNames(t, buf.Bytes()), " "); got != want {
+		t.Fatalf("built tree encoded in order %q, want %q", got, want)
+	}
+	strict := new(bytes.Buffer)
+	if err := (EncodeOptions{StrictTreeOrder: true}).Encode(sorted, strict); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(strict.Bytes(), buf.Bytes()) {
+		t.Fatal("sorted tree encodes differently in strict mode")
+	}
+
+	if err := Encode(buildTree(t, "10x644", "bad"), new(bytes.Buffer)); err == nil {
+		t.Fatal("expected an error for an invalid mode")
+	}
+}
+
+func TestTreeTruncated(t *testing.T) {
+	entry := "100644 a.txt\x00" + strings.Repeat("\x01", 20)
+	for _, test := range []struct {
+		name, body string
+		err        error
+	}{
+		{"Empty", "", nil},
+		{"Whole", entry + "100644 b.txt\x00" + strings.Repeat("\x02", 20), nil},
+		{"InMode", entry + "1006", io.ErrUnexpectedEOF},
+		{"InName", entry + "100644 a.t", io.ErrUnexpectedEOF},
+		{"NoHash", entry + "100644 a.txt\x00", io.ErrUnexpectedEOF},
+		{"InHash", entry + "100644 a.txt\x00\x01\x01", io.ErrUnexpectedEOF},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseObjectFromBuffer(object("tree", test.body))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+
+	// A SHA-1 hash is a truncated SHA-256 one.
+	raw := object("tree", entry)
+	if _, err := (DecodeOptions{ObjectFormat: ObjectFormatSHA256}).ParseObjectFromBuffer(raw); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
@@ -0,0 +1,103 @@
+package ipldgit
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/schema"
+)
+
+// The hash of a tree entry and the object of a tag are untyped links in the
+// schema, as the type of object they name is given by another field: the mode
+// of the entry, or the type of the tag. Only an inline union keyed by that
+// field could type them and keep the data as it is, and gengo does not
+// generate inline unions. The accessors below return them as the typed links
+// of the schema, whose LinkTargetNodePrototype is the prototype of the object
+// named.
+
+// TreeLink returns the subtree a directory entry names.
+func (n TreeEntry) TreeLink() (Tree_Link, error) {
+	if k, err := n.EntryKind(); err != nil || k != EntryDirectory {
+		return nil, fmt.Errorf("tree entry with mode %s is not a directory", n.mode.x)
+	}
+	return &_Tree_Link{n.hash.x}, nil
+}
+
+// BlobLink returns the blob a file, executable or symlink entry names.
+func (n TreeEntry) BlobLink() (Blob_Link, error) {
+	switch k, _ := n.EntryKind(); k {
+	case EntryFile, EntryExecutable, EntrySymlink:
+		return &_Blob_Link{n.hash.x}, nil
+	default:
+		return nil, fmt.Errorf("tree entry with mode %s is not a blob", n.mode.x)
+	}
+}
+
+// TypedLink returns the hash of the entry as a Tree_Link, Blob_Link or
+// Commit_Link, according to its mode.
+func (n TreeEntry) TypedLink() (schema.TypedLinkNode, error) {
+	k, err := n.EntryKind()
+	if err != nil {
+		return nil, err
+	}
+	switch k {
+	case EntryDirectory:
+		return n.TreeLink()
+	case EntryGitlink:
+		return n.CommitLink()
+	default:
+		return n.BlobLink()
+	}
+}
+
+// TypedObject returns the object of the tag as a Commit_Link, Tree_Link,
+// Blob_Link or Tag_Link, according to its type.
+func (n Tag) TypedObject() (schema.TypedLinkNode, error) {
+	switch n.typ.x {
+	case "commit":
+		return &_Commit_Link{n.object.x}, nil
+	case "tree":
+		return &_Tree_Link{n.object.x}, nil
+	case "blob":
+		return &_Blob_Link{n.object.x}, nil
+	case "tag":
+		return &_Tag_Link{n.object.x}, nil
+	default:
+		return nil, fmt.Errorf("unknown tag object type %q", n.typ.x)
+	}
+}
+
+// LinkTargetPrototype chooses the prototype of the object a link names, for
+// use as the LinkTargetNodePrototypeChooser of a traversal. Links that are
+// typed in the schema give their own prototype, while tree entry hashes and tag
+// objects are typed from their mode or type. As with the generated link types,
+// the prototypes are those of the representations, such as Type.Tree__Repr.
+// Other links, such as those reached through nodes loaded with basicnode
+// prototypes, load as basicnode.Prototype.Any.
+func LinkTargetPrototype(lnk ipld.Link, lctx ipld.LinkContext) (ipld.NodePrototype, error) {
+	if tl, ok := lctx.LinkNode.(schema.TypedLinkNode); ok {
+		return tl.LinkTargetNodePrototype(), nil
+	}
+
+	var (
+		tl  schema.TypedLinkNode
+		err error
+	)
+	switch p := lctx.ParentNode.(type) {
+	case TreeEntry:
+		tl, err = p.TypedLink()
+	case *_TreeEntry__Repr:
+		tl, err = (*_TreeEntry)(p).TypedLink()
+	case Tag:
+		tl, err = p.TypedObject()
+	case *_Tag__Repr:
+		tl, err = (*_Tag)(p).TypedObject()
+	default:
+		return basicnode.Prototype.Any, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tl.LinkTargetNodePrototype(), nil
+}
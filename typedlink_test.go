@@ -0,0 +1,89 @@
+package ipldgit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+)
+
+func TestTypedLinks(t *testing.T) {
+	ls := memLinkSystem()
+	blob := storeRaw(t, ls, "blob 6\x00hello\n")
+	sub := storeTree(t, ls, "100755", "run.sh", hex.EncodeToString(blob))
+	tree := storeTree(t, ls,
+		"100644", "README", hex.EncodeToString(blob),
+		"40000", "bin", hex.EncodeToString(sub),
+		"160000", "vendor", hex.EncodeToString(make([]byte, 20)),
+	)
+	commit := storeCommit(t, ls, tree, "commit\n")
+	body := fmt.Sprintf("object %x\ntype commit\ntag v1\ntagger A U Thor <author@example.com> 1 +0000\n\nrelease\n", commit)
+	tag := storeRaw(t, ls, fmt.Sprintf("tag %d\x00%s", len(body), body))
+
+	treeCid, _ := ObjectFormatSHA1.Cid(tree)
+	n, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: treeCid}, Type.Tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]ipld.NodePrototype{
+		"README": Type.Blob__Repr,
+		"bin":    Type.Tree__Repr,
+		"vendor": Type.Commit__Repr,
+	} {
+		tl, err := n.(Tree).Lookup(&_String{name}).TypedLink()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tl.LinkTargetNodePrototype() != want {
+			t.Fatalf("%s: link to %T", name, tl.LinkTargetNodePrototype())
+		}
+	}
+	if _, err := n.(Tree).Lookup(&_String{"README"}).TreeLink(); err == nil {
+		t.Fatal("expected an error for the tree link of a file")
+	}
+	if _, err := n.(Tree).Lookup(&_String{"bin"}).BlobLink(); err == nil {
+		t.Fatal("expected an error for the blob link of a directory")
+	}
+
+	// A traversal from the tag loads every object with its schema type.
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := selector.CompileSelector(ssb.ExploreRecursive(selector.RecursionLimitNone(),
+		ssb.ExploreUnion(ssb.Matcher(), ssb.ExploreAll(ssb.ExploreRecursiveEdge()))).Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagCid, _ := ObjectFormatSHA1.Cid(tag)
+	root, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: tagCid}, Type.Tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[ipld.NodePrototype]int)
+	err = traversal.Progress{
+		Cfg: &traversal.Config{
+			LinkSystem:                     GitlinkLinkSystem(ls, GitlinkSkip, nil),
+			LinkTargetNodePrototypeChooser: LinkTargetPrototype,
+		},
+	}.WalkMatching(root, sel, func(p traversal.Progress, n ipld.Node) error {
+		seen[n.Prototype()]++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for np, want := range map[ipld.NodePrototype]int{
+		Type.Tag:    1,
+		Type.Commit: 1,
+		Type.Tree:   2,
+		Type.Blob:   2,
+	} {
+		if seen[np] != want {
+			t.Errorf("visited %d %T nodes, want %d", seen[np], np, want)
+		}
+	}
+}
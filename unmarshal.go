@@ -2,14 +2,40 @@ package ipldgit
 
 import (
 	"bufio"
+	"bytes"
+	"compress/zlib"
 	"fmt"
 	"io"
 
 	"github.com/ipld/go-ipld-prime"
 )
 
+// DecodeOptions configures how git objects are read. The zero value reads
+// SHA-1 objects, as Decode and the other package-level decoders do.
+type DecodeOptions struct {
+	// ObjectFormat is the hash function the objects were written with. It sets
+	// the width of the hashes in tree entries and in tree, parent and object
+	// headers, and the multihash of the links made from them.
+	ObjectFormat ObjectFormat
+	// BlobContent makes blobs decode to their content alone, without the
+	// "blob <size>\x00" header git hashes them with. ParseObject then returns
+	// blobs as BlobContent nodes, which Encode writes with the header restored.
+	// Decode fails to decode a blob into an assembler of any other prototype,
+	// whose node would encode without its header. Blobs decoded into
+	// BlobContentPrototype lose their header whether this is set or not.
+	BlobContent bool
+}
+
 // Decode reads from a reader to fill a NodeAssembler
 func Decode(na ipld.NodeAssembler, r io.Reader) error {
+	return DecodeOptions{}.Decode(na, r)
+}
+
+// Decode reads from a reader to fill a NodeAssembler
+func (o DecodeOptions) Decode(na ipld.NodeAssembler, r io.Reader) error {
+	if !o.ObjectFormat.valid() {
+		return fmt.Errorf("unsupported object format: %s", o.ObjectFormat)
+	}
 	rd := bufio.NewReader(r)
 
 	typ, err := rd.ReadString(' ')
@@ -23,13 +49,13 @@ func Decode(na ipld.NodeAssembler, r io.Reader) error {
 
 	switch typ {
 	case "tree":
-		return DecodeTree(na, rd)
+		return o.DecodeTree(na, rd)
 	case "commit":
-		return DecodeCommit(na, rd)
+		return o.DecodeCommit(na, rd)
 	case "blob":
-		return DecodeBlob(na, rd)
+		return o.DecodeBlob(na, rd)
 	case "tag":
-		return DecodeTag(na, rd)
+		return o.DecodeTag(na, rd)
 	default:
 		return fmt.Errorf("unrecognized object type: %q", typ)
 	}
@@ -37,6 +63,14 @@ func Decode(na ipld.NodeAssembler, r io.Reader) error {
 
 // ParseObject produces an ipld.Node from a stream / binary represnetation.
 func ParseObject(r io.Reader) (ipld.Node, error) {
+	return DecodeOptions{}.ParseObject(r)
+}
+
+// ParseObject produces an ipld.Node from a stream / binary represnetation.
+func (o DecodeOptions) ParseObject(r io.Reader) (ipld.Node, error) {
+	if !o.ObjectFormat.valid() {
+		return nil, fmt.Errorf("unsupported object format: %s", o.ObjectFormat)
+	}
 	rd := bufio.NewReader(r)
 
 	typ, err := rd.ReadString(' ')
@@ -53,16 +87,19 @@ func ParseObject(r io.Reader) (ipld.Node, error) {
 	switch typ {
 	case "tree":
 		na = Type.Tree.NewBuilder()
-		decode = DecodeTree
+		decode = o.DecodeTree
 	case "commit":
 		na = Type.Commit.NewBuilder()
-		decode = DecodeCommit
+		decode = o.DecodeCommit
 	case "blob":
 		na = Type.Blob.NewBuilder()
-		decode = DecodeBlob
+		if o.BlobContent {
+			na = BlobContentPrototype.NewBuilder()
+		}
+		decode = o.DecodeBlob
 	case "tag":
 		na = Type.Tag.NewBuilder()
-		decode = DecodeTag
+		decode = o.DecodeTag
 	default:
 		return nil, fmt.Errorf("unrecognized object type: %q", typ)
 	}
@@ -73,3 +110,19 @@ func ParseObject(r io.Reader) (ipld.Node, error) {
 	}
 	return na.Build(), nil
 }
+
+// ParseCompressedObject works like ParseObject, but with a surrounding zlib compression.
+func (o DecodeOptions) ParseCompressedObject(r io.Reader) (ipld.Node, error) {
+	rc, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return o.ParseObject(rc)
+}
+
+// ParseObjectFromBuffer is like ParseObject, but with a fully in-memory stream
+func (o DecodeOptions) ParseObjectFromBuffer(b []byte) (ipld.Node, error) {
+	return o.ParseObject(bytes.NewReader(b))
+}
@@ -9,55 +9,50 @@ import (
 	mh "github.com/multiformats/go-multihash"
 )
 
-// gitSHALen is the length of a git object hash. A reference of any other length
-// cannot name a git object, and cidToSha assumes this width when it turns a CID
-// back into one.
-const gitSHALen = 20
-
-func shaToCid(sha []byte) (cid.Cid, error) {
-	if len(sha) != gitSHALen {
-		return cid.Undef, fmt.Errorf("invalid git sha of %d bytes, expected %d", len(sha), gitSHALen)
+func shaToCid(sha []byte, f ObjectFormat) (cid.Cid, error) {
+	if len(sha) != f.Size() {
+		return cid.Undef, fmt.Errorf("invalid git %s of %d bytes, expected %d", f, len(sha), f.Size())
 	}
-	h, err := mh.Encode(sha, mh.SHA1)
+	h, err := mh.Encode(sha, f.multihash())
 	if err != nil {
 		return cid.Undef, err
 	}
 	return cid.NewCidV1(cid.GitRaw, h), nil
 }
 
-func cidToSha(c cid.Cid) []byte {
-	h := c.Hash()
-	return h[len(h)-20:]
+// cidToSha returns the git object hash a CID carries. With ObjectFormatDefault
+// either format is accepted; otherwise the CID must be in the given format.
+func cidToSha(c cid.Cid, f ObjectFormat) ([]byte, error) {
+	dh, err := mh.Decode(c.Hash())
+	if err != nil {
+		return nil, err
+	}
+	lf, err := formatOf(dh.Code, dh.Length)
+	if err != nil {
+		return nil, err
+	}
+	if f != ObjectFormatDefault && lf != f {
+		return nil, fmt.Errorf("link %s is a %s hash, expected %s", c, lf, f)
+	}
+	return dh.Digest, nil
 }
 
-func sha(l ipld.Link) []byte {
+func sha(l ipld.Link, f ObjectFormat) ([]byte, error) {
 	cl, ok := l.(cidlink.Link)
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("unsupported link type %T", l)
 	}
-	return cidToSha(cl.Cid)
+	return cidToSha(cl.Cid, f)
 }
 
-func (l Link) sha() []byte {
-	cl, ok := l.x.(cidlink.Link)
-	if !ok {
-		return nil
-	}
-	return cidToSha(cl.Cid)
+func (l Link) sha(f ObjectFormat) ([]byte, error) {
+	return sha(l.x, f)
 }
 
-func (l Tree_Link) sha() []byte {
-	cl, ok := l.x.(cidlink.Link)
-	if !ok {
-		return nil
-	}
-	return cidToSha(cl.Cid)
+func (l Tree_Link) sha(f ObjectFormat) ([]byte, error) {
+	return sha(l.x, f)
 }
 
-func (l Commit_Link) sha() []byte {
-	cl, ok := l.x.(cidlink.Link)
-	if !ok {
-		return nil
-	}
-	return cidToSha(cl.Cid)
+func (l Commit_Link) sha(f ObjectFormat) ([]byte, error) {
+	return sha(l.x, f)
 }
@@ -0,0 +1,146 @@
+package worktree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// Checkout writes the files of a tree, loaded with ls, into dir, which is
+// created if needed. Files are executable when their mode is 100755, symbolic
+// links are created for mode 120000, and submodules are left as empty
+// directories, as git leaves submodules that are not checked out.
+//
+// Entries that ipldgit.CheckEntryName refuses, such as "..", names holding a
+// slash, or .git and the names NTFS or HFS+ take for it, are refused, and
+// every file is written through an os.Root, so that nothing is written outside
+// dir, even through the symbolic links of the tree. Existing files are not
+// overwritten: checking out over them is an error.
+//
+// Objects are decoded in the object format of their links, SHA-1 or SHA-256,
+// whatever the DecoderChooser of ls, and file content is streamed from the
+// storage of ls to disk.
+func Checkout(ctx context.Context, ls ipld.LinkSystem, tree cid.Cid, dir string) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	c := &checkout{ctx: ctx, ls: gitLinkSystem(ls), root: root}
+	return c.writeTree(cidlink.Link{Cid: tree}, ".")
+}
+
+type checkout struct {
+	ctx  context.Context
+	ls   ipld.LinkSystem
+	root *os.Root
+}
+
+func (c *checkout) writeTree(lnk ipld.Link, dir string) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	n, err := c.ls.Load(ipld.LinkContext{Ctx: c.ctx}, lnk, ipldgit.Type.Tree)
+	if err != nil {
+		return fmt.Errorf("loading tree %s: %w", dir, err)
+	}
+
+	for it := n.(ipldgit.Tree).Iterator(); !it.Done(); {
+		k, te := it.Next()
+		if err := checkName(k.String()); err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+		name := path.Join(dir, k.String())
+		kind, err := te.EntryKind()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		switch kind {
+		case ipldgit.EntryDirectory:
+			if err := c.root.Mkdir(name, 0777); err != nil {
+				return err
+			}
+			tl, _ := te.TreeLink()
+			if err := c.writeTree(tl.Link(), name); err != nil {
+				return err
+			}
+		case ipldgit.EntryGitlink:
+			if err := c.root.Mkdir(name, 0777); err != nil {
+				return err
+			}
+		default:
+			bl, _ := te.BlobLink()
+			if err := c.writeBlob(bl.Link(), name, kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeBlob writes the blob lnk as the file or symbolic link name, streaming
+// its content from storage rather than holding it in memory.
+func (c *checkout) writeBlob(lnk ipld.Link, name string, kind ipldgit.EntryKind) error {
+	b, err := ipldgit.LoadStreamingBlob(ipld.LinkContext{Ctx: c.ctx}, c.ls, lnk)
+	if err != nil {
+		return fmt.Errorf("loading blob %s: %w", name, err)
+	}
+	r, err := b.Reader()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	switch kind {
+	case ipldgit.EntrySymlink:
+		target, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return c.root.Symlink(string(target), name)
+	case ipldgit.EntryExecutable:
+		return c.writeFile(name, r, 0777)
+	default:
+		return c.writeFile(name, r, 0666)
+	}
+}
+
+// writeFile creates the file name, which must not exist, with the permissions
+// perm less the umask.
+func (c *checkout) writeFile(name string, content io.Reader, perm os.FileMode) error {
+	f, err := c.root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// checkName checks that a tree entry name is a single path component that is
+// safe to write, refusing those git refuses to check out, and those holding the
+// path separator of the system.
+func checkName(name string) error {
+	if err := ipldgit.CheckEntryName(name); err != nil {
+		return err
+	}
+	if strings.ContainsRune(name, os.PathSeparator) {
+		return fmt.Errorf("tree entry name %q is not a single path component", name)
+	}
+	return nil
+}
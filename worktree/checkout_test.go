@@ -0,0 +1,166 @@
+package worktree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+func storeRaw(t *testing.T, ls ipld.LinkSystem, typ, body string) cid.Cid {
+	t.Helper()
+	n, err := ipldgit.ParseObjectFromBuffer(fmt.Appendf(nil, "%s %d\x00%s", typ, len(body), body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA1.Prefix()}, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lnk.(cidlink.Link).Cid
+}
+
+func TestCheckout(t *testing.T) {
+	ctx := context.Background()
+	ls, _ := memLinkSystem()
+	tree, err := WriteTree(ctx, ls, testFS, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "checkout")
+	if err := Checkout(ctx, ls, tree, dir); err != nil {
+		t.Fatal(err)
+	}
+	again, err := WriteTreeDir(ctx, ls, dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Equals(tree) {
+		t.Fatalf("checked out tree hashes to %s, want %s", again, tree)
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, "run")); err != nil || info.Mode()&0100 == 0 {
+		t.Fatalf("run is not executable: %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "link")); err != nil || target != "hello" {
+		t.Fatalf("link points to %q: %v", target, err)
+	}
+	if err := Checkout(ctx, ls, tree, dir); err == nil {
+		t.Fatal("checking out over existing files succeeded")
+	}
+}
+
+func TestCheckoutSHA256(t *testing.T) {
+	ctx := context.Background()
+	ls, _ := memLinkSystem()
+	opts := Options{ObjectFormat: ipldgit.ObjectFormatSHA256}
+	tree, err := WriteTree(ctx, ls, testFS, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "checkout")
+	if err := Checkout(ctx, ls, tree, dir); err != nil {
+		t.Fatal(err)
+	}
+	again, err := WriteTreeDir(ctx, ls, dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Equals(tree) {
+		t.Fatalf("checked out tree hashes to %s, want %s", again, tree)
+	}
+}
+
+// A blob whose stored content does not match its hash is refused before its
+// file is created.
+func TestCheckoutCorruptBlob(t *testing.T) {
+	ls, store := memLinkSystem()
+	blob := storeRaw(t, ls, "blob", "data\n")
+	store.Bag[cidlink.Link{Cid: blob}.Binary()] = []byte("blob 5\x00evil\n")
+	sha, _ := ipldgit.ObjectFormatSHA1.Sha(blob)
+	tree := storeRaw(t, ls, "tree", fmt.Sprintf("100644 file\x00%s", sha))
+
+	dir := filepath.Join(t.TempDir(), "checkout")
+	if err := Checkout(context.Background(), ls, tree, dir); err == nil {
+		t.Fatal("expected a hash mismatch")
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "file")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("file of a corrupt blob written: %v", err)
+	}
+}
+
+func TestCheckoutSubmodule(t *testing.T) {
+	ls, _ := memLinkSystem()
+	commit := hashCid(t, "2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51")
+	tree, _, err := ipldgit.NewTreeBuilder(ipldgit.ObjectFormatSHA1).AddSubmodule("sub", commit).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lnk, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA1.Prefix()}, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := Checkout(context.Background(), ls, lnk.(cidlink.Link).Cid, dir); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "sub"))
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("submodule is not an empty directory: %v %v", entries, err)
+	}
+}
+
+func TestCheckoutRefused(t *testing.T) {
+	ls, _ := memLinkSystem()
+	blob := storeRaw(t, ls, "blob", "data\n")
+	sha, _ := ipldgit.ObjectFormatSHA1.Sha(blob)
+	empty := storeRaw(t, ls, "tree", "")
+	emptySha, _ := ipldgit.ObjectFormatSHA1.Sha(empty)
+
+	for _, test := range []struct {
+		name, mode, entry string
+	}{
+		{"DotDot", "40000", ".."},
+		{"Dot", "40000", "."},
+		{"DotGit", "40000", ".git"},
+		{"DotGitUpper", "100644", ".GIT"},
+		{"DotGitNTFS", "40000", ".git. "},
+		{"DotGitShortName", "40000", "GIT~1"},
+		{"DotGitStream", "100644", ".git::$INDEX_ALLOCATION"},
+		{"DotGitHFS", "40000", ".g\u200dit"},
+		{"Slash", "100644", "a/b"},
+		{"Absolute", "100644", "/tmp/evil"},
+		{"Parent", "100644", "../evil"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			hash := sha
+			if test.mode == "40000" {
+				hash = emptySha
+			}
+			tree := storeRaw(t, ls, "tree", fmt.Sprintf("%s %s\x00%s", test.mode, test.entry, hash))
+
+			parent := t.TempDir()
+			if err := Checkout(context.Background(), ls, tree, filepath.Join(parent, "checkout")); err == nil {
+				t.Fatal("expected an error")
+			}
+			entries, err := os.ReadDir(parent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("files written outside the checkout: %v", entries)
+			}
+		})
+	}
+}
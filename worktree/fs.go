@@ -0,0 +1,436 @@
+package worktree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+)
+
+var (
+	_ fs.ReadDirFS  = (*FS)(nil)
+	_ fs.ReadFileFS = (*FS)(nil)
+	_ fs.StatFS     = (*FS)(nil)
+	_ fs.ReadLinkFS = (*FS)(nil)
+)
+
+// maxSymlinks bounds the symbolic links followed to resolve a path, as Linux
+// does.
+const maxSymlinks = 40
+
+// FS is a read-only file system over the files of a git tree. Subtrees and
+// blobs are loaded through a LinkSystem as they are needed, and the subtrees
+// loaded are kept in memory, while files are streamed from storage as they are
+// read. It is safe for concurrent use.
+//
+// Files have mode 0644, or 0755 when executable, and directories 0755.
+// Symbolic links are followed when they point into the tree. Submodules are
+// empty directories, as they are when checked out. Every file has the date of
+// the commit the FS was made from as its modification time.
+type FS struct {
+	ctx     context.Context
+	ls      ipld.LinkSystem
+	root    ipldgit.Tree
+	modTime time.Time
+
+	mu    sync.Mutex
+	trees map[string]ipldgit.Tree
+}
+
+// NewFS returns a file system over a Tree, or over the tree of a Commit. Both
+// must be nodes of the ipldgit schema types, such as ParseObject returns, or
+// loading a link with Type.Tree or Type.Commit. The context is used for every
+// load through ls, which decodes objects with ipldgit.DecoderChooser so that
+// trees of either object format can be read.
+func NewFS(ctx context.Context, ls ipld.LinkSystem, n ipld.Node) (*FS, error) {
+	f := &FS{ctx: ctx, ls: gitLinkSystem(ls), trees: make(map[string]ipldgit.Tree)}
+	switch n := n.(type) {
+	case ipldgit.Tree:
+		f.root = n
+	case ipldgit.Commit:
+		root, err := f.loadTree(n.FieldTree().Link())
+		if err != nil {
+			return nil, err
+		}
+		f.root = root
+		if p := n.FieldCommitter(); p.Exists() {
+			if t, err := p.Must().Time(); err == nil {
+				f.modTime = t
+			}
+		}
+	default:
+		return nil, fmt.Errorf("a %T is not a Tree or Commit", n)
+	}
+	return f, nil
+}
+
+func (f *FS) loadTree(lnk ipld.Link) (ipldgit.Tree, error) {
+	key := lnk.Binary()
+	f.mu.Lock()
+	t, ok := f.trees[key]
+	f.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	n, err := f.ls.Load(ipld.LinkContext{Ctx: f.ctx}, lnk, ipldgit.Type.Tree)
+	if err != nil {
+		return nil, err
+	}
+	t = n.(ipldgit.Tree)
+	f.mu.Lock()
+	f.trees[key] = t
+	f.mu.Unlock()
+	return t, nil
+}
+
+func (f *FS) loadBlob(lnk ipld.Link) ([]byte, error) {
+	n, err := f.ls.Load(ipld.LinkContext{Ctx: f.ctx}, lnk, ipldgit.BlobContentPrototype)
+	if err != nil {
+		return nil, err
+	}
+	return n.AsBytes()
+}
+
+// blobSize returns the size of the blob lnk, reading only its header.
+func (f *FS) blobSize(lnk ipld.Link) (int64, error) {
+	if f.ls.StorageReadOpener == nil {
+		return 0, errors.New("no storage configured for reading")
+	}
+	b, err := ipldgit.OpenBlob(func() (io.ReadCloser, error) {
+		r, err := f.ls.StorageReadOpener(ipld.LinkContext{Ctx: f.ctx}, lnk)
+		if err != nil {
+			return nil, err
+		}
+		if rc, ok := r.(io.ReadCloser); ok {
+			return rc, nil
+		}
+		return io.NopCloser(r), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return b.Size(), nil
+}
+
+// node is a file of the tree: its root, or the entry of a subtree.
+type node struct {
+	name  string
+	entry ipldgit.TreeEntry
+	kind  ipldgit.EntryKind
+}
+
+func (n *node) isDir() bool {
+	return n.kind == ipldgit.EntryDirectory || n.kind == ipldgit.EntryGitlink
+}
+
+func (n *node) mode() fs.FileMode {
+	switch n.kind {
+	case ipldgit.EntryDirectory, ipldgit.EntryGitlink:
+		return fs.ModeDir | 0755
+	case ipldgit.EntryExecutable:
+		return 0755
+	case ipldgit.EntrySymlink:
+		return fs.ModeSymlink | 0777
+	default:
+		return 0644
+	}
+}
+
+// entries returns the entries of a directory, sorted by name.
+func (f *FS) entries(n *node) ([]*node, error) {
+	if n.kind == ipldgit.EntryGitlink {
+		return nil, nil
+	}
+	t := f.root
+	if n.entry != nil {
+		tl, err := n.entry.TreeLink()
+		if err != nil {
+			return nil, err
+		}
+		if t, err = f.loadTree(tl.Link()); err != nil {
+			return nil, err
+		}
+	}
+
+	var entries []*node
+	for it := t.Iterator(); !it.Done(); {
+		k, te := it.Next()
+		kind, err := te.EntryKind()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &node{name: k.String(), entry: te, kind: kind})
+	}
+	slices.SortFunc(entries, func(a, b *node) int { return strings.Compare(a.name, b.name) })
+	return entries, nil
+}
+
+// lookup returns the file name, following symbolic links in its directories,
+// and the link it names if follow is set.
+func (f *FS) lookup(op, name string, follow bool) (*node, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	root := &node{name: ".", kind: ipldgit.EntryDirectory}
+	cur, dir := root, ""
+	rest := strings.Split(name, "/")
+	if name == "." {
+		rest = nil
+	}
+	for links := 0; len(rest) > 0; {
+		if !cur.isDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		entries, err := f.entries(cur)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		i := slices.IndexFunc(entries, func(n *node) bool { return n.name == rest[0] })
+		if i < 0 {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		next := entries[i]
+		if next.kind != ipldgit.EntrySymlink || (len(rest) == 1 && !follow) {
+			cur, dir, rest = next, path.Join(dir, rest[0]), rest[1:]
+			continue
+		}
+
+		if links++; links > maxSymlinks {
+			return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+		}
+		target, err := f.readLink(next)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		resolved := path.Join(dir, target)
+		if path.IsAbs(target) || resolved == ".." || strings.HasPrefix(resolved, "../") {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fmt.Errorf("symbolic link to %q leaves the tree", target)}
+		}
+		cur, dir = root, ""
+		if resolved != "." {
+			rest = append(strings.Split(resolved, "/"), rest[1:]...)
+		} else {
+			rest = rest[1:]
+		}
+	}
+	return cur, nil
+}
+
+func (f *FS) readLink(n *node) (string, error) {
+	bl, err := n.entry.BlobLink()
+	if err != nil {
+		return "", err
+	}
+	target, err := f.loadBlob(bl.Link())
+	return string(target), err
+}
+
+func (f *FS) info(n *node) (*fileInfo, error) {
+	fi := &fileInfo{name: path.Base(n.name), mode: n.mode(), modTime: f.modTime, entry: n.entry}
+	if !n.isDir() {
+		bl, err := n.entry.BlobLink()
+		if err != nil {
+			return nil, err
+		}
+		if fi.size, err = f.blobSize(bl.Link()); err != nil {
+			return nil, err
+		}
+	}
+	return fi, nil
+}
+
+// Open opens the file name, following symbolic links. Files can be read and
+// seeked, and directories read with ReadDir.
+func (f *FS) Open(name string) (fs.File, error) {
+	n, err := f.lookup("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	fi := &fileInfo{name: path.Base(name), mode: n.mode(), modTime: f.modTime, entry: n.entry}
+	if n.isDir() {
+		entries, err := f.entries(n)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return &openDir{f: f, info: fi, entries: entries}, nil
+	}
+
+	bl, err := n.entry.BlobLink()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	b, err := ipldgit.LoadStreamingBlob(ipld.LinkContext{Ctx: f.ctx}, f.ls, bl.Link())
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	rc, err := b.Reader()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	fi.size = b.Size()
+	return &openFile{ReadSeekCloser: rc, info: fi}, nil
+}
+
+// ReadDir returns the entries of the directory name, sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	n, err := f.lookup("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !n.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries, err := f.entries(n)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	des := make([]fs.DirEntry, len(entries))
+	for i, e := range entries {
+		des[i] = &dirEntry{f: f, n: e}
+	}
+	return des, nil
+}
+
+// ReadFile returns the content of the file name.
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	n, err := f.lookup("readfile", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if n.isDir() {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	bl, err := n.entry.BlobLink()
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	data, err := f.loadBlob(bl.Link())
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// Stat describes the file name, following symbolic links.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	return f.stat("stat", name, true)
+}
+
+// Lstat describes the file name, without following it if it is a symbolic
+// link.
+func (f *FS) Lstat(name string) (fs.FileInfo, error) {
+	return f.stat("lstat", name, false)
+}
+
+func (f *FS) stat(op, name string, follow bool) (fs.FileInfo, error) {
+	n, err := f.lookup(op, name, follow)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.info(n)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	fi.name = path.Base(name)
+	return fi, nil
+}
+
+// ReadLink returns the target of the symbolic link name.
+func (f *FS) ReadLink(name string) (string, error) {
+	n, err := f.lookup("readlink", name, false)
+	if err != nil {
+		return "", err
+	}
+	if n.kind != ipldgit.EntrySymlink {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	target, err := f.readLink(n)
+	if err != nil {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: err}
+	}
+	return target, nil
+}
+
+// fileInfo describes a file. Its Sys is the TreeEntry of the file, or nil for
+// the root.
+type fileInfo struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	entry   ipldgit.TreeEntry
+	size    int64
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+
+func (fi *fileInfo) Sys() any {
+	if fi.entry == nil {
+		return nil
+	}
+	return fi.entry
+}
+
+type dirEntry struct {
+	f *FS
+	n *node
+}
+
+func (de *dirEntry) Name() string               { return de.n.name }
+func (de *dirEntry) IsDir() bool                { return de.n.isDir() }
+func (de *dirEntry) Type() fs.FileMode          { return de.n.mode().Type() }
+func (de *dirEntry) Info() (fs.FileInfo, error) { return de.f.info(de.n) }
+func (de *dirEntry) String() string             { return fs.FormatDirEntry(de) }
+
+// openFile is an open file, whose content is read from storage as it is read.
+type openFile struct {
+	io.ReadSeekCloser
+	info *fileInfo
+}
+
+func (f *openFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// openDir is an open directory.
+type openDir struct {
+	f       *FS
+	info    *fileInfo
+	entries []*node
+	read    int
+}
+
+func (d *openDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *openDir) Close() error               { return nil }
+
+func (d *openDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *openDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.read:]
+	if count > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > 0 && count < len(rest) {
+		rest = rest[:count]
+	}
+	d.read += len(rest)
+	des := make([]fs.DirEntry, len(rest))
+	for i, e := range rest {
+		des[i] = &dirEntry{f: d.f, n: e}
+	}
+	return des, nil
+}
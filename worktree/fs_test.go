@@ -0,0 +1,201 @@
+package worktree
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// testCommitFS returns the file system of a commit of the files of fsys, with
+// objects in the given format.
+func testCommitFS(t *testing.T, fsys fs.FS, at time.Time, format ipldgit.ObjectFormat) *FS {
+	t.Helper()
+	ctx := context.Background()
+	ls, _ := memLinkSystem()
+	tree, err := WriteTree(ctx, ls, fsys, Options{ObjectFormat: format})
+	if err != nil {
+		t.Fatal(err)
+	}
+	me, err := ipldgit.NewPersonInfo("A U Thor", "author@example.com", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, _, err := ipldgit.NewCommitBuilder(format).Tree(tree).Author(me).Committer(me).Message("snapshot\n").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFS(ctx, ls, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFS(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	for _, format := range []ipldgit.ObjectFormat{ipldgit.ObjectFormatSHA1, ipldgit.ObjectFormatSHA256} {
+		t.Run(format.String(), func(t *testing.T) {
+			f := testCommitFS(t, testFS, at, format)
+			if err := fstest.TestFS(f, "hello", "run", "link", "dir/a.txt", "dir.d/b", "deep/a/b/c.txt"); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, test := range []struct {
+				name string
+				mode fs.FileMode
+				size int64
+			}{
+				{".", fs.ModeDir | 0755, 0},
+				{"hello", 0644, 6},
+				{"run", 0755, 6},
+				{"dir", fs.ModeDir | 0755, 0},
+				{"link", 0644, 6},
+			} {
+				fi, err := f.Stat(test.name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if fi.Mode() != test.mode || fi.Size() != test.size || !fi.ModTime().Equal(at) {
+					t.Errorf("%s: got mode %s, size %d, time %s", test.name, fi.Mode(), fi.Size(), fi.ModTime())
+				}
+			}
+			if fi, err := f.Lstat("link"); err != nil || fi.Mode() != fs.ModeSymlink|0777 || fi.Size() != 5 {
+				t.Fatalf("link: got %v, %v", fi, err)
+			}
+			if target, err := f.ReadLink("link"); err != nil || target != "hello" {
+				t.Fatalf("link points to %q: %v", target, err)
+			}
+			if fi, _ := f.Stat("hello"); fi.Sys().(ipldgit.TreeEntry).FieldMode().String() != "100644" {
+				t.Fatalf("hello: Sys is not its tree entry: %v", fi.Sys())
+			}
+			if _, err := f.Open("missing"); !errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("expected not exist, got %v", err)
+			}
+		})
+	}
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	io.Reader
+	n *int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
+
+func TestFSStreaming(t *testing.T) {
+	ctx := context.Background()
+	ls, _ := memLinkSystem()
+	content := strings.Repeat("streamed content\n", 1<<16)
+	c, err := WriteTree(ctx, ls, fstest.MapFS{"big": file(content)}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: c}, ipldgit.Type.Tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read int64
+	open := ls.StorageReadOpener
+	ls.StorageReadOpener = func(lctx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		r, err := open(lctx, lnk)
+		return countingReader{r, &read}, err
+	}
+	f, err := NewFS(ctx, ls, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := f.Stat("big")
+	if err != nil || fi.Size() != int64(len(content)) {
+		t.Fatalf("got %v, %v", fi, err)
+	}
+	if read >= int64(len(content)) {
+		t.Fatalf("stat read %d bytes of a %d byte file", read, len(content))
+	}
+
+	file, err := f.Open("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rs := file.(io.ReadSeeker)
+	if pos, err := rs.Seek(-8, io.SeekEnd); err != nil || pos != int64(len(content))-8 {
+		t.Fatalf("seeked to %d, %v", pos, err)
+	}
+	if got, err := io.ReadAll(rs); err != nil || string(got) != "content\n" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+}
+
+func TestFSSymlinks(t *testing.T) {
+	f := testCommitFS(t, fstest.MapFS{
+		"dir/file": file("data\n"),
+		"up":       {Data: []byte("dir/../dir"), Mode: fs.ModeSymlink},
+		"chain":    {Data: []byte("up/file"), Mode: fs.ModeSymlink},
+		"dir/self": {Data: []byte("."), Mode: fs.ModeSymlink},
+		"escape":   {Data: []byte("../outside"), Mode: fs.ModeSymlink},
+		"absolute": {Data: []byte("/etc/passwd"), Mode: fs.ModeSymlink},
+		"loop":     {Data: []byte("loop"), Mode: fs.ModeSymlink},
+	}, time.Unix(0, 0), ipldgit.ObjectFormatSHA1)
+
+	for _, name := range []string{"up/file", "chain", "dir/self/self/file"} {
+		if data, err := f.ReadFile(name); err != nil || string(data) != "data\n" {
+			t.Errorf("%s: got %q, %v", name, data, err)
+		}
+	}
+	for _, name := range []string{"escape", "absolute", "loop"} {
+		if _, err := f.ReadFile(name); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFSTree(t *testing.T) {
+	ctx := context.Background()
+	ls, _ := memLinkSystem()
+	commit := hashCid(t, "2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51")
+	_, blob, err := ipldgit.BuildBlob([]byte("data\n"), ipldgit.ObjectFormatSHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.Store(ipld.LinkContext{}, cidlink.LinkPrototype{Prefix: ipldgit.ObjectFormatSHA1.Prefix()}, ipldgit.NewBlob([]byte("data\n"))); err != nil {
+		t.Fatal(err)
+	}
+	tree, _, err := ipldgit.NewTreeBuilder(ipldgit.ObjectFormatSHA1).AddSubmodule("sub", commit).AddFile("file", blob, false).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := NewFS(ctx, ls, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(f, "file", "sub"); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := f.ReadDir("sub")
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("submodule is not an empty directory: %v %v", entries, err)
+	}
+	if fi, err := f.Stat("file"); err != nil || !fi.ModTime().IsZero() {
+		t.Fatalf("file of a tree has a modification time: %v %v", fi, err)
+	}
+
+	if _, err := NewFS(ctx, ls, ipldgit.NewBlob(nil)); err == nil {
+		t.Fatal("expected an error making a file system of a blob")
+	}
+}
@@ -0,0 +1,197 @@
+package worktree
+
+import (
+	"path"
+	"strings"
+)
+
+// ignoreFile holds the patterns of a .gitignore file, which apply to the paths
+// below dir.
+type ignoreFile struct {
+	dir      string
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	pattern string
+	// negate re-includes the paths the pattern matches.
+	negate bool
+	// dirOnly matches directories only, for patterns ending with a slash.
+	dirOnly bool
+	// anchored matches the path relative to the directory of the .gitignore
+	// file, for patterns holding a slash, rather than the base name.
+	anchored bool
+}
+
+// parseIgnoreFile parses a .gitignore file of dir, in the format of
+// gitignore(5).
+func parseIgnoreFile(dir string, data []byte) *ignoreFile {
+	f := &ignoreFile{dir: dir}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = trimTrailingSpaces(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		var p ignorePattern
+		if line[0] == '!' {
+			p.negate, line = true, line[1:]
+		} else if line[0] == '\\' && len(line) > 1 && (line[1] == '!' || line[1] == '#') {
+			line = line[1:]
+		}
+		if l, ok := strings.CutSuffix(line, "/"); ok {
+			p.dirOnly, line = true, l
+		}
+		if strings.Contains(line, "/") {
+			p.anchored, line = true, strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		p.pattern = line
+		f.patterns = append(f.patterns, p)
+	}
+	return f
+}
+
+// trimTrailingSpaces removes the spaces ending line, unless escaped with a
+// backslash.
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		if end > 1 && line[end-2] == '\\' {
+			return line[:end-2] + line[end-1:]
+		}
+		end--
+	}
+	return line[:end]
+}
+
+// ignored reports whether name, a path of the file system, is ignored by the
+// .gitignore files of its directory and the directories above it, given from
+// the root down. The last pattern matching decides, and the patterns of a
+// deeper file take precedence.
+func ignored(files []*ignoreFile, name string, isDir bool) bool {
+	for i := len(files) - 1; i >= 0; i-- {
+		f := files[i]
+		rel := name
+		if f.dir != "." {
+			rel = strings.TrimPrefix(name, f.dir+"/")
+		}
+		for j := len(f.patterns) - 1; j >= 0; j-- {
+			p := f.patterns[j]
+			if p.dirOnly && !isDir {
+				continue
+			}
+			target := path.Base(rel)
+			if p.anchored {
+				target = rel
+			}
+			if wildmatch(p.pattern, target) {
+				return !p.negate
+			}
+		}
+	}
+	return false
+}
+
+// wildmatch matches a path against a pattern the way git does, where "*" and
+// "?" do not match slashes, "[...]" matches a character class and "**" as a
+// whole path component matches any number of them.
+func wildmatch(pattern, name string) bool {
+	return match(pattern, 0, name)
+}
+
+func match(p string, pi int, s string) bool {
+	for pi < len(p) {
+		switch c := p[pi]; c {
+		case '*':
+			atStart := pi == 0 || p[pi-1] == '/'
+			if strings.HasPrefix(p[pi:], "**") && atStart && (pi+2 == len(p) || p[pi+2] == '/') {
+				if pi+2 == len(p) {
+					return true
+				}
+				// "**/" matches nothing, or any path components.
+				for i := 0; ; {
+					if match(p, pi+3, s[i:]) {
+						return true
+					}
+					j := strings.IndexByte(s[i:], '/')
+					if j < 0 {
+						return false
+					}
+					i += j + 1
+				}
+			}
+			for pi < len(p) && p[pi] == '*' {
+				pi++
+			}
+			for i := 0; i <= len(s); i++ {
+				if match(p, pi, s[i:]) {
+					return true
+				}
+				if i < len(s) && s[i] == '/' {
+					return false
+				}
+			}
+			return false
+		case '?':
+			if s == "" || s[0] == '/' {
+				return false
+			}
+			pi, s = pi+1, s[1:]
+		case '[':
+			end, ok := matchClass(p, pi, s)
+			if !ok {
+				return false
+			}
+			pi, s = end, s[1:]
+		case '\\':
+			if pi+1 < len(p) {
+				pi++
+			}
+			fallthrough
+		default:
+			if s == "" || s[0] != p[pi] {
+				return false
+			}
+			pi, s = pi+1, s[1:]
+		}
+	}
+	return s == ""
+}
+
+// matchClass matches the first character of s against the class starting at
+// p[pi], returning the index after the class.
+func matchClass(p string, pi int, s string) (int, bool) {
+	if s == "" || s[0] == '/' {
+		return 0, false
+	}
+	c := s[0]
+	i := pi + 1
+	negate := i < len(p) && (p[i] == '!' || p[i] == '^')
+	if negate {
+		i++
+	}
+	matched := false
+	for first := true; i < len(p) && (first || p[i] != ']'); first = false {
+		lo := p[i]
+		if lo == '\\' && i+1 < len(p) {
+			i++
+			lo = p[i]
+		}
+		hi := lo
+		if i+2 < len(p) && p[i+1] == '-' && p[i+2] != ']' {
+			hi = p[i+2]
+			i += 2
+		}
+		if lo <= c && c <= hi {
+			matched = true
+		}
+		i++
+	}
+	if i >= len(p) {
+		// An unterminated class matches nothing.
+		return 0, false
+	}
+	return i + 1, matched != negate
+}
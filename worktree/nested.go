@@ -0,0 +1,133 @@
+package worktree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+)
+
+// maxSymrefs bounds the symbolic refs followed to resolve a HEAD, as git does.
+const maxSymrefs = 5
+
+// nestedRepository returns the commit the HEAD of the git repository in dir
+// names, reporting false if dir holds none. As in git add, dir holds one when
+// its .git is a git directory, or a file naming one with a "gitdir:" line, and
+// a git directory has a HEAD, objects and refs. A repository whose HEAD names
+// no commit is an error, as git refuses to add it.
+func (w *treeWriter) nestedRepository(dir string) (cid.Cid, bool, error) {
+	gitdir := path.Join(dir, ".git")
+	fi, err := fs.Stat(w.fsys, gitdir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cid.Undef, false, nil
+	}
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	if !fi.IsDir() {
+		data, err := fs.ReadFile(w.fsys, gitdir)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		target, ok := strings.CutPrefix(strings.TrimRight(string(data), "\r\n"), "gitdir: ")
+		if !ok {
+			return cid.Undef, false, nil
+		}
+		if path.IsAbs(target) || !fs.ValidPath(path.Join(dir, target)) {
+			return cid.Undef, false, fmt.Errorf("%s: git directory %q is outside the file system", dir, target)
+		}
+		gitdir = path.Join(dir, target)
+	}
+
+	// The refs and objects of a linked worktree are in its common directory.
+	common := gitdir
+	if data, err := fs.ReadFile(w.fsys, path.Join(gitdir, "commondir")); err == nil {
+		dir := strings.TrimRight(string(data), "\r\n")
+		if path.IsAbs(dir) || !fs.ValidPath(path.Join(gitdir, dir)) {
+			return cid.Undef, false, fmt.Errorf("%s: common directory %q is outside the file system", gitdir, dir)
+		}
+		common = path.Join(gitdir, dir)
+	}
+	if !w.isFile(path.Join(gitdir, "HEAD")) || !w.isDir(path.Join(common, "objects")) || !w.isDir(path.Join(common, "refs")) {
+		return cid.Undef, false, nil
+	}
+
+	c, err := w.resolveHead(gitdir, common)
+	if err != nil {
+		return cid.Undef, false, fmt.Errorf("%s: %w", dir, err)
+	}
+	return c, true, nil
+}
+
+func (w *treeWriter) isFile(name string) bool {
+	fi, err := fs.Stat(w.fsys, name)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func (w *treeWriter) isDir(name string) bool {
+	fi, err := fs.Stat(w.fsys, name)
+	return err == nil && fi.IsDir()
+}
+
+// resolveHead follows the HEAD of a git directory to the commit it names,
+// looking refs up as loose files and then in packed-refs. HEAD and the other
+// per-worktree refs are in gitdir, and the shared refs in common.
+func (w *treeWriter) resolveHead(gitdir, common string) (cid.Cid, error) {
+	ref := "HEAD"
+	for range maxSymrefs {
+		dir := common
+		if ref == "HEAD" {
+			dir = gitdir
+		}
+		value, err := w.readRef(dir, common, ref)
+		if err != nil {
+			return cid.Undef, err
+		}
+		if value == "" {
+			return cid.Undef, fmt.Errorf("no commit checked out: %s does not exist", ref)
+		}
+		if target, ok := strings.CutPrefix(value, "ref: "); ok {
+			ref = target
+			continue
+		}
+		sha, err := hex.DecodeString(value)
+		if err != nil || len(sha) != w.opts.ObjectFormat.Size() {
+			return cid.Undef, fmt.Errorf("%s is not a %s commit: %q", ref, w.opts.ObjectFormat, value)
+		}
+		return w.opts.ObjectFormat.Cid(sha)
+	}
+	return cid.Undef, fmt.Errorf("too many levels of symbolic refs from HEAD")
+}
+
+// readRef returns the value of the ref name: a hash, or "ref: " and the name
+// of the ref it points to, or "" if it does not exist.
+func (w *treeWriter) readRef(dir, common, name string) (string, error) {
+	if !fs.ValidPath(name) || (name != "HEAD" && !strings.HasPrefix(name, "refs/")) {
+		return "", fmt.Errorf("invalid ref %q", name)
+	}
+	data, err := fs.ReadFile(w.fsys, path.Join(dir, name))
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	packed, err := fs.ReadFile(w.fsys, path.Join(common, "packed-refs"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	for sc := bufio.NewScanner(bytes.NewReader(packed)); sc.Scan(); {
+		sha, ref, ok := strings.Cut(sc.Text(), " ")
+		if ok && ref == name {
+			return sha, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,189 @@
+// Package worktree moves git trees between the ipldgit codec and file systems:
+// it hashes a directory into blobs and trees as git would record it, checks
+// trees out into directories, and serves trees as an fs.FS.
+package worktree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// Options configures how a file system is hashed into trees.
+type Options struct {
+	// ObjectFormat is the format of the objects written. ObjectFormatDefault
+	// writes SHA-1 objects.
+	ObjectFormat ipldgit.ObjectFormat
+	// Gitignore leaves out the files and directories the .gitignore files of
+	// the file system ignore, as git add does.
+	Gitignore bool
+}
+
+// WriteTree stores the files of fsys as blobs and trees with ls, and returns
+// the CID of the root tree, which is the tree git write-tree gives after adding
+// the same files to an empty index.
+//
+// As in git, files have mode 100755 when executable by their owner and 100644
+// otherwise, symbolic links store their target, which requires fsys to be a
+// fs.ReadLinkFS, and directories without files are left out. Entries named
+// .git are left out too. A directory holding a git repository of its own, in
+// a .git directory or a .git file naming one, is recorded as a gitlink to the
+// commit its HEAD names, as git add records it, and is an error if HEAD names
+// no commit yet. Other kinds of files are an error.
+func WriteTree(ctx context.Context, ls ipld.LinkSystem, fsys fs.FS, opts Options) (cid.Cid, error) {
+	w := &treeWriter{ctx: ctx, ls: ls, fsys: fsys, opts: opts}
+	switch opts.ObjectFormat {
+	case ipldgit.ObjectFormatDefault:
+		w.opts.ObjectFormat = ipldgit.ObjectFormatSHA1
+	case ipldgit.ObjectFormatSHA1, ipldgit.ObjectFormatSHA256:
+	default:
+		return cid.Undef, fmt.Errorf("unsupported object format: %s", opts.ObjectFormat)
+	}
+	c, _, err := w.writeDir(".", nil)
+	return c, err
+}
+
+// WriteTreeDir is WriteTree over the directory dir of the operating system.
+func WriteTreeDir(ctx context.Context, ls ipld.LinkSystem, dir string, opts Options) (cid.Cid, error) {
+	return WriteTree(ctx, ls, os.DirFS(dir), opts)
+}
+
+type treeWriter struct {
+	ctx  context.Context
+	ls   ipld.LinkSystem
+	fsys fs.FS
+	opts Options
+}
+
+func (w *treeWriter) store(n ipld.Node) (cid.Cid, error) {
+	lp := cidlink.LinkPrototype{Prefix: w.opts.ObjectFormat.Prefix()}
+	lnk, err := w.ls.Store(ipld.LinkContext{Ctx: w.ctx}, lp, n)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return lnk.(cidlink.Link).Cid, nil
+}
+
+// writeDir stores the tree of dir, reporting false if it has no entries, in
+// which case it is only stored for the root.
+func (w *treeWriter) writeDir(dir string, ignores []*ignoreFile) (cid.Cid, bool, error) {
+	if err := w.ctx.Err(); err != nil {
+		return cid.Undef, false, err
+	}
+	entries, err := fs.ReadDir(w.fsys, dir)
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	if w.opts.Gitignore {
+		data, err := fs.ReadFile(w.fsys, path.Join(dir, ".gitignore"))
+		switch {
+		case err == nil:
+			ignores = append(ignores[:len(ignores):len(ignores)], parseIgnoreFile(dir, data))
+		case !errors.Is(err, fs.ErrNotExist):
+			return cid.Undef, false, err
+		}
+	}
+
+	tb := ipldgit.NewTreeBuilder(w.opts.ObjectFormat)
+	empty := true
+	for _, e := range entries {
+		name := path.Join(dir, e.Name())
+		if strings.EqualFold(e.Name(), ".git") || ignored(ignores, name, e.IsDir()) {
+			continue
+		}
+
+		switch typ := e.Type(); {
+		case typ.IsDir():
+			if c, ok, err := w.nestedRepository(name); err != nil {
+				return cid.Undef, false, err
+			} else if ok {
+				tb.AddSubmodule(e.Name(), c)
+				empty = false
+				continue
+			}
+			c, ok, err := w.writeDir(name, ignores)
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			if ok {
+				tb.AddDir(e.Name(), c)
+			}
+			empty = empty && !ok
+			continue
+		case typ&fs.ModeSymlink != 0:
+			rfs, ok := w.fsys.(fs.ReadLinkFS)
+			if !ok {
+				return cid.Undef, false, fmt.Errorf("%s: symbolic link in a file system that cannot read links", name)
+			}
+			target, err := rfs.ReadLink(name)
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			c, err := w.store(ipldgit.NewBlob([]byte(target)))
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			tb.AddSymlink(e.Name(), c)
+		case typ.IsRegular():
+			info, err := e.Info()
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			c, err := w.writeFile(name, info.Size())
+			if err != nil {
+				return cid.Undef, false, err
+			}
+			tb.AddFile(e.Name(), c, info.Mode()&0100 != 0)
+		default:
+			return cid.Undef, false, fmt.Errorf("%s: unsupported file type %s", name, typ)
+		}
+		empty = false
+	}
+	if empty && dir != "." {
+		return cid.Undef, false, nil
+	}
+
+	tree, _, err := tb.Build()
+	if err != nil {
+		return cid.Undef, false, fmt.Errorf("%s: %w", dir, err)
+	}
+	c, err := w.store(tree)
+	return c, !empty, err
+}
+
+// writeFile stores the blob of a file, streaming its content rather than
+// holding it in memory.
+func (w *treeWriter) writeFile(name string, size int64) (cid.Cid, error) {
+	blob, err := ipldgit.OpenBlob(func() (io.ReadCloser, error) {
+		f, err := w.fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		hdr := strings.NewReader(fmt.Sprintf("blob %d\x00", size))
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(hdr, f), f}, nil
+	})
+	if err != nil {
+		return cid.Undef, err
+	}
+	return w.store(blob)
+}
+
+// gitLinkSystem returns ls decoding git objects in the object format of their
+// links, as the decoder of the multicodec registry only reads SHA-1 objects.
+func gitLinkSystem(ls ipld.LinkSystem) ipld.LinkSystem {
+	ls.DecoderChooser = ipldgit.DecoderChooser
+	return ls
+}
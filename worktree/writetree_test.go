@@ -0,0 +1,300 @@
+package worktree
+
+import (
+	"context"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ipfs/go-cid"
+	ipldgit "github.com/ipfs/go-ipld-git"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+)
+
+func memLinkSystem() (ipld.LinkSystem, *memstore.Store) {
+	ls := cidlink.DefaultLinkSystem()
+	store := &memstore.Store{}
+	ls.SetReadStorage(store)
+	ls.SetWriteStorage(store)
+	return ls, store
+}
+
+func hashCid(t *testing.T, h string) cid.Cid {
+	t.Helper()
+	sha, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := ipldgit.ObjectFormatSHA1.Cid(sha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// The trees git write-tree gives for testFS after git add -A, which follows
+// the .gitignore files, and git add -A -f, which does not.
+const (
+	testFSIgnored = "21b8a74bf0aa4d1dc5d836a7018a0cb51dab6b12"
+	testFSAll     = "bd50a9f1b42f1f8a93334796dca4c02bd326a185"
+)
+
+func file(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data), Mode: 0644}
+}
+
+var testFS = fstest.MapFS{
+	"hello":          file("hello\n"),
+	"run":            {Data: []byte("hello\n"), Mode: 0755},
+	"link":           {Data: []byte("hello"), Mode: fs.ModeSymlink | 0777},
+	"dir/a.txt":      file("a\n"),
+	"dir.d/b":        file("b\n"),
+	"empty":          {Mode: fs.ModeDir | 0755},
+	".gitignore":     file("*.log\n/build/\n!keep.log\nlogs/**\n**/b/*.tmp\n[xy].txt\n\\#hash\ntrailing \n"),
+	"dir/.gitignore": file("a.txt\n!build\n"),
+	"x.log":          file("x\n"),
+	"keep.log":       file("k\n"),
+	"build/out":      file("o\n"),
+	"dir/build/out":  file("o\n"),
+	"logs/l":         file("l\n"),
+	"deep/a/b/c.tmp": file("t\n"),
+	"deep/a/b/c.txt": file("u\n"),
+	"x.txt":          file("x\n"),
+	"z.txt":          file("z\n"),
+	"#hash":          file("h\n"),
+	"trailing":       file("h\n"),
+	"sub/.git/HEAD":  file("ref: refs/heads/main\n"),
+}
+
+func TestWriteTree(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"All", Options{}, testFSAll},
+		{"Gitignore", Options{Gitignore: true}, testFSIgnored},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ls, store := memLinkSystem()
+			got, err := WriteTree(context.Background(), ls, testFS, test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := hashCid(t, test.want); !got.Equals(want) {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+			// Every object is stored, so the root tree can be loaded.
+			if _, err := store.Get(context.Background(), got.KeyString()); err != nil {
+				t.Fatal(err)
+			}
+			blob := hashCid(t, "ce013625030ba8dba906f756967f9e9ca394464a")
+			if _, err := store.Get(context.Background(), blob.KeyString()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWriteTreeDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, f := range testFS {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		var err error
+		switch {
+		case f.Mode.IsDir():
+			err = os.MkdirAll(path, 0755)
+		case f.Mode&fs.ModeSymlink != 0:
+			err = os.Symlink(string(f.Data), path)
+		default:
+			if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+				err = os.WriteFile(path, f.Data, f.Mode)
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ls, _ := memLinkSystem()
+	got, err := WriteTreeDir(context.Background(), ls, dir, Options{Gitignore: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := hashCid(t, testFSIgnored); !got.Equals(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+// loadEntry loads the root tree c and returns its entry name.
+func loadEntry(t *testing.T, ls ipld.LinkSystem, c cid.Cid, name string) ipldgit.TreeEntry {
+	t.Helper()
+	n, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: c}, ipldgit.Type.Tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := ipldgit.Type.String.FromString(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	te := n.(ipldgit.Tree).Lookup(k)
+	if te == nil {
+		t.Fatalf("tree has no entry %q", name)
+	}
+	return te
+}
+
+func TestWriteTreeNestedRepository(t *testing.T) {
+	const head = "2ee5e3bd7b1c4d0e6e1bcb3c2c0e6e9e8f3b7a51"
+	repo := fstest.MapFS{
+		"sub/s":                        file("s\n"),
+		"sub/.git/objects":             {Mode: fs.ModeDir | 0755},
+		"sub/.git/refs/heads/main":     file(head + "\n"),
+		"sub/.git/HEAD":                file("ref: refs/heads/main\n"),
+		"other/.git/HEAD":              file("ref: refs/heads/main\n"),
+		"other/f":                      file("f\n"),
+		"packed/.git/objects":          {Mode: fs.ModeDir | 0755},
+		"packed/.git/refs":             {Mode: fs.ModeDir | 0755},
+		"packed/.git/HEAD":             file("ref: refs/heads/main\n"),
+		"packed/.git/packed-refs":      file("# pack-refs with: peeled fully-peeled sorted\n" + head + " refs/heads/main\n"),
+		"detached/.git/objects":        {Mode: fs.ModeDir | 0755},
+		"detached/.git/refs":           {Mode: fs.ModeDir | 0755},
+		"detached/.git/HEAD":           file(head + "\n"),
+		"gitfile/.git":                 file("gitdir: ../.git/modules/gitfile\n"),
+		".git/modules/gitfile/objects": {Mode: fs.ModeDir | 0755},
+		".git/modules/gitfile/refs":    {Mode: fs.ModeDir | 0755},
+		".git/modules/gitfile/HEAD":    file(head + "\n"),
+		"unborn/.git/objects":          {Mode: fs.ModeDir | 0755},
+		"unborn/.git/refs":             {Mode: fs.ModeDir | 0755},
+		"unborn/.git/HEAD":             file("ref: refs/heads/main\n"),
+		"unborn/u":                     file("u\n"),
+	}
+	ls, _ := memLinkSystem()
+	if _, err := WriteTree(context.Background(), ls, repo, Options{}); err == nil || !strings.Contains(err.Error(), "no commit checked out") {
+		t.Fatalf("expected an error adding a repository without commits, got %v", err)
+	}
+	delete(repo, "unborn/u")
+	delete(repo, "unborn/.git/objects")
+
+	c, err := WriteTree(context.Background(), ls, repo, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"sub", "packed", "detached", "gitfile"} {
+		te := loadEntry(t, ls, c, name)
+		cl, err := te.CommitLink()
+		if err != nil {
+			t.Fatalf("%s is not a gitlink: %v", name, err)
+		}
+		if want := hashCid(t, head); !cl.Link().(cidlink.Link).Cid.Equals(want) {
+			t.Errorf("%s links to %s, want %s", name, cl.Link(), want)
+		}
+	}
+	// A .git holding no repository is left out, and the rest of the directory
+	// is a tree.
+	if kind, err := loadEntry(t, ls, c, "other").EntryKind(); err != nil || kind != ipldgit.EntryDirectory {
+		t.Fatalf("other is a %v, %v", kind, err)
+	}
+}
+
+func TestWriteTreeDirNestedRepository(t *testing.T) {
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command(git, append([]string{"-c", "user.name=A U Thor", "-c", "user.email=author@example.com", "-c", "commit.gpgsign=false", "-c", "advice.addEmbeddedRepo=false"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", args, err, out)
+		}
+	}
+	sub := filepath.Join(dir, "sub")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"a": "a\n", "sub/s": "s\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run(sub, "init", "-q")
+	run(sub, "add", ".")
+	run(sub, "commit", "-q", "-m", "nested")
+	run(dir, "init", "-q")
+	run(dir, "add", "-A")
+	cmd := exec.Command(git, "write-tree")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls, _ := memLinkSystem()
+	got, err := WriteTreeDir(context.Background(), ls, dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := hashCid(t, strings.TrimSpace(string(out))); !got.Equals(want) {
+		t.Fatalf("got %s, want the tree of git write-tree %s", got, want)
+	}
+}
+
+func TestWriteTreeEmpty(t *testing.T) {
+	for _, test := range []struct {
+		format ipldgit.ObjectFormat
+		want   string
+	}{
+		{ipldgit.ObjectFormatSHA1, "4b825dc642cb6eb9a060e54bf8d69288fbee4904"},
+		{ipldgit.ObjectFormatSHA256, "6ef19b41225c5369f1c104d45d8d85efa9b057b53b14b4b9b939dd74decc5321"},
+	} {
+		ls, _ := memLinkSystem()
+		got, err := WriteTree(context.Background(), ls, fstest.MapFS{"empty": {Mode: fs.ModeDir}}, Options{ObjectFormat: test.format})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sha, err := test.format.Sha(got)
+		if err != nil || hex.EncodeToString(sha) != test.want {
+			t.Errorf("got %s, want the empty %s tree", got, test.format)
+		}
+	}
+}
+
+func TestWildmatch(t *testing.T) {
+	for _, test := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.log", "a.log", true},
+		{"*.log", "a/b.log", false},
+		{"a?c", "abc", true},
+		{"a?c", "a/c", false},
+		{"**/b", "b", true},
+		{"**/b", "a/x/b", true},
+		{"a/**", "a/x/y", true},
+		{"a/**", "a", false},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a**b", "axxb", true},
+		{"a**b", "ax/b", false},
+		{"[a-c]x", "bx", true},
+		{"[!a-c]x", "bx", false},
+		{"[]]", "]", true},
+		{"\\*", "*", true},
+		{"\\*", "a", false},
+		{"[abc", "a", false},
+	} {
+		if got := wildmatch(test.pattern, test.name); got != test.want {
+			t.Errorf("wildmatch(%q, %q) = %v, want %v", test.pattern, test.name, got, test.want)
+		}
+	}
+}